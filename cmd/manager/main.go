@@ -5,9 +5,15 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
 	"github.com/personio/newrelic-alert-manager/pkg"
+	"github.com/personio/newrelic-alert-manager/pkg/health"
+	configcontroller "github.com/personio/newrelic-alert-manager/pkg/operator_config/controller"
+	configdomain "github.com/personio/newrelic-alert-manager/pkg/operator_config/domain"
 	"os"
+	"path/filepath"
 	"runtime"
+	"time"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
@@ -23,19 +29,37 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
 
-// Change below variables to serve metrics on different host or port.
+// Change below variables to serve metrics on a different host.
+// The ports are overridden by the operator configuration.
 var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
 )
+
+var (
+	maxConcurrentReconciles = pflag.StringToInt("max-concurrent-reconciles", map[string]int{}, "Number of parallel reconciles per controller, e.g. newrelic-alert-policy-controller=4")
+	requestsPerSecond       = pflag.Float64("newrelic-requests-per-second", 10, "Number of requests per second all controllers together may send to a single New Relic account")
+	requestBurst            = pflag.Int("newrelic-request-burst", 20, "Number of requests which may exceed the per second limit in short bursts")
+	configFile              = pflag.String("config-file", "", "Path to a file containing an OperatorConfig manifest. Changes to the file are applied without a restart")
+	healthProbeBindAddress  = pflag.String("health-probe-bind-address", ":8081", "The address serving the /healthz and /readyz endpoints")
+	webhookPort             = pflag.Int("webhook-port", 9443, "The port serving the admission and conversion webhooks")
+	webhookCertDir          = pflag.String("webhook-cert-dir", "/etc/webhook/certs", "The directory holding the tls.crt and tls.key files of the webhook server. Webhooks are only served when the files exist, which requires --conversion-webhook=false")
+	configName              = pflag.String("config-name", "", "Name of the cluster-scoped OperatorConfig resource holding the operator configuration")
+	rolloutMuting           = pflag.Bool("rollout-muting", false, "Mute the alerts of annotated Deployments and StatefulSets while they are rolled out")
+	conversionWebhook       = pflag.Bool("conversion-webhook", true, "Serve the conversion webhook the multi-version CRDs in deploy/crds point to. The operator does not start without the webhook certificates while it is enabled")
+	reconcileStallTimeout   = pflag.Duration("reconcile-stall-timeout", 10*time.Minute, "The time after which a reconcile which did not return fails the liveness probe")
+)
 var log = logf.Log.WithName("cmd")
 
 func printVersion() {
@@ -75,6 +99,43 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *configFile != "" && *configName != "" {
+		log.Error(errors.New("only one of --config-file and --config-name can be set"), "")
+		os.Exit(1)
+	}
+	configSource := configcontroller.Source{
+		File: *configFile,
+		Name: *configName,
+	}
+	configStore, err := loadConfig(cfg, configSource)
+	if err != nil {
+		log.Error(err, "Invalid operator configuration")
+		os.Exit(1)
+	}
+	metricsPort = configStore.Get().MetricsPort
+	operatorMetricsPort = configStore.Get().OperatorMetricsPort
+
+	// The API server cannot convert between the versions of the CRDs without the webhook server
+	if *conversionWebhook && !webhookCertsExist(*webhookCertDir) {
+		log.Error(errors.New("no webhook certificates found"), "The conversion webhook requires the webhook certificates, which cert-manager issues for deploy/4-webhook_certificate.yaml. "+
+			"Start the operator with --conversion-webhook=false only when the CRDs do not convert between versions", "CertDir", *webhookCertDir)
+		os.Exit(1)
+	}
+
+	progress := health.NewProgress(*reconcileStallTimeout)
+	controllerOptions := internal.ControllerOptions{
+		MaxConcurrentReconciles: *maxConcurrentReconciles,
+		RateLimiters:            internal.NewRateLimiterRegistry(*requestsPerSecond, *requestBurst),
+		Settings:                configStore,
+		RolloutMuting:           *rolloutMuting,
+		TrackReconciler:         progress.Track,
+	}
+
+	// Serve the health probes while waiting for the leader lock
+	newrelicCheck := health.NewNewrelicCheck(controllerOptions.NewRestApiClient(log), configStore.RestApi)
+	probes := health.NewProbes(log, newrelicCheck, progress)
+	probes.Serve(*healthProbeBindAddress)
+
 	ctx := context.TODO()
 	// Become the leader before proceeding
 	err = leader.Become(ctx, "newrelic-alert-manager-lock")
@@ -82,11 +143,14 @@ func main() {
 		log.Error(err, "")
 		os.Exit(1)
 	}
+	probes.SetLeader()
 
 	// Create a new Cmd to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, manager.Options{
 		Namespace:          "",
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		Port:               *webhookPort,
+		CertDir:            *webhookCertDir,
 	})
 	if err != nil {
 		log.Error(err, "")
@@ -101,12 +165,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Reload the operator configuration when it changes
+	if err := configcontroller.Add(mgr, configStore, configSource); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Report the cache state in the readiness probe
+	if err := mgr.Add(probes.WaitForCacheSync(mgr.GetCache())); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Setup all Controllers
-	if err := pkg.RegisterControllers(mgr); err != nil {
+	if err := pkg.RegisterControllers(mgr, controllerOptions); err != nil {
 		log.Error(err, "")
 		os.Exit(1)
 	}
 
+	// Setup all Webhooks
+	if webhookCertsExist(*webhookCertDir) {
+		pkg.RegisterWebhooks(mgr)
+	} else {
+		log.Info("No webhook certificates found, skipping webhook server creation", "CertDir", *webhookCertDir)
+	}
+
 	// Add the Metrics Service
 	addMetrics(ctx, cfg, "")
 
@@ -119,6 +202,40 @@ func main() {
 	}
 }
 
+// loadConfig reads the operator configuration before the manager is started,
+// since the metrics ports are needed to create the manager
+func loadConfig(cfg *rest.Config, configSource configcontroller.Source) (*configdomain.Store, error) {
+	scheme := k8sruntime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	reader, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := configcontroller.LoadConfig(reader, configSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return configdomain.NewStore(*config), nil
+}
+
+func webhookCertsExist(certDir string) bool {
+	for _, name := range []string{"tls.crt", "tls.key"} {
+		if _, err := os.Stat(filepath.Join(certDir, name)); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 // addMetrics will create the Services and Service Monitors to allow the operator export the metrics by using
 // the Prometheus operator
 func addMetrics(ctx context.Context, cfg *rest.Config, namespace string) {
@@ -0,0 +1,157 @@
+// nrql-lint checks NRQL queries without sending them to New Relic.
+//
+// It either lints a single query passed with --query, or all queries
+// in the AlertPolicy and Dashboard manifests passed as arguments:
+//
+//	nrql-lint --query "SELECT count(*) FROM Transaction"
+//	nrql-lint --query "SELECT count(*) FROM Transaction TIMESERIES" --visualization line_chart
+//	nrql-lint hack/examples/alertpolicy_nrql.yaml hack/examples/dashboard_cr.yaml
+//
+// The exit code is 1 when any query has an error, and 2 when the input cannot be read.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	alerts "github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	dashboards "github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/nrql"
+	"github.com/spf13/pflag"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"os"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	query         = pflag.String("query", "", "A single query to lint instead of reading manifests")
+	visualization = pflag.String("visualization", "", "Lint the query as the data of a widget with this visualization. Queries are linted as alert conditions when empty")
+	quiet         = pflag.Bool("quiet", false, "Only report errors")
+)
+
+// queryRef is a query found in a manifest
+type queryRef struct {
+	location string
+	query    string
+	rules    []nrql.Rule
+}
+
+func main() {
+	pflag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--query QUERY [--visualization VISUALIZATION]] [MANIFEST...]\n", os.Args[0])
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+
+	var queries []queryRef
+	if *query != "" {
+		queries = append(queries, queryRef{location: "query", query: *query, rules: rulesFor(*visualization)})
+	}
+
+	for _, path := range pflag.Args() {
+		found, err := readManifests(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(2)
+		}
+		queries = append(queries, found...)
+	}
+
+	if len(queries) == 0 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, ref := range queries {
+		for _, problem := range nrql.Lint(ref.query, ref.rules) {
+			if problem.Severity == nrql.SeverityError {
+				failed = true
+			} else if *quiet {
+				continue
+			}
+			fmt.Printf("%s:%s\n", ref.location, problem)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func rulesFor(visualization string) []nrql.Rule {
+	if visualization == "" {
+		return nrql.AlertConditionRules()
+	}
+
+	return nrql.WidgetRules(visualization)
+}
+
+// readManifests returns the queries of all alert policies and dashboards in a file.
+// Other resources in the file are skipped
+func readManifests(path string) ([]queryRef, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var queries []queryRef
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(file))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return queries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := readManifest(path, document)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, found...)
+	}
+}
+
+func readManifest(path string, document []byte) ([]queryRef, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	var queries []queryRef
+	switch typeMeta.Kind {
+	case "AlertPolicy":
+		var policy alerts.AlertPolicy
+		if err := yaml.Unmarshal(document, &policy); err != nil {
+			return nil, err
+		}
+		for i, condition := range policy.Spec.NrqlConditions {
+			queries = append(queries, queryRef{
+				location: fmt.Sprintf("%s: AlertPolicy/%s: spec.nrqlConditions[%d].query", path, policy.Name, i),
+				query:    condition.Query,
+				rules:    condition.QueryRules(),
+			})
+		}
+	case "Dashboard":
+		var dashboard dashboards.Dashboard
+		if err := yaml.Unmarshal(document, &dashboard); err != nil {
+			return nil, err
+		}
+		for i, widget := range dashboard.Spec.Widgets {
+			if widget.Data.Nrql == "" {
+				continue
+			}
+			queries = append(queries, queryRef{
+				location: fmt.Sprintf("%s: Dashboard/%s: spec.widgets[%d].data.nrql", path, dashboard.Name, i),
+				query:    widget.Data.Nrql,
+				rules:    nrql.WidgetRules(widget.Visualization),
+			})
+		}
+	}
+
+	return queries, nil
+}
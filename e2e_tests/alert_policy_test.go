@@ -61,7 +61,7 @@ func TestCreateAlertPolicy_ApplicationDoesNotExist(t *testing.T) {
 	}
 	t.Log("Successfully created alert policy")
 
-	if policy.Status.Status != "Error" {
+	if !policy.Status.IsError() {
 		t.Error("Resource's Status.Status should be Error")
 	}
 
@@ -85,6 +85,37 @@ func TestCreateAlertPolicy_ApplicationDoesNotExist(t *testing.T) {
 	t.Log("Successfully deleted alert policy")
 }
 
+func TestCreateAlertPolicy_BaselineNrqlCondition(t *testing.T) {
+	ctx := initializeTestResources(t, &v1alpha1.AlertPolicyList{})
+
+	policy := newBaselineNrqlAlertPolicy("test-baseline-policy")
+	err := framework.Global.Client.Create(context.TODO(), policy, cleanupOptions(ctx))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = waitForResource(t, framework.Global.Client.Client, policy, isAlertPolicyReady)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Log("Successfully created alert policy with a baseline condition")
+
+	if policy.Status.NewrelicId == nil {
+		t.Error("Resource's NewrelicId should not be null")
+	}
+
+	err = framework.Global.Client.Delete(context.TODO(), policy)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	err = e2eutil.WaitForDeletion(t, framework.Global.Client.Client, policy, pollInterval, pollTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("Successfully deleted alert policy")
+}
+
 func TestCreateMultipleAlertPolicies(t *testing.T) {
 	ctx := initializeTestResources(t, &v1alpha1.AlertPolicyList{})
 
@@ -217,6 +248,31 @@ func newApmAlertPolicy() *v1alpha1.AlertPolicy {
 	}
 }
 
+func newBaselineNrqlAlertPolicy(name string) *v1alpha1.AlertPolicy {
+	conditionType := "baseline"
+	baselineDirection := "upper_and_lower"
+
+	policy := newAlertPolicy(name)
+	policy.Spec.NrqlConditions = []v1alpha1.NrqlCondition{
+		{
+			Name:              "throughput",
+			Type:              &conditionType,
+			BaselineDirection: &baselineDirection,
+			Query:             "SELECT count(*) FROM Transaction",
+			Since:             5,
+			ValueFunction:     "single_value",
+			AlertThreshold: v1alpha1.Threshold{
+				TimeFunction:    "all",
+				Operator:        "above",
+				Value:           "3",
+				DurationMinutes: 10,
+			},
+		},
+	}
+
+	return policy
+}
+
 func isAlertPolicyReady(t *testing.T, obj runtime.Object) bool {
 	policy, ok := obj.(*v1alpha1.AlertPolicy)
 	if !ok {
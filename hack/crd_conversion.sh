@@ -0,0 +1,13 @@
+#!/bin/bash
+# Adds the conversion webhook to CRDs which serve more than one version.
+# operator-sdk generate crds has no conversion settings, so this runs after it
+set -e
+for crd in "$@"; do
+  if [ "$(grep -c '^  - name: v' "$crd")" -lt 2 ]; then
+    continue
+  fi
+  sed -i \
+    -e 's|^metadata:$|metadata:\n  annotations:\n    cert-manager.io/inject-ca-from: newrelic-alert-manager/newrelic-alert-manager-webhook|' \
+    -e 's|^spec:$|spec:\n  conversion:\n    strategy: Webhook\n    webhookClientConfig:\n      service:\n        name: newrelic-alert-manager-webhook\n        namespace: newrelic-alert-manager\n        path: /convert\n  preserveUnknownFields: false|' \
+    "$crd"
+done
@@ -1,5 +1,7 @@
 package internal
 
+import "errors"
+
 type ClientError struct {
 	message string
 }
@@ -15,6 +17,6 @@ func (err ClientError) Error() string {
 }
 
 func IsClientError(err error) bool {
-	_, ok := err.(ClientError)
-	return ok
+	var clientError ClientError
+	return errors.As(err, &clientError)
 }
\ No newline at end of file
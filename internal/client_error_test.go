@@ -33,4 +33,12 @@ func TestIsRetryableError_RetryableError(t *testing.T) {
 	if !isRetryable {
 		t.Error("Generic error should not be a client error")
 	}
-}
\ No newline at end of file
+}
+func TestIsRetryableError_WrappedClientError(t *testing.T) {
+	err := fmt.Errorf("saving failed: %w", internal.NewClientError("something went wrong"))
+
+	isRetryable := internal.IsClientError(err)
+	if !isRetryable {
+		t.Error("Wrapped client error should be a client error")
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
+)
+
+// ControllerOptions holds the settings shared by all controllers
+type ControllerOptions struct {
+	// MaxConcurrentReconciles maps a controller name to the number of reconciles it may run in parallel.
+	// Controllers which are not listed reconcile one object at a time.
+	MaxConcurrentReconciles map[string]int
+	// RateLimiters limits the requests sent to each New Relic account across all controllers
+	RateLimiters *RateLimiterRegistry
+	// Settings holds the current operator configuration
+	Settings Settings
+	// RolloutMuting enables the controllers which mute the alerts of annotated Deployments and StatefulSets during rollouts
+	RolloutMuting bool
+	// TrackReconciler wraps the reconciler of every controller, e.g. to report stalled reconciles in the liveness probe
+	TrackReconciler func(controllerName string, reconciler reconcile.Reconciler) reconcile.Reconciler
+}
+
+func (options ControllerOptions) ForController(controllerName string, reconciler reconcile.Reconciler) controller.Options {
+	maxConcurrentReconciles, ok := options.MaxConcurrentReconciles[controllerName]
+	if !ok || maxConcurrentReconciles < 1 {
+		maxConcurrentReconciles = 1
+	}
+	if options.TrackReconciler != nil {
+		reconciler = options.TrackReconciler(controllerName, reconciler)
+	}
+
+	return controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+	}
+}
+
+// NewRestApiClient creates a client for the New Relic REST API
+func (options ControllerOptions) NewRestApiClient(log logr.Logger) NewrelicClient {
+	return options.newNewrelicClient(log, options.Settings.RestApi)
+}
+
+// NewInfraApiClient creates a client for the New Relic Infrastructure API
+func (options ControllerOptions) NewInfraApiClient(log logr.Logger) NewrelicClient {
+	return options.newNewrelicClient(log, options.Settings.InfraApi)
+}
+
+// NewSyntheticsApiClient creates a client for the New Relic Synthetics API
+func (options ControllerOptions) NewSyntheticsApiClient(log logr.Logger) NewrelicClient {
+	return options.newNewrelicClient(log, options.Settings.SyntheticsApi)
+}
+
+// NewNerdGraphClient creates a client for the New Relic NerdGraph API
+func (options ControllerOptions) NewNerdGraphClient(log logr.Logger) NerdGraphClient {
+	return NewNerdGraphClient(options.newNewrelicClient(log, options.Settings.NerdGraphApi), options.Settings.AccountId)
+}
+
+// newNewrelicClient creates a client which draws from the request budget of the configured account
+func (options ControllerOptions) newNewrelicClient(log logr.Logger, settings func() ClientSettings) NewrelicClient {
+	client := NewConfigurableNewrelicClient(log, settings)
+	if options.RateLimiters == nil {
+		return client
+	}
+
+	return rateLimitedClient{
+		client: client,
+		limiter: func() *rate.Limiter {
+			return options.RateLimiters.ForAccount(options.Settings.AccountId(), settings().AdminKey)
+		},
+	}
+}
+
+// NewReconcileResult works like the NewReconcileResult function,
+// but uses the configured requeue intervals and resyncs successfully reconciled resources
+func (options ControllerOptions) NewReconcileResult(err error) (reconcile.Result, error) {
+	if err == nil {
+		return reconcile.Result{RequeueAfter: options.Settings.ResyncInterval()}, nil
+	}
+
+	return newReconcileResult(err, options.Settings.ErrorRequeueInterval())
+}
+
+// NewRetryResult requeues a resource after the error requeue interval, whatever caused it to fail.
+// It is used when retrying is needed to bring New Relic back into a consistent state, even after a client error
+func (options ControllerOptions) NewRetryResult() (reconcile.Result, error) {
+	return reconcile.Result{RequeueAfter: options.Settings.ErrorRequeueInterval()}, nil
+}
+
+// NewEntityResolveResult is returned for resources which reference entities that could not be found,
+// or which select entities by name pattern or label.
+// The resource is reconciled again after the entity resolve interval even when periodic resyncs are disabled,
+// or earlier when the resync interval is shorter
+func (options ControllerOptions) NewEntityResolveResult() (reconcile.Result, error) {
+	requeueAfter := options.Settings.EntityResolveInterval()
+	if resync := options.Settings.ResyncInterval(); resync > 0 && resync < requeueAfter {
+		requeueAfter = resync
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// NewScheduledResult is returned for resources whose state changes after the given delay, such as muting rules with a schedule.
+// The resource is reconciled again once the delay has passed, or earlier when the resync interval is shorter
+func (options ControllerOptions) NewScheduledResult(delay time.Duration) (reconcile.Result, error) {
+	if resync := options.Settings.ResyncInterval(); resync > 0 && resync < delay {
+		delay = resync
+	}
+
+	return reconcile.Result{RequeueAfter: delay}, nil
+}
@@ -0,0 +1,129 @@
+package internal_test
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+	"time"
+)
+
+func TestControllerOptions_ForController(t *testing.T) {
+	options := internal.ControllerOptions{
+		MaxConcurrentReconciles: map[string]int{
+			"newrelic-alert-policy-controller": 4,
+		},
+	}
+
+	if options.ForController("newrelic-alert-policy-controller", nil).MaxConcurrentReconciles != 4 {
+		t.Error("MaxConcurrentReconciles should be equal to 4")
+	}
+
+	if options.ForController("newrelic-dashboard-controller", nil).MaxConcurrentReconciles != 1 {
+		t.Error("MaxConcurrentReconciles should default to 1")
+	}
+}
+
+func TestControllerOptions_ForController_TracksReconciler(t *testing.T) {
+	var tracked []string
+	options := internal.ControllerOptions{
+		TrackReconciler: func(controllerName string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+			return reconcile.Func(func(request reconcile.Request) (reconcile.Result, error) {
+				tracked = append(tracked, controllerName)
+				return reconciler.Reconcile(request)
+			})
+		},
+	}
+
+	reconciled := false
+	reconciler := reconcile.Func(func(request reconcile.Request) (reconcile.Result, error) {
+		reconciled = true
+		return reconcile.Result{}, nil
+	})
+
+	_, _ = options.ForController("newrelic-dashboard-controller", reconciler).Reconciler.Reconcile(reconcile.Request{})
+	if !reconciled || len(tracked) != 1 || tracked[0] != "newrelic-dashboard-controller" {
+		t.Errorf("Expected the reconciler to be tracked, got reconciled=%t and tracked=%v", reconciled, tracked)
+	}
+}
+
+type fixedSettings struct {
+	internal.Settings
+}
+
+func (fixedSettings) ErrorRequeueInterval() time.Duration {
+	return 30 * time.Second
+}
+
+func (fixedSettings) ResyncInterval() time.Duration {
+	return 10 * time.Minute
+}
+
+func (fixedSettings) EntityResolveInterval() time.Duration {
+	return 5 * time.Minute
+}
+
+func TestControllerOptions_NewReconcileResult(t *testing.T) {
+	options := internal.ControllerOptions{
+		Settings: fixedSettings{},
+	}
+
+	result, _ := options.NewReconcileResult(nil)
+	if result.RequeueAfter != 10*time.Minute {
+		t.Error("Reconciled resources should be resynced after 10m")
+	}
+
+	result, _ = options.NewReconcileResult(fmt.Errorf("something went wrong"))
+	if result.RequeueAfter != 30*time.Second {
+		t.Error("Failed resources should be requeued after 30s")
+	}
+
+	result, _ = options.NewReconcileResult(internal.NewClientError("invalid request"))
+	if result.RequeueAfter != 0 {
+		t.Error("Client errors should not be requeued")
+	}
+}
+
+func TestControllerOptions_NewRetryResult(t *testing.T) {
+	options := internal.ControllerOptions{
+		Settings: fixedSettings{},
+	}
+
+	result, err := options.NewRetryResult()
+	if err != nil {
+		t.Error(err)
+	}
+	if result.RequeueAfter != 30*time.Second {
+		t.Error("Resources should be retried after 30s")
+	}
+}
+
+func TestControllerOptions_NewEntityResolveResult(t *testing.T) {
+	options := internal.ControllerOptions{
+		Settings: fixedSettings{},
+	}
+
+	result, err := options.NewEntityResolveResult()
+	if err != nil {
+		t.Error(err)
+	}
+	if result.RequeueAfter != 5*time.Minute {
+		t.Error("Resources with unresolved entities should be requeued after 5m")
+	}
+}
+
+func TestControllerOptions_NewScheduledResult(t *testing.T) {
+	options := internal.ControllerOptions{
+		Settings: fixedSettings{},
+	}
+
+	result, _ := options.NewScheduledResult(2 * time.Minute)
+	if result.RequeueAfter != 2*time.Minute {
+		t.Error("Resources should be requeued when their state changes")
+	}
+
+	result, _ = options.NewScheduledResult(time.Hour)
+	if result.RequeueAfter != 10*time.Minute {
+		t.Error("Resources should be requeued after the resync interval when it is shorter")
+	}
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// NerdGraphClient sends GraphQL queries and mutations to the New Relic NerdGraph API
+type NerdGraphClient interface {
+	// Query executes the query or mutation and decodes the data of the response into result
+	Query(query string, variables map[string]interface{}, result interface{}) error
+	// AccountId is the id of the New Relic account the queries are sent for
+	AccountId() int64
+}
+
+type nerdGraphRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type nerdGraphResponse struct {
+	Data   json.RawMessage  `json:"data"`
+	Errors []nerdGraphError `json:"errors"`
+}
+
+type nerdGraphError struct {
+	Message string `json:"message"`
+}
+
+type nerdGraphClient struct {
+	client    NewrelicClient
+	accountId func() int64
+}
+
+// NewNerdGraphClient creates a NerdGraph client sending its requests through the given client,
+// which must be configured with the NerdGraph endpoint
+func NewNerdGraphClient(client NewrelicClient, accountId func() int64) NerdGraphClient {
+	return nerdGraphClient{
+		client:    client,
+		accountId: accountId,
+	}
+}
+
+func (nerdGraph nerdGraphClient) AccountId() int64 {
+	return nerdGraph.accountId()
+}
+
+func (nerdGraph nerdGraphClient) Query(query string, variables map[string]interface{}, result interface{}) error {
+	payload, err := json.Marshal(nerdGraphRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	response, err := nerdGraph.client.PostJson("graphql", payload)
+	if err != nil {
+		return err
+	}
+
+	var body nerdGraphResponse
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return err
+	}
+
+	// NerdGraph answers invalid queries and rejected mutations with status 200 and a list of errors
+	if len(body.Errors) > 0 {
+		messages := make([]string, len(body.Errors))
+		for i, nerdGraphErr := range body.Errors {
+			messages[i] = nerdGraphErr.Message
+		}
+		return NewClientError(strings.Join(messages, "; "))
+	}
+
+	if result == nil || len(body.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body.Data, result)
+}
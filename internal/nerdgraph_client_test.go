@@ -0,0 +1,69 @@
+package internal_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNerdGraphClient_Query_DecodesData(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On(
+		"PostJson",
+		"graphql",
+		[]byte(`{"query":"query { actor { user { name } } }"}`),
+	).Return(
+		newNerdGraphResponse(`{"data": {"actor": {"user": {"name": "operator"}}}}`),
+		nil,
+	)
+
+	var result struct {
+		Actor struct {
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+		} `json:"actor"`
+	}
+	nerdGraph := internal.NewNerdGraphClient(client, func() int64 { return 1 })
+	err := nerdGraph.Query("query { actor { user { name } } }", nil, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Actor.User.Name != "operator" {
+		t.Errorf("Expected the user name operator, got %s", result.Actor.User.Name)
+	}
+}
+
+func TestNerdGraphClient_Query_ReturnsClientErrorForErrors(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On(
+		"PostJson",
+		"graphql",
+		mock.Anything,
+	).Return(
+		newNerdGraphResponse(`{"data": null, "errors": [{"message": "first"}, {"message": "second"}]}`),
+		nil,
+	)
+
+	nerdGraph := internal.NewNerdGraphClient(client, func() int64 { return 1 })
+	err := nerdGraph.Query("mutation { doSomething }", map[string]interface{}{"accountId": 1}, nil)
+	if !internal.IsClientError(err) {
+		t.Fatalf("Expected a client error, got %v", err)
+	}
+
+	if err.Error() != "first; second" {
+		t.Errorf("Expected the messages of all errors, got %s", err.Error())
+	}
+}
+
+func newNerdGraphResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
@@ -18,22 +18,46 @@ type NewrelicClient interface {
 	Delete(path string) (*http.Response, error)
 }
 
-type newrelicClient struct {
-	client *http.Client
-	log    logr.InfoLogger
+// ClientSettings are the connection settings used for a request to New Relic
+type ClientSettings struct {
+	Url      string
+	AdminKey string
+	Timeout  time.Duration
+	// ApiKeyHeader is the header carrying the admin key. Defaults to X-Api-Key
+	ApiKeyHeader string
+}
+
+func (settings ClientSettings) apiKeyHeader() string {
+	if settings.ApiKeyHeader == "" {
+		return "X-Api-Key"
+	}
 
-	url      string
-	adminKey string
+	return settings.ApiKeyHeader
+}
+
+type newrelicClient struct {
+	log      logr.InfoLogger
+	settings func() ClientSettings
 }
 
 func NewNewrelicClient(log logr.Logger, url string, adminKey string) NewrelicClient {
+	settings := ClientSettings{
+		Url:      url,
+		AdminKey: adminKey,
+		Timeout:  time.Second * 3,
+	}
+
+	return NewConfigurableNewrelicClient(log, func() ClientSettings {
+		return settings
+	})
+}
+
+// NewConfigurableNewrelicClient creates a client which reads its settings before every request,
+// so that configuration changes take effect without restarting the operator
+func NewConfigurableNewrelicClient(log logr.Logger, settings func() ClientSettings) NewrelicClient {
 	return newrelicClient{
-		client:   &http.Client{
-			Timeout: time.Second * 3,
-		},
 		log:      log.V(3),
-		url:      url,
-		adminKey: adminKey,
+		settings: settings,
 	}
 }
 
@@ -76,27 +100,31 @@ func (newrelic newrelicClient) Delete(path string) (*http.Response, error) {
 }
 
 func (newrelic *newrelicClient) newRequest(method string, path string, body []byte) *http.Request {
+	settings := newrelic.settings()
+
 	var req *http.Request
 	if body == nil {
-		req = newRequest(method, newrelic.url, path)
+		req = newRequest(method, settings.Url, path)
 	} else {
-		req = newRequestWithBody(method, newrelic.url, path, body)
+		req = newRequestWithBody(method, settings.Url, path, body)
 	}
 
-	req.Header.Add("X-Api-Key", newrelic.adminKey)
+	req.Header.Add(settings.apiKeyHeader(), settings.AdminKey)
 
 	return req
 }
 
 func (newrelic *newrelicClient) newJsonRequest(method string, path string, body []byte) *http.Request {
+	settings := newrelic.settings()
+
 	var req *http.Request
 	if body == nil {
-		req = newRequest(method, newrelic.url, path)
+		req = newRequest(method, settings.Url, path)
 	} else {
-		req = newRequestWithBody(method, newrelic.url, path, body)
+		req = newRequestWithBody(method, settings.Url, path, body)
 	}
 
-	req.Header.Add("X-Api-Key", newrelic.adminKey)
+	req.Header.Add(settings.apiKeyHeader(), settings.AdminKey)
 	req.Header.Add("Content-Type", "application/json")
 
 	return req
@@ -135,7 +163,10 @@ func (newrelic newrelicClient) executeWithStatusCheck(request *http.Request) (*h
 
 func (newrelic newrelicClient) execute(request *http.Request) (*http.Response, error) {
 	newrelic.log.Info("Executing request", "Method", request.Method, "Endpoint", request.URL, "Payload", request.Body)
-	response, err := newrelic.client.Do(request)
+	client := &http.Client{
+		Timeout: newrelic.settings().Timeout,
+	}
+	response, err := client.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// RateLimiterRegistry hands out one token-bucket limiter per New Relic account.
+// All clients talking to the same account share the limiter, so the request budget
+// holds no matter how many controllers or concurrent reconciles are running.
+type RateLimiterRegistry struct {
+	mutex    sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+func NewRateLimiterRegistry(requestsPerSecond float64, burst int) *RateLimiterRegistry {
+	return &RateLimiterRegistry{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// ForAccount returns the limiter for the account with the given id.
+// When no account id is configured, the admin key identifies the account instead,
+// which assumes that every account is only accessed with a single admin key
+func (registry *RateLimiterRegistry) ForAccount(accountId int64, adminKey string) *rate.Limiter {
+	key := "adminKey/" + adminKey
+	if accountId != 0 {
+		key = "account/" + strconv.FormatInt(accountId, 10)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	limiter, ok := registry.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(registry.limit, registry.burst)
+		registry.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+type rateLimitedClient struct {
+	client  NewrelicClient
+	limiter func() *rate.Limiter
+}
+
+// NewRateLimitedClient wraps a client so that every request waits for a token from the limiter
+func NewRateLimitedClient(client NewrelicClient, limiter *rate.Limiter) NewrelicClient {
+	return rateLimitedClient{
+		client: client,
+		limiter: func() *rate.Limiter {
+			return limiter
+		},
+	}
+}
+
+func (c rateLimitedClient) Get(path string) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	return c.client.Get(path)
+}
+
+func (c rateLimitedClient) GetJson(path string) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetJson(path)
+}
+
+func (c rateLimitedClient) PostJson(path string, payload []byte) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	return c.client.PostJson(path, payload)
+}
+
+func (c rateLimitedClient) PutJson(path string, payload []byte) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	return c.client.PutJson(path, payload)
+}
+
+func (c rateLimitedClient) Delete(path string) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	return c.client.Delete(path)
+}
+
+func (c rateLimitedClient) wait() error {
+	return c.limiter().Wait(context.Background())
+}
@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"net/http"
+	"testing"
+)
+
+func TestRateLimiterRegistry_ForAccount_SharesLimiterPerAccount(t *testing.T) {
+	registry := internal.NewRateLimiterRegistry(1, 1)
+
+	if registry.ForAccount(1234567, "account-key") != registry.ForAccount(1234567, "other-account-key") {
+		t.Error("Limiters for the same account should be shared, whatever the admin key")
+	}
+
+	if registry.ForAccount(1234567, "account-key") == registry.ForAccount(7654321, "account-key") {
+		t.Error("Limiters for different accounts should not be shared")
+	}
+}
+
+func TestRateLimiterRegistry_ForAccount_FallsBackToAdminKey(t *testing.T) {
+	registry := internal.NewRateLimiterRegistry(1, 1)
+
+	if registry.ForAccount(0, "account-key") != registry.ForAccount(0, "account-key") {
+		t.Error("Limiters for the same admin key should be shared")
+	}
+
+	if registry.ForAccount(0, "account-key") == registry.ForAccount(0, "other-account-key") {
+		t.Error("Limiters for different admin keys should not be shared without an account id")
+	}
+}
+
+func TestRateLimitedClient_ConsumesTokens(t *testing.T) {
+	registry := internal.NewRateLimiterRegistry(1, 2)
+	limiter := registry.ForAccount(1234567, "account-key")
+
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "alerts_policies.json").Return(&http.Response{StatusCode: 200}, nil)
+
+	rateLimitedClient := internal.NewRateLimitedClient(client, limiter)
+	_, err := rateLimitedClient.Get("alerts_policies.json")
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "Get", "alerts_policies.json")
+	if limiter.Allow() != true {
+		t.Error("One token should be left in the bucket")
+	}
+	if limiter.Allow() != false {
+		t.Error("The bucket should be empty")
+	}
+}
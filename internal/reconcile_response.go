@@ -6,12 +6,16 @@ import (
 )
 
 func NewReconcileResult(err error) (reconcile.Result, error) {
+	return newReconcileResult(err, 5*time.Second)
+}
+
+func newReconcileResult(err error, errorRequeueInterval time.Duration) (reconcile.Result, error) {
 	if IsClientError(err) {
 		return reconcile.Result{}, nil
 	}
 
 	if err != nil {
-		return reconcile.Result{ RequeueAfter: 5 * time.Second }, nil
+		return reconcile.Result{ RequeueAfter: errorRequeueInterval }, nil
 	}
 
 	return reconcile.Result{}, nil
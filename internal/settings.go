@@ -0,0 +1,35 @@
+package internal
+
+import "time"
+
+const (
+	// SaveFailureModeRollback reverts every change of an alert policy save which failed
+	SaveFailureModeRollback = "Rollback"
+	// SaveFailureModeCheckpoint keeps the steps of an alert policy save which completed before the failure
+	SaveFailureModeCheckpoint = "Checkpoint"
+)
+
+// Settings exposes the operator configuration to the controllers.
+// The configuration can be reloaded while the operator is running,
+// so implementations must be safe for concurrent use and callers should not cache the returned values.
+type Settings interface {
+	RestApi() ClientSettings
+	InfraApi() ClientSettings
+	SyntheticsApi() ClientSettings
+	NerdGraphApi() ClientSettings
+	// AccountId is the id of the New Relic account, which NerdGraph requests are sent for.
+	// A zero value means that no account id is configured
+	AccountId() int64
+	// ErrorRequeueInterval is the delay after which a resource which failed to reconcile is retried
+	ErrorRequeueInterval() time.Duration
+	// ResyncInterval is the delay after which a successfully reconciled resource is reconciled again.
+	// A zero value disables periodic resyncs.
+	ResyncInterval() time.Duration
+	// EntityResolveInterval is the delay after which a resource referencing entities
+	// which do not exist in New Relic yet is reconciled again
+	EntityResolveInterval() time.Duration
+	// SaveFailureMode is either SaveFailureModeRollback or SaveFailureModeCheckpoint
+	SaveFailureMode() string
+	DefaultSlackWebhookUrl() string
+	DefaultOpsgenieApiKey() string
+}
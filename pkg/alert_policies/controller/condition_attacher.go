@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sort"
+)
+
+// policyOwner is the owner of the condition names listed in the policy itself, including the ones of its templates
+const policyOwner = "the policy"
+
+// AttachedCondition is a standalone condition which refers to a policy.
+// Err is the reason the condition was left out of the policy, or nil when it was added
+type AttachedCondition struct {
+	Condition v1alpha1.StandaloneCondition
+	Err       error
+}
+
+// attachConditions adds the standalone conditions which refer to the policy to its spec.
+// A condition is left out when it is invalid, or when its name is already used by a condition of the same type
+// in the policy or by an older standalone condition, so that the conditions of different owners never replace each other
+func (policyFactory PolicyFactory) attachConditions(cr *v1alpha1.AlertPolicy, spec *v1alpha1.AlertPolicySpec) ([]AttachedCondition, error) {
+	conditions, err := policyFactory.conditionRepository.GetAttachedConditions(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return isOlder(conditions[i], conditions[j])
+	})
+
+	nrqlOwners := make(map[string]string)
+	for _, condition := range spec.NrqlConditions {
+		nrqlOwners[condition.Name] = policyOwner
+	}
+	apmOwners := make(map[string]string)
+	for _, condition := range spec.ApmConditions {
+		apmOwners[condition.Name] = policyOwner
+	}
+
+	result := make([]AttachedCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = AttachedCondition{Condition: condition}
+		switch condition := condition.(type) {
+		case *v1alpha1.NrqlAlertCondition:
+			result[i].Err = attachNrqlCondition(spec, condition, nrqlOwners)
+		case *v1alpha1.ApmAlertCondition:
+			result[i].Err = attachApmCondition(spec, condition, apmOwners)
+		}
+	}
+
+	return result, nil
+}
+
+func attachNrqlCondition(spec *v1alpha1.AlertPolicySpec, condition *v1alpha1.NrqlAlertCondition, owners map[string]string) error {
+	if errs := condition.Spec.Validate(field.NewPath("spec")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	if err := claimConditionName(owners, condition); err != nil {
+		return err
+	}
+
+	spec.NrqlConditions = append(spec.NrqlConditions, condition.Spec.NrqlCondition)
+	return nil
+}
+
+func attachApmCondition(spec *v1alpha1.AlertPolicySpec, condition *v1alpha1.ApmAlertCondition, owners map[string]string) error {
+	if errs := condition.Spec.Validate(field.NewPath("spec")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	if err := claimConditionName(owners, condition); err != nil {
+		return err
+	}
+
+	spec.ApmConditions = append(spec.ApmConditions, condition.Spec.ApmCondition)
+	return nil
+}
+
+func claimConditionName(owners map[string]string, condition v1alpha1.StandaloneCondition) error {
+	name := condition.GetConditionName()
+	if owner, ok := owners[name]; ok {
+		return fmt.Errorf("condition name %s is already used by %s", name, owner)
+	}
+
+	owners[name] = fmt.Sprintf("%s/%s", condition.GetNamespace(), condition.GetName())
+	return nil
+}
+
+// isOlder orders conditions by their creation, and by their namespace and name when they were created at the same time
+func isOlder(condition v1alpha1.StandaloneCondition, other v1alpha1.StandaloneCondition) bool {
+	created, otherCreated := condition.GetCreationTimestamp(), other.GetCreationTimestamp()
+	if !created.Equal(&otherCreated) {
+		return created.Before(&otherCreated)
+	}
+	if condition.GetNamespace() != other.GetNamespace() {
+		return condition.GetNamespace() < other.GetNamespace()
+	}
+
+	return condition.GetName() < other.GetName()
+}
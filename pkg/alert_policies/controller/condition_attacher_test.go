@@ -0,0 +1,189 @@
+package controller_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/controller"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolicyFactory_NewAlertPolicy_AttachedConditions(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	apmCondition := &v1alpha1.ApmAlertCondition{
+		ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "checkout-apdex"},
+		Spec: v1alpha1.ApmAlertConditionSpec{
+			PolicyRef: v1alpha1.PolicyReference{Name: "test-policy"},
+			ApmCondition: v1alpha1.ApmCondition{
+				Name:     "checkout apdex",
+				Type:     "apm_app_metric",
+				Metric:   "apdex",
+				Entities: []string{"checkout"},
+				CriticalThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "below",
+					Value:           "0.8",
+					DurationMinutes: 5,
+				},
+			},
+		},
+	}
+	factory := newFactoryWithConditions(client,
+		newNrqlAlertCondition("team-a", "errors", "team-a errors", 0),
+		newNrqlAlertCondition("team-b", "errors", "team-b errors", 0),
+		apmCondition,
+	)
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(newPolicyWithTemplate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.NrqlConditions) != 2 || domainPolicy.NrqlConditions[0].Condition.Name != "team-a errors" {
+		t.Errorf("Expected the conditions of both teams, got %+v", domainPolicy.NrqlConditions)
+	}
+	if len(domainPolicy.ApmConditions) != 1 || domainPolicy.ApmConditions[0].Condition.Entities[0] != "10" {
+		t.Errorf("Expected the checkout apdex condition, got %+v", domainPolicy.ApmConditions)
+	}
+	assertAttached(t, resolution.Attached, map[string]string{
+		"team-a/errors":          "",
+		"team-b/errors":          "",
+		"default/checkout-apdex": "",
+	})
+}
+
+func TestPolicyFactory_NewAlertPolicy_AttachedConditionsOfOtherPolicies(t *testing.T) {
+	other := newNrqlAlertCondition("team-a", "other", "other errors", 0)
+	other.Spec.PolicyRef = v1alpha1.PolicyReference{Name: "other-policy", Namespace: "default"}
+	sameNameOtherNamespace := newNrqlAlertCondition("team-a", "local", "local errors", 0)
+	sameNameOtherNamespace.Spec.PolicyRef = v1alpha1.PolicyReference{Name: "test-policy"}
+
+	factory := newFactoryWithConditions(new(mocks.NewrelicClient), other, sameNameOtherNamespace)
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(newPolicyWithTemplate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.NrqlConditions) != 0 || len(resolution.Attached) != 0 {
+		t.Errorf("Expected no attached conditions, got %v", resolution.Attached)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_AttachedConditionNameConflicts(t *testing.T) {
+	policy := newPolicyWithTemplate()
+	policy.Spec.NrqlConditions = []v1alpha1.NrqlCondition{newNrqlAlertCondition("default", "own", "platform errors", 0).Spec.NrqlCondition}
+
+	factory := newFactoryWithConditions(new(mocks.NewrelicClient),
+		newNrqlAlertCondition("team-b", "errors", "shared errors", 2),
+		newNrqlAlertCondition("team-a", "errors", "shared errors", 1),
+		newNrqlAlertCondition("team-c", "errors", "platform errors", 0),
+	)
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.NrqlConditions) != 2 {
+		t.Errorf("Expected the condition of the policy and the older shared condition, got %+v", domainPolicy.NrqlConditions)
+	}
+	assertAttached(t, resolution.Attached, map[string]string{
+		"team-a/errors": "",
+		"team-b/errors": "condition name shared errors is already used by team-a/errors",
+		"team-c/errors": "condition name platform errors is already used by the policy",
+	})
+}
+
+func TestPolicyFactory_NewAlertPolicy_InvalidAttachedCondition(t *testing.T) {
+	invalid := newNrqlAlertCondition("team-a", "invalid", "invalid errors", 0)
+	invalid.Spec.Query = ""
+
+	factory := newFactoryWithConditions(new(mocks.NewrelicClient),
+		invalid,
+		newNrqlAlertCondition("team-b", "errors", "team-b errors", 0),
+	)
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(newPolicyWithTemplate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.NrqlConditions) != 1 || domainPolicy.NrqlConditions[0].Condition.Name != "team-b errors" {
+		t.Errorf("Expected only the valid condition, got %+v", domainPolicy.NrqlConditions)
+	}
+	for _, attached := range resolution.Attached {
+		if attached.Condition.GetName() == "invalid" && (attached.Err == nil || !strings.Contains(attached.Err.Error(), "spec.query: Required value")) {
+			t.Errorf("Expected a missing query error, got %v", attached.Err)
+		}
+	}
+}
+
+func newFactoryWithConditions(client *mocks.NewrelicClient, conditions ...runtime.Object) *controller.PolicyFactory {
+	return controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(),
+		newConditionRepository(conditions...),
+	)
+}
+
+// newNrqlAlertCondition returns a condition of the test-policy in the default namespace, created the given number of minutes after the others
+func newNrqlAlertCondition(namespace string, name string, conditionName string, createdAfterMinutes int) *v1alpha1.NrqlAlertCondition {
+	created := time.Date(2020, 6, 1, 0, createdAfterMinutes, 0, 0, time.UTC)
+	return &v1alpha1.NrqlAlertCondition{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: v1.NewTime(created),
+		},
+		Spec: v1alpha1.NrqlAlertConditionSpec{
+			PolicyRef: v1alpha1.PolicyReference{Name: "test-policy", Namespace: "default"},
+			NrqlCondition: v1alpha1.NrqlCondition{
+				Name:          conditionName,
+				Query:         "SELECT count(*) FROM TransactionError",
+				Since:         5,
+				ValueFunction: "single_value",
+				AlertThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "100",
+					DurationMinutes: 5,
+				},
+			},
+		},
+	}
+}
+
+// assertAttached compares the errors of the attached conditions, by <namespace>/<name>, with the expected errors.
+// An empty error means the condition was added to the policy
+func assertAttached(t *testing.T, attached []controller.AttachedCondition, expected map[string]string) {
+	t.Helper()
+	if len(attached) != len(expected) {
+		t.Fatalf("Expected %d attached conditions, got %v", len(expected), attached)
+	}
+
+	for _, condition := range attached {
+		key := condition.Condition.GetNamespace() + "/" + condition.Condition.GetName()
+		expectedErr, ok := expected[key]
+		if !ok {
+			t.Errorf("Unexpected attached condition %s", key)
+			continue
+		}
+
+		actualErr := ""
+		if condition.Err != nil {
+			actualErr = condition.Err.Error()
+		}
+		if actualErr != expectedErr {
+			t.Errorf("Expected error %q for %s, got %q", expectedErr, key, actualErr)
+		}
+	}
+}
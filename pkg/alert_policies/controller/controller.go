@@ -6,23 +6,29 @@ import (
 	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/infrastructure/k8s"
 	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/infrastructure/newrelic"
 	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
-	commonv1alpha1 "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
-	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
+	goerrors "errors"
 	"github.com/go-logr/logr"
 	"github.com/operator-framework/operator-sdk/pkg/predicate"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"os"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 var log = logf.Log.WithName("controller_newrelic_alert_policy")
 
+// entityCacheTtl is how long the entities of a selector are reused, which is well below the default resync interval
+// so that newly deployed applications are still picked up on the next resync
+const entityCacheTtl = 5 * time.Minute
+
 // ReconcileNewrelicPolicy reconciles a AlertPolicy object
 type ReconcileNewrelicPolicy struct {
 	policyFactory *PolicyFactory
@@ -30,24 +36,23 @@ type ReconcileNewrelicPolicy struct {
 	scheme        *runtime.Scheme
 	newrelic      *newrelic.AlertPolicyRepository
 	log           logr.Logger
+	options       internal.ControllerOptions
 }
 
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, options internal.ControllerOptions) error {
 	log.Info("Registering newrelic alert policy controller")
 
-	client := internal.NewNewrelicClient(
-		log,
-		"https://api.newrelic.com/v2",
-		os.Getenv("NEWRELIC_ADMIN_KEY"),
-	)
-	infraClient := internal.NewNewrelicClient(
-		log,
-		"https://infra-api.newrelic.com/v2",
-		os.Getenv("NEWRELIC_ADMIN_KEY"),
-	)
+	client := options.NewRestApiClient(log)
+	infraClient := options.NewInfraApiClient(log)
 
-	repository := newrelic.NewAlertPolicyRepository(log, client, infraClient)
-	policyFactory := NewPolicyFactory(applications.NewRepository(client))
+	repository := newrelic.NewAlertPolicyRepository(log, client, infraClient, options.NewNerdGraphClient(log))
+	templateRepository := k8s.NewTemplateRepository(mgr.GetClient())
+	policyFactory := NewPolicyFactory(
+		entities.NewRepository(client).WithCache(entityCacheTtl),
+		monitors.NewRepository(options.NewSyntheticsApiClient(log)),
+		templateRepository,
+		k8s.NewConditionRepository(mgr.GetClient()),
+	)
 
 	k8sClient := k8s.NewClient(log, mgr.GetClient())
 	reconciler := &ReconcileNewrelicPolicy{
@@ -56,9 +61,10 @@ func Add(mgr manager.Manager) error {
 		scheme:        mgr.GetScheme(),
 		newrelic:      repository,
 		log:           log,
+		options:       options,
 	}
 
-	c, err := controller.New("newrelic-alert-policy-controller", mgr, controller.Options{Reconciler: reconciler})
+	c, err := controller.New("newrelic-alert-policy-controller", mgr, options.ForController("newrelic-alert-policy-controller", reconciler))
 	if err != nil {
 		return err
 	}
@@ -69,9 +75,67 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	// Watch for changes to condition templates, and reconcile the policies which refer to them
+	err = c.Watch(&source.Kind{Type: &v1alpha1.AlertConditionTemplate{}}, newDependentPolicyHandler(templateRepository, v1alpha1.AlertConditionTemplateKind), predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &v1alpha1.ClusterAlertConditionTemplate{}}, newDependentPolicyHandler(templateRepository, v1alpha1.ClusterAlertConditionTemplateKind), predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to standalone conditions, and reconcile the policies they refer to
+	err = c.Watch(&source.Kind{Type: &v1alpha1.NrqlAlertCondition{}}, newAttachedPolicyHandler(), predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &v1alpha1.ApmAlertCondition{}}, newAttachedPolicyHandler(), predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// newAttachedPolicyHandler enqueues the policy a standalone condition refers to.
+// Updates enqueue both the previous and the current policy, so that a condition moved to another policy is removed from the previous one
+func newAttachedPolicyHandler() handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			condition, ok := object.Object.(v1alpha1.StandaloneCondition)
+			if !ok {
+				return nil
+			}
+
+			return []reconcile.Request{
+				{NamespacedName: condition.GetPolicyRef().NamespacedName(condition.GetNamespace())},
+			}
+		}),
+	}
+}
+
+// newDependentPolicyHandler enqueues the policies which refer to a template of the given kind
+func newDependentPolicyHandler(templateRepository *k8s.TemplateRepository, kind string) handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			policies, err := templateRepository.GetDependentPolicies(kind, object.Meta.GetNamespace(), object.Meta.GetName())
+			if err != nil {
+				log.Error(err, "Error listing the policies of a template", "Template", object.Meta.GetName())
+				return nil
+			}
+
+			requests := make([]reconcile.Request, len(policies))
+			for i, policy := range policies {
+				requests[i] = reconcile.Request{NamespacedName: policy}
+			}
+			return requests
+		}),
+	}
+}
+
 func (r *ReconcileNewrelicPolicy) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling AlertPolicy")
@@ -82,51 +146,70 @@ func (r *ReconcileNewrelicPolicy) Reconcile(request reconcile.Request) (reconcil
 			return internal.NewReconcileResult(nil)
 		}
 		reqLogger.Error(err, "Error talking to API server. Re-queueing request")
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
+	}
+
+	policy, resolution, err := r.policyFactory.NewAlertPolicy(instance)
+	if instance.DeletionTimestamp != nil {
+		// Deleting only needs the policy id, so missing applications must not block it
+		return r.deletePolicy(policy, *instance)
 	}
 
-	policy, err := r.policyFactory.NewAlertPolicy(instance)
 	if err != nil {
 		reqLogger.Error(err, "Error creating alerting policy")
-		instance.Status = commonv1alpha1.NewError(policy.Policy.Id, err)
+		r.updateConditionStatuses(instance, resolution.Attached, err)
+		instance.Status = v1alpha1.NewPolicyError(policy.Policy.Id, err, v1alpha1.SaveOutcomeNotAttempted, resolution.Unresolved).WithResolvedEntities(resolution.Resolved)
 		statisErr := r.k8s.UpdatePolicyStatus(instance)
 		if statisErr != nil {
-			return internal.NewReconcileResult(statisErr)
+			return r.options.NewReconcileResult(statisErr)
 		}
 
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
-	if instance.DeletionTimestamp != nil {
-		return r.deletePolicy(policy, *instance)
-	} else {
-		err := r.k8s.SetFinalizer(*instance)
-		if err != nil {
-			reqLogger.Error(err, "Error setting finalizer on policy")
-			return internal.NewReconcileResult(err)
-		}
-
-		err = r.newrelic.Save(policy)
-		if err != nil {
-			reqLogger.Error(err, "Error saving policy")
-			instance.Status = commonv1alpha1.NewError(policy.Policy.Id, err)
-			statusErr := r.k8s.UpdatePolicyStatus(instance)
-			if statusErr != nil {
-				return internal.NewReconcileResult(statusErr)
-			}
+	err = r.k8s.SetFinalizer(*instance)
+	if err != nil {
+		reqLogger.Error(err, "Error setting finalizer on policy")
+		return r.options.NewReconcileResult(err)
+	}
 
-			return internal.NewReconcileResult(err)
+	err = r.save(policy)
+	if err != nil {
+		reqLogger.Error(err, "Error saving policy")
+		r.updateConditionStatuses(instance, resolution.Attached, err)
+		outcome := saveOutcome(err)
+		instance.Status = v1alpha1.NewPolicyError(policy.Policy.Id, err, outcome, resolution.Unresolved).WithResolvedEntities(resolution.Resolved)
+		statusErr := r.k8s.UpdatePolicyStatus(instance)
+		if statusErr != nil {
+			return r.options.NewReconcileResult(statusErr)
 		}
 
-		instance.Status = commonv1alpha1.NewReady(policy.Policy.Id)
-		err = r.k8s.UpdatePolicyStatus(instance)
-		if err != nil {
-			return internal.NewReconcileResult(err)
+		if outcome == v1alpha1.SaveOutcomePartiallyApplied {
+			// New Relic is left in an unknown state, so the policy is retried even when New Relic rejected the change
+			return r.options.NewRetryResult()
 		}
+		return r.options.NewReconcileResult(err)
+	}
+
+	r.updateConditionStatuses(instance, resolution.Attached, nil)
+	instance.Status = v1alpha1.NewPolicyReady(policy.Policy.Id, resolution.Unresolved).WithResolvedEntities(resolution.Resolved)
+	err = r.k8s.UpdatePolicyStatus(instance)
+	if err != nil {
+		return r.options.NewReconcileResult(err)
+	}
+
+	if len(resolution.Unresolved) > 0 {
+		reqLogger.Info("Finished reconciling with unresolved entities", "UnresolvedEntities", len(resolution.Unresolved))
+		return r.options.NewEntityResolveResult()
+	}
 
-		reqLogger.Info("Finished reconciling")
-		return internal.NewReconcileResult(nil)
+	reqLogger.Info("Finished reconciling")
+	if resolution.Dynamic {
+		// Applications deployed later only match the entity selectors when the policy is reconciled again
+		return r.options.NewEntityResolveResult()
 	}
+
+	return r.options.NewReconcileResult(nil)
 }
 
 func (r *ReconcileNewrelicPolicy) deletePolicy(policy *domain.AlertPolicy, instance v1alpha1.AlertPolicy) (reconcile.Result, error) {
@@ -144,3 +227,45 @@ func (r *ReconcileNewrelicPolicy) deletePolicy(policy *domain.AlertPolicy, insta
 
 	return reconcile.Result{}, nil
 }
+
+// updateConditionStatuses reports on the standalone conditions of the policy whether they were saved.
+// Failing to update the status of a condition does not fail the reconciliation of the policy
+func (r *ReconcileNewrelicPolicy) updateConditionStatuses(instance *v1alpha1.AlertPolicy, attached []AttachedCondition, policyErr error) {
+	policy := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	for _, condition := range attached {
+		status := v1alpha1.NewConditionReady(policy)
+		if condition.Err != nil {
+			status = v1alpha1.NewConditionError(policy, condition.Err)
+		} else if policyErr != nil {
+			status = v1alpha1.NewConditionError(policy, policyErr)
+		}
+
+		if condition.Condition.GetStatus() == status {
+			continue
+		}
+
+		condition.Condition.SetStatus(status)
+		_ = r.k8s.UpdateConditionStatus(condition.Condition)
+	}
+}
+
+// save rolls back or stops at the last checkpoint when saving fails, depending on the configured save failure mode
+func (r *ReconcileNewrelicPolicy) save(policy *domain.AlertPolicy) error {
+	if r.options.Settings.SaveFailureMode() == internal.SaveFailureModeCheckpoint {
+		return r.newrelic.SaveToCheckpoint(policy)
+	}
+
+	return r.newrelic.Save(policy)
+}
+
+func saveOutcome(err error) string {
+	var saveErr newrelic.SaveError
+	if !goerrors.As(err, &saveErr) || saveErr.RolledBack() {
+		return v1alpha1.SaveOutcomeReverted
+	}
+	if saveErr.Checkpoint() != "" {
+		return v1alpha1.SaveOutcomeCheckpoint
+	}
+
+	return v1alpha1.SaveOutcomePartiallyApplied
+}
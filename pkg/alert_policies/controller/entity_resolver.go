@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"errors"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"strconv"
+)
+
+// entityResolver looks up the entities of conditions, and collects the entities which do not exist
+type entityResolver struct {
+	repository *entities.Repository
+	resolution Resolution
+	// missingErr is the error for the first missing entity of a condition which does not tolerate it
+	missingErr error
+}
+
+func newEntityResolver(repository *entities.Repository) *entityResolver {
+	return &entityResolver{
+		repository: repository,
+	}
+}
+
+// resolve returns the ids of the entities of a condition, and whether the condition should be saved
+// according to its missingEntities setting. A condition which is not saved keeps its existing New Relic condition
+func (resolver *entityResolver) resolve(conditionName string, kind entities.Kind, selectors []applications.Selector, missingEntities *string) ([]string, bool, error) {
+	entityIds, missing, err := resolver.getEntityIds(conditionName, kind, selectors)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(missing) == 0 {
+		return entityIds, true, nil
+	}
+
+	for _, name := range missing {
+		resolver.resolution.Unresolved = append(resolver.resolution.Unresolved, v1alpha1.UnresolvedEntity{Condition: conditionName, Entity: name})
+	}
+
+	switch stringWithDefault(missingEntities, v1alpha1.DefaultMissingEntities) {
+	case v1alpha1.MissingEntitiesSkip:
+		return entityIds, len(entityIds) > 0, nil
+	case v1alpha1.MissingEntitiesWait:
+		return nil, false, nil
+	default:
+		if resolver.missingErr == nil {
+			resolver.missingErr = entities.NotFoundError{Kind: kind, Name: missing[0]}
+		}
+		return nil, false, nil
+	}
+}
+
+// getEntityIds returns the ids of the selected entities without duplicates,
+// and the selectors which do not match any entity
+func (resolver *entityResolver) getEntityIds(conditionName string, kind entities.Kind, selectors []applications.Selector) ([]string, []string, error) {
+	var result []string
+	var missing []string
+	seen := make(map[int]bool)
+	for _, selector := range selectors {
+		if selector.IsDynamic() {
+			resolver.resolution.Dynamic = true
+		}
+
+		selected, err := resolver.repository.Select(kind, selector)
+		if err != nil {
+			var notFound entities.NotFoundError
+			if errors.As(err, &notFound) {
+				missing = append(missing, selector.String())
+				continue
+			}
+			return nil, nil, err
+		}
+
+		resolved := v1alpha1.ResolvedEntity{Condition: conditionName, Entity: selector.String(), Kind: string(kind)}
+		for _, entity := range selected {
+			resolved.Ids = append(resolved.Ids, entity.Id)
+			if seen[entity.Id] {
+				continue
+			}
+			seen[entity.Id] = true
+			result = append(result, strconv.Itoa(entity.Id))
+		}
+		resolver.resolution.Resolved = append(resolver.resolution.Resolved, resolved)
+	}
+
+	return result, missing, nil
+}
+
+// entityKind returns the kind of entity New Relic expects for the type of an APM condition
+func entityKind(conditionType string) entities.Kind {
+	switch conditionType {
+	case "browser_metric":
+		return entities.KindBrowserApplication
+	case "mobile_metric":
+		return entities.KindMobileApplication
+	case "apm_kt_metric":
+		return entities.KindKeyTransaction
+	default:
+		return entities.KindApplication
+	}
+}
+
@@ -2,12 +2,18 @@ package controller_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/infrastructure/k8s"
 	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
 	"io/ioutil"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func newPolicyWithApmCondition(policyName string, entityName string) *v1alpha1.AlertPolicy {
@@ -22,7 +28,8 @@ func newPolicyWithApmCondition(policyName string, entityName string) *v1alpha1.A
 			ApmConditions: []v1alpha1.ApmCondition{
 				{
 					Name:     "condition",
-					Type:     "apm_metric",
+					Type:     "apm_app_metric",
+					Metric:   "apdex",
 					Entities: []string{entityName},
 				},
 			},
@@ -31,6 +38,32 @@ func newPolicyWithApmCondition(policyName string, entityName string) *v1alpha1.A
 	}
 }
 
+func newPolicyWithExternalServiceCondition(policyName string, entityName string) *v1alpha1.AlertPolicy {
+	return &v1alpha1.AlertPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: policyName,
+		},
+		Spec: v1alpha1.AlertPolicySpec{
+			Name:               policyName,
+			IncidentPreference: "per_policy",
+			ExternalServiceConditions: []v1alpha1.ExternalServiceCondition{
+				{
+					Name:               "payments-slow",
+					Entities:           []string{entityName},
+					ExternalServiceUrl: "api.stripe.com",
+					Metric:             "response_time_average",
+					CriticalThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "2",
+						DurationMinutes: 5,
+					},
+				},
+			},
+		},
+	}
+}
+
 func newEmptyResponse() *http.Response {
 	response := []byte(fmt.Sprintf(`
 		{
@@ -71,3 +104,133 @@ func newResponse(appId int, appName string) *http.Response {
 		Close:      false,
 	}
 }
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+// newMonitorResponse returns a function which creates a new response for every call,
+// since the monitors are listed again for every monitor name
+func newMonitorResponse(monitors map[string]string) func(string) *http.Response {
+	var list struct {
+		Monitors []map[string]string `json:"monitors"`
+		Count    int                 `json:"count"`
+	}
+	for id, name := range monitors {
+		list.Monitors = append(list.Monitors, map[string]string{"id": id, "name": name})
+	}
+	list.Count = len(list.Monitors)
+
+	content, err := json.Marshal(list)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			Close:      false,
+		}
+	}
+}
+
+func newStringResponse(response string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(response))),
+		Close:      false,
+	}
+}
+
+// objectReader returns the condition templates, standalone conditions and policies it was created with
+type objectReader struct {
+	templates        []v1alpha1.AlertConditionTemplate
+	clusterTemplates []v1alpha1.ClusterAlertConditionTemplate
+	nrqlConditions   []v1alpha1.NrqlAlertCondition
+	apmConditions    []v1alpha1.ApmAlertCondition
+	policies         []v1alpha1.AlertPolicy
+}
+
+func newTemplateRepository(objects ...runtime.Object) *k8s.TemplateRepository {
+	return k8s.NewTemplateRepository(newObjectReader(objects...))
+}
+
+func newConditionRepository(objects ...runtime.Object) *k8s.ConditionRepository {
+	return k8s.NewConditionRepository(newObjectReader(objects...))
+}
+
+func newObjectReader(objects ...runtime.Object) *objectReader {
+	reader := &objectReader{}
+	for _, object := range objects {
+		switch object := object.(type) {
+		case *v1alpha1.AlertConditionTemplate:
+			reader.templates = append(reader.templates, *object)
+		case *v1alpha1.ClusterAlertConditionTemplate:
+			reader.clusterTemplates = append(reader.clusterTemplates, *object)
+		case *v1alpha1.NrqlAlertCondition:
+			reader.nrqlConditions = append(reader.nrqlConditions, *object)
+		case *v1alpha1.ApmAlertCondition:
+			reader.apmConditions = append(reader.apmConditions, *object)
+		case *v1alpha1.AlertPolicy:
+			reader.policies = append(reader.policies, *object)
+		}
+	}
+
+	return reader
+}
+
+func (r *objectReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	switch obj := obj.(type) {
+	case *v1alpha1.AlertConditionTemplate:
+		for _, template := range r.templates {
+			if template.Namespace == key.Namespace && template.Name == key.Name {
+				*obj = template
+				return nil
+			}
+		}
+	case *v1alpha1.ClusterAlertConditionTemplate:
+		for _, template := range r.clusterTemplates {
+			if template.Name == key.Name {
+				*obj = template
+				return nil
+			}
+		}
+	}
+
+	return errors.NewNotFound(schema.GroupResource{Resource: "alertconditiontemplates"}, key.Name)
+}
+
+func (r *objectReader) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	options := &client.ListOptions{}
+	options.ApplyOptions(opts)
+
+	switch list := list.(type) {
+	case *v1alpha1.AlertPolicyList:
+		for _, policy := range r.policies {
+			if options.Namespace == "" || policy.Namespace == options.Namespace {
+				list.Items = append(list.Items, policy)
+			}
+		}
+	case *v1alpha1.NrqlAlertConditionList:
+		list.Items = append(list.Items, r.nrqlConditions...)
+	case *v1alpha1.ApmAlertConditionList:
+		list.Items = append(list.Items, r.apmConditions...)
+	}
+
+	return nil
+}
+
+func newTemplate(namespace string, name string, spec v1alpha1.AlertConditionTemplateSpec) *v1alpha1.AlertConditionTemplate {
+	return &v1alpha1.AlertConditionTemplate{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: spec,
+	}
+}
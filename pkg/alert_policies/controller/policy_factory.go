@@ -1,77 +1,235 @@
 package controller
 
 import (
+	"fmt"
 	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/infrastructure/k8s"
 	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apm"
 	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
 	"strconv"
 	"strings"
 )
 
+// Resolution lists the entities the conditions of a policy resolved to, the ones which do not exist,
+// and the standalone conditions which refer to the policy
+type Resolution struct {
+	Resolved   []v1alpha1.ResolvedEntity
+	Unresolved []v1alpha1.UnresolvedEntity
+	Attached   []AttachedCondition
+	// Dynamic is set when a condition selects entities by name pattern or label,
+	// whose matches change as applications are deployed
+	Dynamic bool
+}
+
 type PolicyFactory struct {
-	appRepository *applications.Repository
+	entityRepository    *entities.Repository
+	monitorRepository   *monitors.Repository
+	templateRepository  *k8s.TemplateRepository
+	conditionRepository *k8s.ConditionRepository
 }
 
-func NewPolicyFactory(appRepository *applications.Repository) *PolicyFactory {
+func NewPolicyFactory(entityRepository *entities.Repository, monitorRepository *monitors.Repository, templateRepository *k8s.TemplateRepository, conditionRepository *k8s.ConditionRepository) *PolicyFactory {
 	return &PolicyFactory{
-		appRepository: appRepository,
+		entityRepository:    entityRepository,
+		monitorRepository:   monitorRepository,
+		templateRepository:  templateRepository,
+		conditionRepository: conditionRepository,
 	}
 }
 
-func (policyFactory PolicyFactory) NewAlertPolicy(cr *v1alpha1.AlertPolicy) (*domain.AlertPolicy, error) {
+// NewAlertPolicy returns the policy to save in New Relic, along with the entities the APM and external service conditions resolved to
+// and the ones which do not exist. Conditions with missing entities are handled according to their missingEntities setting.
+// The conditions of the templates the policy refers to, and the standalone conditions which refer to the policy,
+// are added to the ones listed in the policy
+func (policyFactory PolicyFactory) NewAlertPolicy(cr *v1alpha1.AlertPolicy) (*domain.AlertPolicy, Resolution, error) {
 	policy := &domain.AlertPolicy{
 		Policy: domain.Policy{
 			Id:                 cr.Status.NewrelicId,
 			Name:               cr.Spec.Name,
 			IncidentPreference: strings.ToUpper(cr.Spec.IncidentPreference),
 		},
-		ApmConditions:   []*domain.ApmCondition{},
-		NrqlConditions:  policyFactory.newNrqlConditions(cr.Spec.NrqlConditions),
-		InfraConditions: policyFactory.newInfraConditions(cr.Spec.InfraConditions),
+		ApmConditions:             []*domain.ApmCondition{},
+		ExternalServiceConditions: []*domain.ExternalServiceCondition{},
+		NrqlConditions:            []*domain.NrqlCondition{},
+		InfraConditions:           []*domain.InfraCondition{},
+		SyntheticsConditions:      []*domain.SyntheticsCondition{},
+		LocationFailureConditions: []*domain.LocationFailureCondition{},
+	}
+
+	spec, err := policyFactory.expandTemplates(cr)
+	if err != nil {
+		return policy, Resolution{}, err
 	}
 
-	apmConditions, err := policyFactory.newApmConditions(cr.Spec.ApmConditions)
+	attached, err := policyFactory.attachConditions(cr, &spec)
 	if err != nil {
-		return policy, err
+		return policy, Resolution{}, err
+	}
+
+	policy.NrqlConditions = policyFactory.newNrqlConditions(spec.NrqlConditions)
+	policy.InfraConditions = policyFactory.newInfraConditions(spec.InfraConditions)
+
+	resolver := newEntityResolver(policyFactory.entityRepository)
+	resolver.resolution.Attached = attached
+	apmConditions, err := policyFactory.newApmConditions(resolver, spec.ApmConditions)
+	if err != nil {
+		return policy, resolver.resolution, err
+	}
+
+	externalServiceConditions, err := newExternalServiceConditions(resolver, spec.ExternalServiceConditions)
+	if err != nil {
+		return policy, resolver.resolution, err
+	}
+
+	if resolver.missingErr != nil {
+		return policy, resolver.resolution, resolver.missingErr
 	}
 
 	policy.ApmConditions = apmConditions
-	return policy, nil
+	policy.ExternalServiceConditions = externalServiceConditions
+
+	err = policyFactory.addSyntheticsConditions(policy, spec.SyntheticsConditions)
+	if err != nil {
+		return policy, resolver.resolution, err
+	}
+
+	return policy, resolver.resolution, nil
 }
 
-func (policyFactory PolicyFactory) newApmConditions(conditions []v1alpha1.ApmCondition) ([]*domain.ApmCondition, error) {
-	result := make([]*domain.ApmCondition, len(conditions))
-	for i, condition := range conditions {
-		condition, err := policyFactory.newApmAlertCondition(condition)
+func newExternalServiceConditions(resolver *entityResolver, conditions []v1alpha1.ExternalServiceCondition) ([]*domain.ExternalServiceCondition, error) {
+	result := make([]*domain.ExternalServiceCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		entityIds, ok, err := resolver.resolve(condition.Name, entities.KindApplication, applications.NewSelectors(condition.Entities, nil), condition.MissingEntities)
 		if err != nil {
 			return nil, err
 		}
-		result[i] = condition
+		if !ok {
+			result = append(result, &domain.ExternalServiceCondition{Condition: domain.ExternalServiceConditionBody{Name: condition.Name}, Retained: true})
+			continue
+		}
+
+		result = append(result, newExternalServiceCondition(condition, entityIds))
 	}
 
 	return result, nil
 }
 
-func (policyFactory PolicyFactory) newApmAlertCondition(condition v1alpha1.ApmCondition) (*domain.ApmCondition, error) {
-	entityIds, err := policyFactory.getApplicationIds(condition)
-	if err != nil {
-		return nil, err
+func newExternalServiceCondition(condition v1alpha1.ExternalServiceCondition, entityIds []string) *domain.ExternalServiceCondition {
+	return &domain.ExternalServiceCondition{
+		Condition: domain.ExternalServiceConditionBody{
+			Name:               condition.Name,
+			Type:               domain.ExternalServiceConditionTypeApm,
+			Enabled:            boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
+			Entities:           entityIds,
+			ExternalServiceUrl: condition.ExternalServiceUrl,
+			Metric:             condition.Metric,
+			RunbookUrl:         condition.RunbookUrl,
+			Terms:              newThresholds(condition.CriticalThreshold, condition.WarningThreshold),
+		},
 	}
+}
 
+// addSyntheticsConditions adds single monitor conditions and multi-location conditions to the policy,
+// which New Relic manages through different endpoints
+func (policyFactory PolicyFactory) addSyntheticsConditions(policy *domain.AlertPolicy, conditions []v1alpha1.SyntheticsCondition) error {
+	for _, condition := range conditions {
+		monitorIds, err := policyFactory.getMonitorIds(condition)
+		if err != nil {
+			return err
+		}
+
+		if condition.LocationThresholds == nil {
+			policy.SyntheticsConditions = append(policy.SyntheticsConditions, newSyntheticsCondition(condition, monitorIds[0]))
+		} else {
+			policy.LocationFailureConditions = append(policy.LocationFailureConditions, newLocationFailureCondition(condition, monitorIds))
+		}
+	}
+
+	return nil
+}
+
+func newSyntheticsCondition(condition v1alpha1.SyntheticsCondition, monitorId string) *domain.SyntheticsCondition {
+	return &domain.SyntheticsCondition{
+		Condition: domain.SyntheticsConditionBody{
+			Name:       condition.Name,
+			MonitorId:  monitorId,
+			RunbookUrl: condition.RunbookUrl,
+			Enabled:    boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
+		},
+	}
+}
+
+func newLocationFailureCondition(condition v1alpha1.SyntheticsCondition, monitorIds []string) *domain.LocationFailureCondition {
+	terms := []domain.LocationTerm{
+		{Priority: domain.PriorityCritical, Threshold: condition.LocationThresholds.Critical},
+	}
+	if condition.LocationThresholds.Warning != nil {
+		terms = append(terms, domain.LocationTerm{Priority: domain.PriorityWarning, Threshold: *condition.LocationThresholds.Warning})
+	}
+
+	return &domain.LocationFailureCondition{
+		Condition: domain.LocationFailureConditionBody{
+			Name:       condition.Name,
+			Enabled:    boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
+			Entities:   monitorIds,
+			Terms:      terms,
+			RunbookUrl: condition.RunbookUrl,
+		},
+	}
+}
+
+func (policyFactory PolicyFactory) newApmConditions(resolver *entityResolver, conditions []v1alpha1.ApmCondition) ([]*domain.ApmCondition, error) {
+	result := make([]*domain.ApmCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		if err := validateApmCondition(condition); err != nil {
+			return nil, err
+		}
+
+		entityIds, ok, err := resolver.resolve(condition.Name, entityKind(condition.Type), applications.NewSelectors(condition.Entities, condition.EntitySelectors), condition.MissingEntities)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			result = append(result, &domain.ApmCondition{Condition: domain.ApmConditionBody{Name: condition.Name}, Retained: true})
+			continue
+		}
+
+		result = append(result, newApmAlertCondition(condition, entityIds))
+	}
+
+	return result, nil
+}
+
+func validateApmCondition(condition v1alpha1.ApmCondition) error {
+	conditionScope := stringWithDefault(condition.ConditionScope, v1alpha1.DefaultConditionScope)
+	if err := apm.ValidateMetric(condition.Type, condition.Metric); err != nil {
+		return fmt.Errorf("apm condition %s: %v", condition.Name, err)
+	}
+	if err := apm.ValidateConditionScope(condition.Type, conditionScope); err != nil {
+		return fmt.Errorf("apm condition %s: %v", condition.Name, err)
+	}
+
+	return nil
+}
+
+func newApmAlertCondition(condition v1alpha1.ApmCondition, entityIds []string) *domain.ApmCondition {
 	return &domain.ApmCondition{
 		Condition: domain.ApmConditionBody{
 			Name:                condition.Name,
 			Type:                condition.Type,
-			Enabled:             boolWithDefault(condition.Enabled, true),
+			Enabled:             boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
 			Entities:            entityIds,
-			ConditionScope:      stringWithDefault(condition.ConditionScope, "application"),
+			ConditionScope:      stringWithDefault(condition.ConditionScope, v1alpha1.DefaultConditionScope),
 			Metric:              condition.Metric,
 			ViolationCloseTimer: condition.ViolationCloseTimer,
 			RunbookUrl:          condition.RunbookUrl,
 			Terms:               newThresholds(condition.CriticalThreshold, condition.WarningThreshold),
 			UserDefined:         newUserDefined(condition),
 		},
-	}, nil
+	}
 }
 
 func newUserDefined(condition v1alpha1.ApmCondition) *domain.UserDefined {
@@ -97,20 +255,77 @@ func (policyFactory PolicyFactory) newNrqlConditions(conditions []v1alpha1.NrqlC
 func (policyFactory PolicyFactory) newNrqlAlertCondition(condition v1alpha1.NrqlCondition) *domain.NrqlCondition {
 	return &domain.NrqlCondition{
 		Condition: domain.NrqlConditionBody{
-			Type:          "static",
-			Name:          condition.Name,
-			RunbookURL:    condition.RunbookUrl,
-			Enabled:       boolWithDefault(condition.Enabled, true),
-			Terms:         newThresholds(condition.AlertThreshold, condition.WarningThreshold),
-			ValueFunction: condition.ValueFunction,
+			Type:              stringWithDefault(condition.Type, v1alpha1.DefaultNrqlConditionType),
+			Name:              condition.Name,
+			RunbookURL:        condition.RunbookUrl,
+			Enabled:           boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
+			Terms:             newThresholds(condition.AlertThreshold, condition.WarningThreshold),
+			ValueFunction:     condition.ValueFunction,
+			BaselineDirection: stringWithDefault(condition.BaselineDirection, ""),
+			ExpectedGroups:    intWithDefault(condition.ExpectedGroups, 0),
+			IgnoreOverlap:     boolWithDefault(condition.IgnoreOverlap, v1alpha1.DefaultIgnoreOverlap),
 			Nrql: domain.Nrql{
 				Query:      condition.Query,
 				SinceValue: strconv.Itoa(condition.Since),
 			},
+			StreamingSettings: newNrqlStreamingSettings(condition.Signal, condition.Expiration),
 		},
 	}
 }
 
+// newNrqlStreamingSettings returns nil when neither the signal nor the expiration of the condition are managed
+func newNrqlStreamingSettings(signal *v1alpha1.NrqlSignal, expiration *v1alpha1.NrqlExpiration) *domain.NrqlStreamingSettings {
+	if signal == nil && expiration == nil {
+		return nil
+	}
+
+	settings := &domain.NrqlStreamingSettings{}
+	if signal != nil {
+		settings.Signal = domain.NrqlSignal{
+			AggregationWindow: signal.AggregationWindow,
+			AggregationMethod: upperCase(signal.AggregationMethod),
+			AggregationDelay:  signal.AggregationDelay,
+			AggregationTimer:  signal.AggregationTimer,
+			EvaluationOffset:  signal.EvaluationOffset,
+			FillOption:        upperCase(signal.FillOption),
+			FillValue:         parseFloat(signal.FillValue),
+		}
+	}
+	if expiration != nil {
+		settings.Expiration = domain.NrqlExpiration{
+			ExpirationDuration:          expiration.ExpirationDuration,
+			OpenViolationOnExpiration:   expiration.OpenViolationOnExpiration,
+			CloseViolationsOnExpiration: expiration.CloseViolationsOnExpiration,
+		}
+	}
+
+	return settings
+}
+
+// upperCase converts the lower case enum values of the custom resource to the enum values of NerdGraph
+func upperCase(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := strings.ToUpper(*value)
+	return &result
+}
+
+// parseFloat expects a value which passed validation
+func parseFloat(value *string) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	result, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &result
+}
+
 func newThresholds(criticalThreshold v1alpha1.Threshold, warningThreshold *v1alpha1.Threshold) []domain.Term {
 	var terms []domain.Term
 	criticalTerm := domain.Term{
@@ -145,38 +360,60 @@ func (policyFactory PolicyFactory) newInfraConditions(conditions []v1alpha1.Infr
 }
 
 func (policyFactory PolicyFactory) newInfraAlertCondition(condition v1alpha1.InfraCondition) *domain.InfraCondition {
+	conditionType := stringWithDefault(condition.Type, v1alpha1.DefaultInfraConditionType)
 	return &domain.InfraCondition{
 		Condition: domain.InfraConditionBody{
-			Name:       condition.Name,
-			Type:       "infra_metric",
-			Comparison: condition.Comparison,
-			CriticalThreshold: domain.InfraThreshold{
-				TimeFunction:    condition.CriticalThreshold.TimeFunction,
-				Value:           condition.CriticalThreshold.Value,
-				DurationMinutes: condition.CriticalThreshold.DurationMinutes,
-			},
-			WarningThreshold:    maybeInfraThreshold(condition.WarningThreshold),
-			Enabled:             boolWithDefault(condition.Enabled, true),
+			Name:                condition.Name,
+			Type:                conditionType,
+			Comparison:          infraComparison(condition.Comparison),
+			CriticalThreshold:   newInfraThreshold(conditionType, condition.CriticalThreshold),
+			WarningThreshold:    maybeInfraThreshold(conditionType, condition.WarningThreshold),
+			Enabled:             boolWithDefault(condition.Enabled, v1alpha1.DefaultConditionEnabled),
 			EventType:           condition.EventType,
 			IntegrationProvider: condition.IntegrationProvider,
 			RunbookUrl:          condition.RunbookUrl,
 			SelectValue:         condition.SelectValue,
 			ViolationCloseTimer: condition.ViolationCloseTimer,
 			WhereClause:         condition.WhereClause,
+			ProcessWhereClause:  condition.ProcessWhereClause,
 		},
 	}
 }
 
-func maybeInfraThreshold(threshold *v1alpha1.InfraThreshold) *domain.InfraThreshold {
-	if threshold == nil {
-		return nil
+// infraComparison maps the v1alpha1 spelling `bellow` to the value expected by New Relic
+func infraComparison(comparison string) string {
+	if comparison == "bellow" {
+		return "below"
 	}
 
-	return &domain.InfraThreshold{
-		TimeFunction:    threshold.TimeFunction,
-		Value:           threshold.Value,
+	return comparison
+}
+
+// newInfraThreshold leaves out the threshold fields which New Relic does not accept for the condition type
+func newInfraThreshold(conditionType string, threshold v1alpha1.InfraThreshold) domain.InfraThreshold {
+	result := domain.InfraThreshold{
 		DurationMinutes: threshold.DurationMinutes,
 	}
+	if conditionType == v1alpha1.InfraConditionTypeHostNotReporting {
+		return result
+	}
+
+	value := threshold.Value
+	result.Value = &value
+	if conditionType == v1alpha1.InfraConditionTypeMetric {
+		result.TimeFunction = threshold.TimeFunction
+	}
+
+	return result
+}
+
+func maybeInfraThreshold(conditionType string, threshold *v1alpha1.InfraThreshold) *domain.InfraThreshold {
+	if threshold == nil {
+		return nil
+	}
+
+	result := newInfraThreshold(conditionType, *threshold)
+	return &result
 }
 
 func boolWithDefault(enabled *bool, defaultValue bool) bool {
@@ -187,6 +424,14 @@ func boolWithDefault(enabled *bool, defaultValue bool) bool {
 	return *enabled
 }
 
+func intWithDefault(value *int, defaultValue int) int {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
+
 func stringWithDefault(scope *string, defaultValue string) string {
 	if scope == nil {
 		return defaultValue
@@ -195,18 +440,19 @@ func stringWithDefault(scope *string, defaultValue string) string {
 	return *scope
 }
 
-func (policyFactory PolicyFactory) getApplicationIds(condition v1alpha1.ApmCondition) ([]string, error) {
-	var result []string
-	for _, item := range condition.Entities {
-		application, err := policyFactory.appRepository.GetApplicationByName(item)
+func (policyFactory PolicyFactory) getMonitorIds(condition v1alpha1.SyntheticsCondition) ([]string, error) {
+	if len(condition.Monitors) == 0 {
+		return nil, fmt.Errorf("synthetics condition %s: at least one monitor is required", condition.Name)
+	}
+
+	result := make([]string, len(condition.Monitors))
+	for i, name := range condition.Monitors {
+		monitor, err := policyFactory.monitorRepository.GetMonitorByName(name)
 		if err != nil {
 			return nil, err
 		}
-		if application == nil {
-			continue
-		}
 
-		result = append(result, strconv.Itoa(application.Id))
+		result[i] = monitor.Id
 	}
 
 	return result, nil
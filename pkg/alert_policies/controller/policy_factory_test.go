@@ -3,7 +3,11 @@ package controller_test
 import (
 	"github.com/personio/newrelic-alert-manager/internal/mocks"
 	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/controller"
-	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
+	"strings"
 	"testing"
 )
 
@@ -17,11 +21,11 @@ func TestPolicyFactory_NewAlertPolicy_ApmCondition(t *testing.T) {
 		nil,
 	)
 
-	repository := applications.NewRepository(client)
+	repository := entities.NewRepository(client)
 
 	policy := newPolicyWithApmCondition("test-policy", "test-entity")
-	factory := controller.NewPolicyFactory(repository)
-	domainPolicy, err := factory.NewAlertPolicy(policy)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
 	if err != nil {
 		t.Error(err)
 	}
@@ -29,6 +33,9 @@ func TestPolicyFactory_NewAlertPolicy_ApmCondition(t *testing.T) {
 	if domainPolicy.ApmConditions[0].Condition.Entities[0] != "10" {
 		t.Error("Entity ID should be 10")
 	}
+	if resolution.Dynamic {
+		t.Error("Conditions listing applications by name should not be resolved periodically")
+	}
 }
 
 func TestPolicyFactory_NewAlertPolicy_ApmCondition_NonExistentEntity(t *testing.T) {
@@ -41,12 +48,12 @@ func TestPolicyFactory_NewAlertPolicy_ApmCondition_NonExistentEntity(t *testing.
 		nil,
 	)
 
-	repository := applications.NewRepository(client)
+	repository := entities.NewRepository(client)
 
 	policy := newPolicyWithApmCondition("test-policy", "test-entity")
-	factory := controller.NewPolicyFactory(repository)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
 
-	_, err := factory.NewAlertPolicy(policy)
+	_, _, err := factory.NewAlertPolicy(policy)
 	expoectedError := "application with name test-entity does not exist"
 	if err == nil || err.Error() != expoectedError {
 		t.Errorf("Expected error %s", expoectedError)
@@ -63,14 +70,559 @@ func TestPolicyFactory_NewAlertPolicy_ApmCondition_MisnamedEntity(t *testing.T)
 		nil,
 	)
 
-	repository := applications.NewRepository(client)
+	repository := entities.NewRepository(client)
 
 	policy := newPolicyWithApmCondition("test-policy", "test-entity")
-	factory := controller.NewPolicyFactory(repository)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
 
-	_, err := factory.NewAlertPolicy(policy)
+	_, _, err := factory.NewAlertPolicy(policy)
 	expoectedError := "application with name test-entity does not exist"
 	if err == nil || err.Error() != expoectedError {
 		t.Errorf("Expected error %s", expoectedError)
 	}
 }
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_SkipMissingEntity(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=test-entity").Return(newResponse(10, "test-entity"), nil)
+	client.On("Get", "/applications.json?filter[name]=removed-entity").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Entities = []string{"test-entity", "removed-entity"}
+	policy.Spec.ApmConditions[0].MissingEntities = stringPtr(v1alpha1.MissingEntitiesSkip)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entities := domainPolicy.ApmConditions[0].Condition.Entities
+	if len(entities) != 1 || entities[0] != "10" {
+		t.Errorf("Expected only entity 10, got %v", entities)
+	}
+	if len(unresolved) != 1 || unresolved[0].Entity != "removed-entity" {
+		t.Errorf("Expected removed-entity to be unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_EntitySelectors(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=test-entity").Return(newResponse(10, "test-entity"), nil)
+	client.On("Get", "/applications.json?page=1").Return(newStringResponse(`
+		{
+			"applications": [
+				{"id": 10, "name": "test-entity"},
+				{"id": 11, "name": "test-worker"},
+				{"id": 12, "name": "other"}
+			]
+		}
+	`), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].EntitySelectors = []common.EntitySelector{{NamePattern: "test-.*"}}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entities := domainPolicy.ApmConditions[0].Condition.Entities
+	if len(entities) != 2 || entities[0] != "10" || entities[1] != "11" {
+		t.Errorf("Expected entities 10 and 11, got %v", entities)
+	}
+	if len(unresolved) != 0 {
+		t.Errorf("Expected no unresolved entities, got %v", unresolved)
+	}
+	if !resolution.Dynamic {
+		t.Error("Conditions with a name pattern should be resolved periodically")
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_BrowserCondition(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/browser_applications.json?filter[name]=test-entity").Return(newStringResponse(`
+		{"browser_applications": [{"id": 20, "name": "test-entity"}]}
+	`), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Type = "browser_metric"
+	policy.Spec.ApmConditions[0].Metric = "end_user_apdex"
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domainPolicy.ApmConditions[0].Condition.Entities[0] != "20" {
+		t.Errorf("Expected entity 20, got %v", domainPolicy.ApmConditions[0].Condition.Entities)
+	}
+	if len(resolution.Resolved) != 1 {
+		t.Fatalf("Expected one resolved entity, got %v", resolution.Resolved)
+	}
+
+	resolved := resolution.Resolved[0]
+	if resolved.Condition != "condition" || resolved.Kind != "browser_application" || len(resolved.Ids) != 1 || resolved.Ids[0] != 20 {
+		t.Errorf("Unexpected resolved entity %v", resolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_MobileCondition_NonExistentEntity(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/mobile_applications.json?page=1").Return(newStringResponse(`{"applications": []}`), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Type = "mobile_metric"
+	policy.Spec.ApmConditions[0].Metric = "mobile_crash_rate"
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	_, _, err := factory.NewAlertPolicy(policy)
+	if err == nil || err.Error() != "mobile_application with name test-entity does not exist" {
+		t.Errorf("Expected a missing mobile application error, got %v", err)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_EntitySelectorWithoutMatch(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/labels.json?page=1").Return(newStringResponse(`{"labels": []}`), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Entities = nil
+	policy.Spec.ApmConditions[0].EntitySelectors = []common.EntitySelector{{Label: "Team:Payments"}}
+	policy.Spec.ApmConditions[0].MissingEntities = stringPtr(v1alpha1.MissingEntitiesWait)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.ApmConditions) != 1 || !domainPolicy.ApmConditions[0].Retained || domainPolicy.ApmConditions[0].Condition.Name != "condition" {
+		t.Errorf("Expected the existing condition to be retained, got %v", domainPolicy.ApmConditions)
+	}
+	if len(unresolved) != 1 || unresolved[0].Entity != "label=Team:Payments" {
+		t.Errorf("Expected the selector to be unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_SkipAllEntitiesMissing(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=test-entity").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].MissingEntities = stringPtr(v1alpha1.MissingEntitiesSkip)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.ApmConditions) != 1 || !domainPolicy.ApmConditions[0].Retained || domainPolicy.ApmConditions[0].Condition.Name != "condition" {
+		t.Errorf("Expected the existing condition to be retained, got %v", domainPolicy.ApmConditions)
+	}
+	if len(unresolved) != 1 || unresolved[0].Condition != "condition" {
+		t.Errorf("Expected the condition to be reported as unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_WaitForMissingEntity(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=test-entity").Return(newResponse(10, "test-entity"), nil)
+	client.On("Get", "/applications.json?filter[name]=new-entity").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Entities = []string{"test-entity", "new-entity"}
+	policy.Spec.ApmConditions[0].MissingEntities = stringPtr(v1alpha1.MissingEntitiesWait)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.ApmConditions) != 1 || !domainPolicy.ApmConditions[0].Retained || domainPolicy.ApmConditions[0].Condition.Entities != nil {
+		t.Errorf("Expected the existing condition to be retained until all entities exist, got %v", domainPolicy.ApmConditions)
+	}
+	if len(unresolved) != 1 || unresolved[0].Entity != "new-entity" {
+		t.Errorf("Expected new-entity to be unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_FailReportsUnresolved(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=test-entity").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	_, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err == nil {
+		t.Error("Expected an error")
+	}
+	if len(unresolved) != 1 || unresolved[0].Entity != "test-entity" {
+		t.Errorf("Expected test-entity to be unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ApmCondition_UnsupportedMetric(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions[0].Type = "apm_jvm_metric"
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	_, _, err := factory.NewAlertPolicy(policy)
+	if err == nil || !strings.Contains(err.Error(), "metric apdex is not supported by apm_jvm_metric conditions") {
+		t.Errorf("Expected an unsupported metric error, got %v", err)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ExternalServiceCondition(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithExternalServiceCondition("test-policy", "checkout")
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := domainPolicy.ExternalServiceConditions[0].Condition
+	if condition.Entities[0] != "10" {
+		t.Errorf("Expected entity 10, got %v", condition.Entities)
+	}
+	if condition.Type != "apm_external_service" {
+		t.Errorf("Expected type apm_external_service, got %s", condition.Type)
+	}
+	if condition.ExternalServiceUrl != "api.stripe.com" || condition.Metric != "response_time_average" {
+		t.Errorf("Expected the external service and metric of the spec, got %s and %s", condition.ExternalServiceUrl, condition.Metric)
+	}
+	if !condition.Enabled {
+		t.Error("Expected the condition to be enabled by default")
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ExternalServiceCondition_WaitForMissingEntity(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithExternalServiceCondition("test-policy", "checkout")
+	policy.Spec.ExternalServiceConditions[0].MissingEntities = stringPtr(v1alpha1.MissingEntitiesWait)
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.ExternalServiceConditions) != 1 || !domainPolicy.ExternalServiceConditions[0].Retained || domainPolicy.ExternalServiceConditions[0].Condition.Name != "payments-slow" {
+		t.Errorf("Expected the existing condition to be retained, got %v", domainPolicy.ExternalServiceConditions)
+	}
+	if len(unresolved) != 1 || unresolved[0].Condition != "payments-slow" {
+		t.Errorf("Expected the condition to be reported as unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ExternalServiceCondition_NonExistentEntity(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newEmptyResponse(), nil)
+
+	repository := entities.NewRepository(client)
+
+	policy := newPolicyWithExternalServiceCondition("test-policy", "checkout")
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	_, resolution, err := factory.NewAlertPolicy(policy)
+	unresolved := resolution.Unresolved
+	if err == nil {
+		t.Error("Expected an error")
+	}
+	if len(unresolved) != 1 || unresolved[0].Entity != "checkout" {
+		t.Errorf("Expected checkout to be unresolved, got %v", unresolved)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_InfraConditionBelow(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.InfraConditions = []v1alpha1.InfraCondition{
+		{Name: "condition", Comparison: "bellow"},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domainPolicy.InfraConditions[0].Condition.Comparison != "below" {
+		t.Errorf("Expected comparison below, got %s", domainPolicy.InfraConditions[0].Condition.Comparison)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_InfraConditionDefaultsToMetric(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.InfraConditions = []v1alpha1.InfraCondition{
+		{
+			Name:              "condition",
+			Comparison:        "above",
+			SelectValue:       "cpuPercent",
+			CriticalThreshold: v1alpha1.InfraThreshold{TimeFunction: "all", Value: 90, DurationMinutes: 5},
+		},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := domainPolicy.InfraConditions[0].Condition
+	if condition.Type != "infra_metric" {
+		t.Errorf("Expected type infra_metric, got %s", condition.Type)
+	}
+	if condition.CriticalThreshold.TimeFunction != "all" || *condition.CriticalThreshold.Value != 90 {
+		t.Errorf("Expected the threshold of the spec, got %v", condition.CriticalThreshold)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_InfraProcessRunningCondition(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.InfraConditions = []v1alpha1.InfraCondition{
+		{
+			Name:               "nginx-down",
+			Type:               stringPtr("infra_process_running"),
+			Comparison:         "equal",
+			CriticalThreshold:  v1alpha1.InfraThreshold{Value: 0, DurationMinutes: 5},
+			ProcessWhereClause: "commandName = 'nginx'",
+		},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := domainPolicy.InfraConditions[0].Condition
+	if condition.Type != "infra_process_running" || condition.ProcessWhereClause != "commandName = 'nginx'" {
+		t.Errorf("Expected a process running condition for nginx, got %v", condition)
+	}
+	if condition.CriticalThreshold.Value == nil || *condition.CriticalThreshold.Value != 0 {
+		t.Errorf("Expected the process count 0 to be sent, got %v", condition.CriticalThreshold.Value)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_InfraHostNotReportingCondition(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.InfraConditions = []v1alpha1.InfraCondition{
+		{
+			Name:              "host-down",
+			Type:              stringPtr("infra_host_not_reporting"),
+			CriticalThreshold: v1alpha1.InfraThreshold{DurationMinutes: 10},
+		},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	threshold := domainPolicy.InfraConditions[0].Condition.CriticalThreshold
+	if threshold.Value != nil || threshold.TimeFunction != "" || threshold.DurationMinutes != 10 {
+		t.Errorf("Expected a threshold with only a duration, got %v", threshold)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_BaselineNrqlCondition(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.NrqlConditions = []v1alpha1.NrqlCondition{
+		{Name: "condition", Type: stringPtr("baseline"), BaselineDirection: stringPtr("lower_only")},
+		{Name: "static-condition"},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseline := domainPolicy.NrqlConditions[0].Condition
+	if baseline.Type != "baseline" || baseline.BaselineDirection != "lower_only" {
+		t.Errorf("Expected a lower_only baseline condition, got %s %s", baseline.Type, baseline.BaselineDirection)
+	}
+	if domainPolicy.NrqlConditions[1].Condition.Type != "static" {
+		t.Errorf("Expected type static, got %s", domainPolicy.NrqlConditions[1].Condition.Type)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_OutlierNrqlCondition(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	expectedGroups := 2
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.NrqlConditions = []v1alpha1.NrqlCondition{
+		{Name: "condition", Type: stringPtr("outlier"), ExpectedGroups: &expectedGroups},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outlier := domainPolicy.NrqlConditions[0].Condition
+	if outlier.Type != "outlier" || outlier.ExpectedGroups != 2 || outlier.IgnoreOverlap {
+		t.Errorf("Expected an outlier condition with 2 groups, got %+v", outlier)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_NrqlStreamingSettings(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.NrqlConditions = []v1alpha1.NrqlCondition{
+		{
+			Name: "condition",
+			Signal: &v1alpha1.NrqlSignal{
+				AggregationMethod: stringPtr("event_timer"),
+				AggregationTimer:  intPtr(60),
+				FillOption:        stringPtr("static"),
+				FillValue:         stringPtr("0.5"),
+			},
+		},
+		{Name: "unmanaged-condition"},
+	}
+	factory := controller.NewPolicyFactory(repository, monitors.NewRepository(new(mocks.NewrelicClient)), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signal := domainPolicy.NrqlConditions[0].Condition.StreamingSettings.Signal
+	if *signal.AggregationMethod != "EVENT_TIMER" || *signal.AggregationTimer != 60 || *signal.FillOption != "STATIC" || *signal.FillValue != 0.5 {
+		t.Errorf("Expected an event timer signal filled with 0.5, got %+v", signal)
+	}
+	if signal.AggregationWindow != nil || signal.AggregationDelay != nil {
+		t.Errorf("Expected the fields left empty to stay unmanaged, got %+v", signal)
+	}
+	if domainPolicy.NrqlConditions[1].Condition.StreamingSettings != nil {
+		t.Errorf("Expected no streaming settings, got %+v", domainPolicy.NrqlConditions[1].Condition.StreamingSettings)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_SyntheticsConditions(t *testing.T) {
+	monitorClient := new(mocks.NewrelicClient)
+	monitorClient.On(
+		"Get",
+		"monitors?offset=0&limit=100",
+	).Return(
+		newMonitorResponse(map[string]string{"abc-123": "homepage", "def-456": "checkout"}),
+		nil,
+	)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{Name: "homepage-down", Monitors: []string{"homepage"}},
+		{
+			Name:               "checkout-down",
+			Monitors:           []string{"checkout", "homepage"},
+			LocationThresholds: &v1alpha1.LocationThresholds{Critical: 3, Warning: intPtr(2)},
+		},
+	}
+	factory := controller.NewPolicyFactory(entities.NewRepository(new(mocks.NewrelicClient)), monitors.NewRepository(monitorClient), newTemplateRepository(), newConditionRepository())
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.SyntheticsConditions) != 1 || domainPolicy.SyntheticsConditions[0].Condition.MonitorId != "abc-123" {
+		t.Errorf("Expected a condition for monitor abc-123, got %v", domainPolicy.SyntheticsConditions)
+	}
+	if len(domainPolicy.LocationFailureConditions) != 1 {
+		t.Fatalf("Expected a multi-location condition, got %v", domainPolicy.LocationFailureConditions)
+	}
+
+	condition := domainPolicy.LocationFailureConditions[0].Condition
+	if len(condition.Entities) != 2 || condition.Entities[0] != "def-456" || condition.Entities[1] != "abc-123" {
+		t.Errorf("Expected monitors def-456 and abc-123, got %v", condition.Entities)
+	}
+	if len(condition.Terms) != 2 || condition.Terms[0].Threshold != 3 || condition.Terms[1].Threshold != 2 {
+		t.Errorf("Expected critical and warning terms, got %v", condition.Terms)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_SyntheticsCondition_NonExistentMonitor(t *testing.T) {
+	monitorClient := new(mocks.NewrelicClient)
+	monitorClient.On(
+		"Get",
+		"monitors?offset=0&limit=100",
+	).Return(
+		newMonitorResponse(map[string]string{"abc-123": "homepage"}),
+		nil,
+	)
+
+	policy := newPolicyWithApmCondition("test-policy", "test-entity")
+	policy.Spec.ApmConditions = nil
+	policy.Spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{Name: "checkout-down", Monitors: []string{"checkout"}},
+	}
+	factory := controller.NewPolicyFactory(entities.NewRepository(new(mocks.NewrelicClient)), monitors.NewRepository(monitorClient), newTemplateRepository(), newConditionRepository())
+
+	_, _, err := factory.NewAlertPolicy(policy)
+	expectedError := "monitor with name checkout does not exist"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("Expected error %s, got %v", expectedError, err)
+	}
+}
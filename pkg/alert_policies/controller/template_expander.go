@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// expandTemplates returns the spec of the policy with the conditions of its templates added.
+// The resulting conditions are validated like the ones listed in the policy itself
+func (policyFactory PolicyFactory) expandTemplates(cr *v1alpha1.AlertPolicy) (v1alpha1.AlertPolicySpec, error) {
+	spec := *cr.Spec.DeepCopy()
+	if len(spec.Templates) == 0 {
+		return spec, nil
+	}
+
+	for _, reference := range cr.Spec.Templates {
+		templateSpec, err := policyFactory.templateRepository.GetTemplate(cr.Namespace, reference)
+		if err != nil {
+			return spec, fmt.Errorf("template %s: %v", reference.Name, err)
+		}
+
+		conditions, err := renderTemplate(*templateSpec, reference.Parameters)
+		if err != nil {
+			return spec, fmt.Errorf("template %s: %v", reference.Name, err)
+		}
+
+		spec.ApmConditions = append(spec.ApmConditions, conditions.ApmConditions...)
+		spec.NrqlConditions = append(spec.NrqlConditions, conditions.NrqlConditions...)
+		spec.InfraConditions = append(spec.InfraConditions, conditions.InfraConditions...)
+	}
+
+	if errs := spec.Validate(field.NewPath("spec")); len(errs) > 0 {
+		return spec, errs.ToAggregate()
+	}
+
+	return spec, nil
+}
+
+// renderTemplate replaces the placeholders in the string fields of the template conditions with the parameter values
+func renderTemplate(templateSpec v1alpha1.AlertConditionTemplateSpec, values map[string]string) (v1alpha1.AlertConditionTemplateSpec, error) {
+	var result v1alpha1.AlertConditionTemplateSpec
+	parameters, err := newTemplateParameters(templateSpec.Parameters, values)
+	if err != nil {
+		return result, err
+	}
+
+	// The conditions are rendered as a JSON document, so that every string field is covered
+	// without listing them, and converted back to the condition types afterwards
+	content, err := json.Marshal(v1alpha1.AlertConditionTemplateSpec{
+		ApmConditions:   templateSpec.ApmConditions,
+		NrqlConditions:  templateSpec.NrqlConditions,
+		InfraConditions: templateSpec.InfraConditions,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return result, err
+	}
+
+	rendered, err := renderValue(document, parameters)
+	if err != nil {
+		return result, err
+	}
+
+	content, err = json.Marshal(rendered)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(content, &result)
+	return result, err
+}
+
+// newTemplateParameters returns the values of the template parameters, checked against their type.
+// Parameters which are not passed take their default value.
+// The values are kept as text, since placeholders can only be used in string fields
+func newTemplateParameters(parameters []v1alpha1.TemplateParameter, values map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(parameters))
+	for _, parameter := range parameters {
+		value, ok := values[parameter.Name]
+		if !ok {
+			if parameter.Default == nil {
+				return nil, fmt.Errorf("parameter %s is required", parameter.Name)
+			}
+			value = *parameter.Default
+		}
+
+		err := checkTemplateParameter(stringWithDefault(parameter.Type, v1alpha1.DefaultTemplateParameterType), value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %v", parameter.Name, err)
+		}
+		result[parameter.Name] = value
+	}
+
+	var unknown []string
+	for name := range values {
+		if _, ok := result[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameters %s", strings.Join(unknown, ", "))
+	}
+
+	return result, nil
+}
+
+// checkTemplateParameter returns an error when the value does not have the format of the parameter type
+func checkTemplateParameter(parameterType string, value string) error {
+	switch parameterType {
+	case v1alpha1.TemplateParameterTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s is not an int", value)
+		}
+	case v1alpha1.TemplateParameterTypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s is not a float", value)
+		}
+	case v1alpha1.TemplateParameterTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s is not a bool", value)
+		}
+	}
+
+	return nil
+}
+
+func renderValue(value interface{}, parameters map[string]string) (interface{}, error) {
+	switch value := value.(type) {
+	case string:
+		return renderString(value, parameters)
+	case []interface{}:
+		for i, item := range value {
+			rendered, err := renderValue(item, parameters)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = rendered
+		}
+		return value, nil
+	case map[string]interface{}:
+		for key, item := range value {
+			rendered, err := renderValue(item, parameters)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = rendered
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func renderString(value string, parameters map[string]string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	parsed, err := template.New("").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	if err := parsed.Execute(&result, parameters); err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
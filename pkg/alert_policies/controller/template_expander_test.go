@@ -0,0 +1,364 @@
+package controller_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/controller"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
+	"io/ioutil"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+func TestPolicyFactory_NewAlertPolicy_Template(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	policy := newPolicyWithTemplate(v1alpha1.AlertConditionTemplateReference{
+		Name:       "service-defaults",
+		Parameters: map[string]string{"appName": "checkout"},
+	})
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(newTemplate("default", "service-defaults", newTemplateSpec())),
+		newConditionRepository(),
+	)
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apmCondition := domainPolicy.ApmConditions[0].Condition
+	if apmCondition.Name != "checkout-error-rate" || apmCondition.Entities[0] != "10" {
+		t.Errorf("Expected the error rate condition of checkout, got %s %v", apmCondition.Name, apmCondition.Entities)
+	}
+
+	nrqlCondition := domainPolicy.NrqlConditions[0].Condition
+	if nrqlCondition.Nrql.Query != "SELECT count(*) FROM TransactionError WHERE appName = 'checkout'" {
+		t.Errorf("Unexpected query %s", nrqlCondition.Nrql.Query)
+	}
+	if nrqlCondition.Terms[0].Threshold != "100" {
+		t.Errorf("Expected the default threshold 100, got %s", nrqlCondition.Terms[0].Threshold)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_ClusterTemplate(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	policy := newPolicyWithTemplate(v1alpha1.AlertConditionTemplateReference{
+		Kind:       stringPtr("ClusterAlertConditionTemplate"),
+		Name:       "service-defaults",
+		Parameters: map[string]string{"appName": "checkout", "errorCount": "250"},
+	})
+	template := &v1alpha1.ClusterAlertConditionTemplate{
+		ObjectMeta: v1.ObjectMeta{Name: "service-defaults"},
+		Spec:       newTemplateSpec(),
+	}
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(template),
+		newConditionRepository(),
+	)
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domainPolicy.NrqlConditions[0].Condition.Terms[0].Threshold != "250" {
+		t.Errorf("Expected the threshold 250, got %s", domainPolicy.NrqlConditions[0].Condition.Terms[0].Threshold)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_TemplateNumericThreshold(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	templateSpec := newTemplateSpec()
+	templateSpec.Parameters = append(templateSpec.Parameters, v1alpha1.TemplateParameter{Name: "errorRate", Type: stringPtr("float")})
+	templateSpec.ApmConditions[0].CriticalThreshold.Value = "{{ .errorRate }}"
+
+	policy := newPolicyWithTemplate(v1alpha1.AlertConditionTemplateReference{
+		Name:       "service-defaults",
+		Parameters: map[string]string{"appName": "checkout", "errorCount": "250", "errorRate": "2.50"},
+	})
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(newTemplate("default", "service-defaults", templateSpec)),
+		newConditionRepository(),
+	)
+
+	domainPolicy, _, err := factory.NewAlertPolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if threshold := domainPolicy.ApmConditions[0].Condition.Terms[0].Threshold; threshold != "2.50" {
+		t.Errorf("Expected the threshold 2.50 as passed by the policy, got %s", threshold)
+	}
+	if threshold := domainPolicy.NrqlConditions[0].Condition.Terms[0].Threshold; threshold != "250" {
+		t.Errorf("Expected the threshold 250, got %s", threshold)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_TemplateErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		reference     v1alpha1.AlertConditionTemplateReference
+		expectedError string
+	}{
+		{
+			name:          "missing template",
+			reference:     v1alpha1.AlertConditionTemplateReference{Name: "unknown"},
+			expectedError: "template unknown: alertconditiontemplates \"unknown\" not found",
+		},
+		{
+			name:          "missing parameter",
+			reference:     v1alpha1.AlertConditionTemplateReference{Name: "service-defaults"},
+			expectedError: "template service-defaults: parameter appName is required",
+		},
+		{
+			name: "invalid parameter type",
+			reference: v1alpha1.AlertConditionTemplateReference{
+				Name:       "service-defaults",
+				Parameters: map[string]string{"appName": "checkout", "errorCount": "many"},
+			},
+			expectedError: "template service-defaults: parameter errorCount: many is not an int",
+		},
+		{
+			name: "fractional int parameter",
+			reference: v1alpha1.AlertConditionTemplateReference{
+				Name:       "service-defaults",
+				Parameters: map[string]string{"appName": "checkout", "errorCount": "2.5"},
+			},
+			expectedError: "template service-defaults: parameter errorCount: 2.5 is not an int",
+		},
+		{
+			name: "unknown parameters",
+			reference: v1alpha1.AlertConditionTemplateReference{
+				Name:       "service-defaults",
+				Parameters: map[string]string{"appName": "checkout", "team": "payments", "region": "eu"},
+			},
+			expectedError: "template service-defaults: unknown parameters region, team",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			factory := controller.NewPolicyFactory(
+				entities.NewRepository(new(mocks.NewrelicClient)),
+				monitors.NewRepository(new(mocks.NewrelicClient)),
+				newTemplateRepository(newTemplate("default", "service-defaults", newTemplateSpec())),
+				newConditionRepository(),
+			)
+
+			_, _, err := factory.NewAlertPolicy(newPolicyWithTemplate(test.reference))
+			if err == nil || err.Error() != test.expectedError {
+				t.Errorf("Expected error %s, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_TemplateInOtherNamespace(t *testing.T) {
+	policy := newPolicyWithTemplate(v1alpha1.AlertConditionTemplateReference{
+		Name:       "service-defaults",
+		Parameters: map[string]string{"appName": "checkout"},
+	})
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(new(mocks.NewrelicClient)),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(newTemplate("other", "service-defaults", newTemplateSpec())),
+		newConditionRepository(),
+	)
+
+	_, _, err := factory.NewAlertPolicy(policy)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_TemplateDuplicateConditionName(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(newResponse(10, "checkout"), nil)
+
+	policy := newPolicyWithTemplate(
+		v1alpha1.AlertConditionTemplateReference{Name: "service-defaults", Parameters: map[string]string{"appName": "checkout"}},
+		v1alpha1.AlertConditionTemplateReference{Name: "service-defaults", Parameters: map[string]string{"appName": "checkout"}},
+	)
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(newTemplate("default", "service-defaults", newTemplateSpec())),
+		newConditionRepository(),
+	)
+
+	_, _, err := factory.NewAlertPolicy(policy)
+	if err == nil || !strings.Contains(err.Error(), "spec.apmConditions[1].name: Duplicate value") {
+		t.Errorf("Expected a duplicate condition name, got %v", err)
+	}
+}
+
+func TestPolicyFactory_NewAlertPolicy_TemplateExamples(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(func(string) *http.Response {
+		return newResponse(10, "checkout")
+	}, nil)
+
+	var policy v1alpha1.AlertPolicy
+	readExample(t, "alertpolicy_templates.yaml", &policy)
+	policy.Namespace = "default"
+
+	var objects []runtime.Object
+	for _, document := range strings.Split(readExampleFile(t, "alertconditiontemplate_cr.yaml"), "\n---\n") {
+		var meta v1.TypeMeta
+		if err := yaml.Unmarshal([]byte(document), &meta); err != nil {
+			t.Fatal(err)
+		}
+
+		var object runtime.Object = &v1alpha1.AlertConditionTemplate{}
+		if meta.Kind == v1alpha1.ClusterAlertConditionTemplateKind {
+			object = &v1alpha1.ClusterAlertConditionTemplate{}
+		}
+		if err := yaml.Unmarshal([]byte(document), object); err != nil {
+			t.Fatal(err)
+		}
+		if template, ok := object.(*v1alpha1.AlertConditionTemplate); ok {
+			template.Namespace = "default"
+		}
+		objects = append(objects, object)
+	}
+
+	factory := controller.NewPolicyFactory(
+		entities.NewRepository(client),
+		monitors.NewRepository(new(mocks.NewrelicClient)),
+		newTemplateRepository(objects...),
+		newConditionRepository(),
+	)
+
+	domainPolicy, _, err := factory.NewAlertPolicy(&policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainPolicy.ApmConditions) != 2 || len(domainPolicy.NrqlConditions) != 1 || len(domainPolicy.InfraConditions) != 1 {
+		t.Errorf("Expected the conditions of both templates, got %+v", domainPolicy)
+	}
+	if domainPolicy.ApmConditions[0].Condition.Terms[0].Threshold != "2.5" {
+		t.Errorf("Expected the error rate 2.5, got %s", domainPolicy.ApmConditions[0].Condition.Terms[0].Threshold)
+	}
+}
+
+func TestTemplateRepository_GetDependentPolicies(t *testing.T) {
+	reference := v1alpha1.AlertConditionTemplateReference{Name: "service-defaults"}
+	clusterReference := v1alpha1.AlertConditionTemplateReference{Kind: stringPtr("ClusterAlertConditionTemplate"), Name: "service-defaults"}
+
+	policies := []*v1alpha1.AlertPolicy{
+		newPolicyWithTemplate(reference),
+		newPolicyWithTemplate(clusterReference),
+		newPolicyWithTemplate(reference),
+		newPolicyWithTemplate(v1alpha1.AlertConditionTemplateReference{Name: "other"}),
+	}
+	policies[0].Name = "checkout"
+	policies[1].Name = "search"
+	policies[2].Name = "payments"
+	policies[2].Namespace = "payments"
+	policies[3].Name = "other"
+
+	repository := newTemplateRepository(policies[0], policies[1], policies[2], policies[3])
+
+	dependent, err := repository.GetDependentPolicies(v1alpha1.AlertConditionTemplateKind, "default", "service-defaults")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependent) != 1 || dependent[0].Name != "checkout" {
+		t.Errorf("Expected the checkout policy, got %v", dependent)
+	}
+
+	dependent, err = repository.GetDependentPolicies(v1alpha1.ClusterAlertConditionTemplateKind, "", "service-defaults")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependent) != 1 || dependent[0].Name != "search" {
+		t.Errorf("Expected the search policy, got %v", dependent)
+	}
+}
+
+func newPolicyWithTemplate(references ...v1alpha1.AlertConditionTemplateReference) *v1alpha1.AlertPolicy {
+	return &v1alpha1.AlertPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.AlertPolicySpec{
+			Name:               "test-policy",
+			IncidentPreference: "per_policy",
+			Templates:          references,
+		},
+	}
+}
+
+func newTemplateSpec() v1alpha1.AlertConditionTemplateSpec {
+	return v1alpha1.AlertConditionTemplateSpec{
+		Parameters: []v1alpha1.TemplateParameter{
+			{Name: "appName"},
+			{Name: "errorCount", Type: stringPtr("int"), Default: stringPtr("100")},
+		},
+		ApmConditions: []v1alpha1.ApmCondition{
+			{
+				Name:     "{{ .appName }}-error-rate",
+				Type:     "apm_app_metric",
+				Entities: []string{"{{ .appName }}"},
+				Metric:   "error_percentage",
+				CriticalThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "5",
+					DurationMinutes: 5,
+				},
+			},
+		},
+		NrqlConditions: []v1alpha1.NrqlCondition{
+			{
+				Name:          "{{ .appName }}-errors",
+				Query:         "SELECT count(*) FROM TransactionError WHERE appName = '{{ .appName }}'",
+				Since:         5,
+				ValueFunction: "single_value",
+				AlertThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "{{ .errorCount }}",
+					DurationMinutes: 5,
+				},
+			},
+		},
+	}
+}
+
+func readExample(t *testing.T, name string, object runtime.Object) {
+	t.Helper()
+	if err := yaml.Unmarshal([]byte(readExampleFile(t, name)), object); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readExampleFile(t *testing.T, name string) string {
+	t.Helper()
+	content, err := ioutil.ReadFile(filepath.Join("../../../hack/examples", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
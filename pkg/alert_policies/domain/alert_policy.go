@@ -1,10 +1,13 @@
 package domain
 
 type AlertPolicy struct {
-	Policy          Policy            `json:"policy"`
-	NrqlConditions  []*NrqlCondition  `json:"nrql_conditions,omitempty"`
-	ApmConditions   []*ApmCondition   `json:"conditions,omitempty"`
-	InfraConditions []*InfraCondition `json:"conditions,omitempty"`
+	Policy                    Policy                      `json:"policy"`
+	NrqlConditions            []*NrqlCondition            `json:"nrql_conditions,omitempty"`
+	ApmConditions             []*ApmCondition             `json:"-"`
+	InfraConditions           []*InfraCondition           `json:"-"`
+	SyntheticsConditions      []*SyntheticsCondition      `json:"-"`
+	LocationFailureConditions []*LocationFailureCondition `json:"-"`
+	ExternalServiceConditions []*ExternalServiceCondition `json:"-"`
 }
 
 func (policy AlertPolicy) Equals(other AlertPolicy) bool {
@@ -10,6 +10,9 @@ type ApmConditionList struct {
 
 type ApmCondition struct {
 	Condition ApmConditionBody `json:"condition"`
+	// Retained keeps the existing New Relic condition with the same name unchanged,
+	// e.g. while its entities are missing. Only the name of a retained condition is set
+	Retained bool `json:"-"`
 }
 
 type ApmConditionBody struct {
@@ -27,6 +30,11 @@ type ApmConditionBody struct {
 	UserDefined         *UserDefined `json:"user_defined,omitempty"`
 }
 
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (b ApmConditionBody) Equals(other ApmConditionBody) bool {
+	return b.getHashKey() == other.getHashKey()
+}
+
 func (b ApmConditionBody) getHashKey() string {
 	return fmt.Sprintf(
 		"%s-%s-%t-%s-%s-%s-%d-%s-%s-%s",
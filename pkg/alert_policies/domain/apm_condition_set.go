@@ -2,12 +2,11 @@ package domain
 
 type ApmConditionSet struct {
 	conditions map[string]ApmConditionBody
+	ids        map[int64]ApmConditionBody
 }
 
 func NewApmConditionSet(conditions ApmConditionList) *ApmConditionSet {
-	set := &ApmConditionSet{
-		conditions: make(map[string]ApmConditionBody),
-	}
+	set := newApmConditionSet()
 	for _, condition := range conditions.Condition {
 		set.put(condition)
 	}
@@ -16,9 +15,7 @@ func NewApmConditionSet(conditions ApmConditionList) *ApmConditionSet {
 }
 
 func NewApmConditionSetFromSlice(conditions []*ApmCondition) *ApmConditionSet {
-	set := &ApmConditionSet{
-		conditions: make(map[string]ApmConditionBody),
-	}
+	set := newApmConditionSet()
 	for _, condition := range conditions {
 		set.put(condition.Condition)
 	}
@@ -26,13 +23,48 @@ func NewApmConditionSetFromSlice(conditions []*ApmCondition) *ApmConditionSet {
 	return set
 }
 
+func newApmConditionSet() *ApmConditionSet {
+	return &ApmConditionSet{
+		conditions: make(map[string]ApmConditionBody),
+		ids:        make(map[int64]ApmConditionBody),
+	}
+}
+
 func (set ApmConditionSet) put(condition ApmConditionBody) {
-	set.conditions[condition.getHashKey()] = condition
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
 }
 
-func (set ApmConditionSet) Contains(condition ApmConditionBody) bool {
-	key := condition.getHashKey()
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set ApmConditionSet) Get(condition ApmConditionBody) (ApmConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
 
-	_, ok := set.conditions[key]
+func (set ApmConditionSet) Contains(condition ApmConditionBody) bool {
+	_, ok := set.Get(condition)
 	return ok
 }
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set ApmConditionSet) IsDuplicate(condition ApmConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
@@ -80,4 +80,79 @@ func TestApmConditionSet_Contains(t *testing.T) {
 	if ! set.Contains(remoteCondition.Condition) {
 		t.Error("Slice should contain existingCondition, but does not")
 	}
-}
\ No newline at end of file
+}
+
+func TestApmConditionSet_Get_MatchesByName(t *testing.T) {
+	existingConditionId := int64(10)
+	existingConditions := domain.ApmConditionList{
+		Condition: []domain.ApmConditionBody{
+			{
+				Id:       &existingConditionId,
+				Name:     "Low apdex",
+				Type:     "apm_app_metric",
+				Entities: []string{"1"},
+				Metric:   "apdex",
+				Terms: []domain.Term{
+					{Duration: "5", Operator: "below", Priority: "critical", Threshold: "0.8", TimeFunction: "all"},
+				},
+			},
+		},
+	}
+	set := domain.NewApmConditionSet(existingConditions)
+
+	newCondition := existingConditions.Condition[0]
+	newCondition.Id = nil
+	newCondition.RunbookUrl = "http://runbook"
+
+	existingCondition, ok := set.Get(newCondition)
+	if !ok {
+		t.Fatal("Set should contain a condition with the same name")
+	}
+
+	if *existingCondition.Id != existingConditionId {
+		t.Errorf("Condition id should be equal to %d", existingConditionId)
+	}
+
+	if existingCondition.Equals(newCondition) {
+		t.Error("Conditions with different runbook urls should not be equal")
+	}
+}
+
+func TestApmConditionSet_Get_MatchesChangedThreshold(t *testing.T) {
+	existingConditionId := int64(10)
+	existingConditions := domain.ApmConditionList{
+		Condition: []domain.ApmConditionBody{
+			{
+				Id:       &existingConditionId,
+				Name:     "Low apdex",
+				Type:     "apm_app_metric",
+				Entities: []string{"1"},
+				Metric:   "apdex",
+				Terms: []domain.Term{
+					{Duration: "5", Operator: "below", Priority: "critical", Threshold: "0.8", TimeFunction: "all"},
+				},
+			},
+		},
+	}
+	set := domain.NewApmConditionSet(existingConditions)
+
+	newCondition := existingConditions.Condition[0]
+	newCondition.Id = nil
+	newCondition.Terms = []domain.Term{
+		{Duration: "5", Operator: "below", Priority: "critical", Threshold: "0.7", TimeFunction: "all"},
+	}
+
+	existingCondition, ok := set.Get(newCondition)
+	if !ok {
+		t.Fatal("Set should contain a condition with the same name")
+	}
+	if *existingCondition.Id != existingConditionId {
+		t.Errorf("Condition id should be equal to %d", existingConditionId)
+	}
+	if existingCondition.Equals(newCondition) {
+		t.Error("Conditions with different thresholds should not be equal")
+	}
+	if !set.Contains(newCondition) {
+		t.Error("Set should contain the condition with the changed threshold, so that it is updated instead of deleted")
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExternalServiceConditionTypeApm is the type of conditions on the external services called by APM applications
+const ExternalServiceConditionTypeApm = "apm_external_service"
+
+type ExternalServiceConditionList struct {
+	Condition []ExternalServiceConditionBody `json:"external_service_conditions"`
+}
+
+type ExternalServiceCondition struct {
+	Condition ExternalServiceConditionBody `json:"external_service_condition"`
+	// Retained keeps the existing New Relic condition with the same name unchanged,
+	// e.g. while its entities are missing. Only the name of a retained condition is set
+	Retained bool `json:"-"`
+}
+
+type ExternalServiceConditionBody struct {
+	Id                 *int64   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Type               string   `json:"type"`
+	Enabled            bool     `json:"enabled"`
+	Entities           []string `json:"entities"`
+	ExternalServiceUrl string   `json:"external_service_url"`
+	Metric             string   `json:"metric"`
+	RunbookUrl         string   `json:"runbook_url,omitempty"`
+	Terms              []Term   `json:"terms"`
+}
+
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (condition ExternalServiceConditionBody) Equals(other ExternalServiceConditionBody) bool {
+	return condition.getHashKey() == other.getHashKey()
+}
+
+func (condition ExternalServiceConditionBody) getHashKey() string {
+	return fmt.Sprintf(
+		"%s-%s-%t-%s-%s-%s-%s-%s",
+		condition.Name,
+		condition.Type,
+		condition.Enabled,
+		condition.getSortedEntities(),
+		condition.ExternalServiceUrl,
+		condition.Metric,
+		condition.RunbookUrl,
+		condition.getTermsHash(),
+	)
+}
+
+// getSortedEntities ignores the order of the entities, which New Relic does not preserve
+func (condition ExternalServiceConditionBody) getSortedEntities() []string {
+	entities := make([]string, len(condition.Entities))
+	copy(entities, condition.Entities)
+	sort.Strings(entities)
+
+	return entities
+}
+
+func (condition ExternalServiceConditionBody) getTermsHash() string {
+	var critical, warning string
+	for _, term := range condition.Terms {
+		if term.Priority == PriorityCritical {
+			critical = term.getHashKey()
+		} else {
+			warning = term.getHashKey()
+		}
+	}
+
+	return critical + "-" + warning
+}
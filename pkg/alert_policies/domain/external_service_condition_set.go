@@ -0,0 +1,70 @@
+package domain
+
+type ExternalServiceConditionSet struct {
+	conditions map[string]ExternalServiceConditionBody
+	ids        map[int64]ExternalServiceConditionBody
+}
+
+func NewExternalServiceConditionSet(conditions ExternalServiceConditionList) *ExternalServiceConditionSet {
+	set := newExternalServiceConditionSet()
+	for _, condition := range conditions.Condition {
+		set.put(condition)
+	}
+
+	return set
+}
+
+func NewExternalServiceConditionSetFromSlice(conditions []*ExternalServiceCondition) *ExternalServiceConditionSet {
+	set := newExternalServiceConditionSet()
+	for _, condition := range conditions {
+		set.put(condition.Condition)
+	}
+
+	return set
+}
+
+func newExternalServiceConditionSet() *ExternalServiceConditionSet {
+	return &ExternalServiceConditionSet{
+		conditions: make(map[string]ExternalServiceConditionBody),
+		ids:        make(map[int64]ExternalServiceConditionBody),
+	}
+}
+
+func (set ExternalServiceConditionSet) put(condition ExternalServiceConditionBody) {
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
+}
+
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set ExternalServiceConditionSet) Get(condition ExternalServiceConditionBody) (ExternalServiceConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
+
+func (set ExternalServiceConditionSet) Contains(condition ExternalServiceConditionBody) bool {
+	_, ok := set.Get(condition)
+	return ok
+}
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set ExternalServiceConditionSet) IsDuplicate(condition ExternalServiceConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
@@ -17,21 +17,27 @@ type InfraConditionBody struct {
 	PolicyId int64  `json:"policy_id"`
 
 	// +kubebuilder:validation:Enum=equal;above;bellow
-	Comparison          string          `json:"comparison"`
+	Comparison          string          `json:"comparison,omitempty"`
 	CriticalThreshold   InfraThreshold  `json:"critical_threshold"`
 	WarningThreshold    *InfraThreshold `json:"warning_threshold,omitempty"`
 	Enabled             bool            `json:"enabled,omitempty"`
-	EventType           string          `json:"event_type"`
-	IntegrationProvider string          `json:"integration_provider"`
+	EventType           string          `json:"event_type,omitempty"`
+	IntegrationProvider string          `json:"integration_provider,omitempty"`
 	RunbookUrl          string          `json:"runbook_url,omitempty"`
-	SelectValue         string          `json:"select_value"`
+	SelectValue         string          `json:"select_value,omitempty"`
 	ViolationCloseTimer int             `json:"violation_close_timer,omitempty"`
 	WhereClause         string          `json:"where_clause,omitempty"`
+	ProcessWhereClause  string          `json:"process_where_clause,omitempty"`
+}
+
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (b InfraConditionBody) Equals(other InfraConditionBody) bool {
+	return b.getHashKey() == other.getHashKey()
 }
 
 func (b InfraConditionBody) getHashKey() string {
 	return fmt.Sprintf(
-		"%s-%s-%s-%s-%s-%t-%s-%s-%s-%s-%d-%s",
+		"%s-%s-%s-%s-%s-%t-%s-%s-%s-%s-%d-%s-%s",
 		b.Name,
 		b.Type,
 		b.Comparison,
@@ -44,6 +50,7 @@ func (b InfraConditionBody) getHashKey() string {
 		b.SelectValue,
 		b.ViolationCloseTimer,
 		b.WhereClause,
+		b.ProcessWhereClause,
 	)
 }
 
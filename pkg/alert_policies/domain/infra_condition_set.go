@@ -2,12 +2,11 @@ package domain
 
 type InfraConditionSet struct {
 	conditions map[string]InfraConditionBody
+	ids        map[int64]InfraConditionBody
 }
 
 func NewInfraConditionSet(conditions InfraConditionList) *InfraConditionSet {
-	set := &InfraConditionSet{
-		conditions: make(map[string]InfraConditionBody),
-	}
+	set := newInfraConditionSet()
 	for _, condition := range conditions.Condition {
 		set.put(condition)
 	}
@@ -16,9 +15,7 @@ func NewInfraConditionSet(conditions InfraConditionList) *InfraConditionSet {
 }
 
 func NewInfraConditionSetFromSlice(conditions []*InfraCondition) *InfraConditionSet {
-	set := &InfraConditionSet{
-		conditions: make(map[string]InfraConditionBody),
-	}
+	set := newInfraConditionSet()
 	for _, condition := range conditions {
 		set.put(condition.Condition)
 	}
@@ -26,13 +23,48 @@ func NewInfraConditionSetFromSlice(conditions []*InfraCondition) *InfraCondition
 	return set
 }
 
+func newInfraConditionSet() *InfraConditionSet {
+	return &InfraConditionSet{
+		conditions: make(map[string]InfraConditionBody),
+		ids:        make(map[int64]InfraConditionBody),
+	}
+}
+
 func (set InfraConditionSet) put(condition InfraConditionBody) {
-	set.conditions[condition.getHashKey()] = condition
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
 }
 
-func (set InfraConditionSet) Contains(condition InfraConditionBody) bool {
-	key := condition.getHashKey()
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set InfraConditionSet) Get(condition InfraConditionBody) (InfraConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
 
-	_, ok := set.conditions[key]
+func (set InfraConditionSet) Contains(condition InfraConditionBody) bool {
+	_, ok := set.Get(condition)
 	return ok
 }
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set InfraConditionSet) IsDuplicate(condition InfraConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
@@ -2,18 +2,28 @@ package domain
 
 import "fmt"
 
+// InfraThreshold is the threshold of an infra condition. Host not reporting conditions
+// only have a duration, and process running conditions have no time function
 type InfraThreshold struct {
-	Value           int `json:"value"`
-	DurationMinutes int `json:"duration_minutes"`
+	Value           *int `json:"value,omitempty"`
+	DurationMinutes int  `json:"duration_minutes"`
 	// +kubebuilder:validation:Enum=all;any
-	TimeFunction string `json:"time_function"`
+	TimeFunction string `json:"time_function,omitempty"`
 }
 
 func (t InfraThreshold) getHashKey() string {
 	return fmt.Sprintf(
-		"%s-%d-%d",
+		"%s-%s-%d",
 		t.TimeFunction,
-		t.Value,
+		t.getValueHashKey(),
 		t.DurationMinutes,
 	)
 }
+
+func (t InfraThreshold) getValueHashKey() string {
+	if t.Value == nil {
+		return "nil"
+	}
+
+	return fmt.Sprintf("%d", *t.Value)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+type LocationFailureConditionList struct {
+	Condition []LocationFailureConditionBody `json:"location_failure_conditions"`
+}
+
+type LocationFailureCondition struct {
+	Condition LocationFailureConditionBody `json:"location_failure_condition"`
+}
+
+// LocationFailureConditionBody is a multi-location Synthetics condition,
+// which opens violations when a monitor fails in several locations at once
+type LocationFailureConditionBody struct {
+	Id         *int64         `json:"id,omitempty"`
+	Name       string         `json:"name"`
+	Enabled    bool           `json:"enabled"`
+	Entities   []string       `json:"entities"`
+	Terms      []LocationTerm `json:"terms"`
+	RunbookUrl string         `json:"runbook_url,omitempty"`
+}
+
+// LocationTerm is the number of failing locations which opens a violation of the given priority
+type LocationTerm struct {
+	Priority  string `json:"priority"`
+	Threshold int    `json:"threshold"`
+}
+
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (condition LocationFailureConditionBody) Equals(other LocationFailureConditionBody) bool {
+	return condition.getHashKey() == other.getHashKey()
+}
+
+func (condition LocationFailureConditionBody) getHashKey() string {
+	return fmt.Sprintf(
+		"%s-%t-%s-%s-%s",
+		condition.Name,
+		condition.Enabled,
+		sortedCopy(condition.Entities),
+		condition.getTermsHash(),
+		condition.RunbookUrl,
+	)
+}
+
+func (condition LocationFailureConditionBody) getTermsHash() string {
+	var critical, warning int
+	for _, term := range condition.Terms {
+		if term.Priority == PriorityCritical {
+			critical = term.Threshold
+		} else {
+			warning = term.Threshold
+		}
+	}
+
+	return fmt.Sprintf("%d-%d", critical, warning)
+}
+
+// sortedCopy sorts the monitor ids, since New Relic does not keep their order
+func sortedCopy(values []string) []string {
+	result := append([]string(nil), values...)
+	sort.Strings(result)
+
+	return result
+}
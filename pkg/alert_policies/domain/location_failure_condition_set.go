@@ -0,0 +1,70 @@
+package domain
+
+type LocationFailureConditionSet struct {
+	conditions map[string]LocationFailureConditionBody
+	ids        map[int64]LocationFailureConditionBody
+}
+
+func NewLocationFailureConditionSet(conditions LocationFailureConditionList) *LocationFailureConditionSet {
+	set := newLocationFailureConditionSet()
+	for _, condition := range conditions.Condition {
+		set.put(condition)
+	}
+
+	return set
+}
+
+func NewLocationFailureConditionSetFromSlice(conditions []*LocationFailureCondition) *LocationFailureConditionSet {
+	set := newLocationFailureConditionSet()
+	for _, condition := range conditions {
+		set.put(condition.Condition)
+	}
+
+	return set
+}
+
+func newLocationFailureConditionSet() *LocationFailureConditionSet {
+	return &LocationFailureConditionSet{
+		conditions: make(map[string]LocationFailureConditionBody),
+		ids:        make(map[int64]LocationFailureConditionBody),
+	}
+}
+
+func (set LocationFailureConditionSet) put(condition LocationFailureConditionBody) {
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
+}
+
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set LocationFailureConditionSet) Get(condition LocationFailureConditionBody) (LocationFailureConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
+
+func (set LocationFailureConditionSet) Contains(condition LocationFailureConditionBody) bool {
+	_, ok := set.Get(condition)
+	return ok
+}
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set LocationFailureConditionSet) IsDuplicate(condition LocationFailureConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
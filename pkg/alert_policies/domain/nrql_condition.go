@@ -13,24 +13,43 @@ type NrqlCondition struct {
 }
 
 type NrqlConditionBody struct {
-	Id            *int64 `json:"id,omitempty"`
-	Type          string `json:"type"`
-	Name          string `json:"name"`
-	RunbookURL    string `json:"runbook_url"`
-	Enabled       bool   `json:"enabled"`
-	Terms         []Term `json:"terms"`
-	ValueFunction string `json:"value_function"`
-	Nrql          Nrql   `json:"nrql"`
+	Id                *int64 `json:"id,omitempty"`
+	Type              string `json:"type"`
+	Name              string `json:"name"`
+	RunbookURL        string `json:"runbook_url"`
+	Enabled           bool   `json:"enabled"`
+	Terms             []Term `json:"terms"`
+	ValueFunction     string `json:"value_function"`
+	BaselineDirection string `json:"baseline_direction,omitempty"`
+	ExpectedGroups    int    `json:"expected_groups,omitempty"`
+	IgnoreOverlap     bool   `json:"ignore_overlap,omitempty"`
+	Nrql              Nrql   `json:"nrql"`
+	// StreamingSettings are only available through NerdGraph and therefore not part of the REST payload
+	StreamingSettings *NrqlStreamingSettings `json:"-"`
+}
+
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (condition NrqlConditionBody) Equals(other NrqlConditionBody) bool {
+	return condition.getHashKey() == other.getHashKey()
+}
+
+// HasSameType returns whether the condition can be updated to the other condition in place.
+// New Relic does not allow changing the type of an existing condition
+func (condition NrqlConditionBody) HasSameType(other NrqlConditionBody) bool {
+	return condition.Type == other.Type
 }
 
 func (condition NrqlConditionBody) getHashKey() string {
 	return fmt.Sprintf(
-		"%s-%s-%s-%t-%s-%s-%s",
+		"%s-%s-%s-%t-%s-%s-%d-%t-%s-%s",
 		condition.Type,
 		condition.Name,
 		condition.RunbookURL,
 		condition.Enabled,
 		condition.ValueFunction,
+		condition.BaselineDirection,
+		condition.ExpectedGroups,
+		condition.IgnoreOverlap,
 		condition.getTermsHash(),
 		condition.Nrql.getHashKey(),
 	)
@@ -2,12 +2,11 @@ package domain
 
 type NrqlConditionSet struct {
 	conditions map[string]NrqlConditionBody
+	ids        map[int64]NrqlConditionBody
 }
 
 func NewNrqlConditionSet(conditions NrqlConditionList) *NrqlConditionSet {
-	set := &NrqlConditionSet{
-		conditions: make(map[string]NrqlConditionBody),
-	}
+	set := newNrqlConditionSet()
 	for _, condition := range conditions.Condition {
 		set.put(condition)
 	}
@@ -16,9 +15,7 @@ func NewNrqlConditionSet(conditions NrqlConditionList) *NrqlConditionSet {
 }
 
 func NewNrqlConditionSetFromSlice(conditions []*NrqlCondition) *NrqlConditionSet {
-	set := &NrqlConditionSet{
-		conditions: make(map[string]NrqlConditionBody),
-	}
+	set := newNrqlConditionSet()
 	for _, condition := range conditions {
 		set.put(condition.Condition)
 	}
@@ -26,13 +23,48 @@ func NewNrqlConditionSetFromSlice(conditions []*NrqlCondition) *NrqlConditionSet
 	return set
 }
 
+func newNrqlConditionSet() *NrqlConditionSet {
+	return &NrqlConditionSet{
+		conditions: make(map[string]NrqlConditionBody),
+		ids:        make(map[int64]NrqlConditionBody),
+	}
+}
+
 func (set NrqlConditionSet) put(condition NrqlConditionBody) {
-	set.conditions[condition.getHashKey()] = condition
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
 }
 
-func (set NrqlConditionSet) Contains(condition NrqlConditionBody) bool {
-	key := condition.getHashKey()
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set NrqlConditionSet) Get(condition NrqlConditionBody) (NrqlConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
 
-	_, ok := set.conditions[key]
+func (set NrqlConditionSet) Contains(condition NrqlConditionBody) bool {
+	_, ok := set.Get(condition)
 	return ok
 }
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set NrqlConditionSet) IsDuplicate(condition NrqlConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
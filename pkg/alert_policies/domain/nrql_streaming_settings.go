@@ -0,0 +1,61 @@
+package domain
+
+// NrqlStreamingSettings holds the settings of a NRQL condition which New Relic only accepts through NerdGraph.
+// The field names follow the NerdGraph schema. Fields which are nil are not managed and keep their value in New Relic.
+type NrqlStreamingSettings struct {
+	Signal     NrqlSignal     `json:"signal"`
+	Expiration NrqlExpiration `json:"expiration"`
+}
+
+type NrqlSignal struct {
+	AggregationWindow *int     `json:"aggregationWindow,omitempty"`
+	AggregationMethod *string  `json:"aggregationMethod,omitempty"`
+	AggregationDelay  *int     `json:"aggregationDelay,omitempty"`
+	AggregationTimer  *int     `json:"aggregationTimer,omitempty"`
+	EvaluationOffset  *int     `json:"evaluationOffset,omitempty"`
+	FillOption        *string  `json:"fillOption,omitempty"`
+	FillValue         *float64 `json:"fillValue,omitempty"`
+}
+
+type NrqlExpiration struct {
+	ExpirationDuration          *int  `json:"expirationDuration,omitempty"`
+	OpenViolationOnExpiration   *bool `json:"openViolationOnExpiration,omitempty"`
+	CloseViolationsOnExpiration *bool `json:"closeViolationsOnExpiration,omitempty"`
+}
+
+// IsSatisfiedBy returns whether every managed field has the same value in the current settings
+func (settings NrqlStreamingSettings) IsSatisfiedBy(current NrqlStreamingSettings) bool {
+	return settings.Signal.isSatisfiedBy(current.Signal) && settings.Expiration.isSatisfiedBy(current.Expiration)
+}
+
+func (signal NrqlSignal) isSatisfiedBy(current NrqlSignal) bool {
+	return intMatches(signal.AggregationWindow, current.AggregationWindow) &&
+		stringMatches(signal.AggregationMethod, current.AggregationMethod) &&
+		intMatches(signal.AggregationDelay, current.AggregationDelay) &&
+		intMatches(signal.AggregationTimer, current.AggregationTimer) &&
+		intMatches(signal.EvaluationOffset, current.EvaluationOffset) &&
+		stringMatches(signal.FillOption, current.FillOption) &&
+		floatMatches(signal.FillValue, current.FillValue)
+}
+
+func (expiration NrqlExpiration) isSatisfiedBy(current NrqlExpiration) bool {
+	return intMatches(expiration.ExpirationDuration, current.ExpirationDuration) &&
+		boolMatches(expiration.OpenViolationOnExpiration, current.OpenViolationOnExpiration) &&
+		boolMatches(expiration.CloseViolationsOnExpiration, current.CloseViolationsOnExpiration)
+}
+
+func intMatches(managed *int, current *int) bool {
+	return managed == nil || (current != nil && *managed == *current)
+}
+
+func stringMatches(managed *string, current *string) bool {
+	return managed == nil || (current != nil && *managed == *current)
+}
+
+func floatMatches(managed *float64, current *float64) bool {
+	return managed == nil || (current != nil && *managed == *current)
+}
+
+func boolMatches(managed *bool, current *bool) bool {
+	return managed == nil || (current != nil && *managed == *current)
+}
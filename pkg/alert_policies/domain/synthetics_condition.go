@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+)
+
+type SyntheticsConditionList struct {
+	Condition []SyntheticsConditionBody `json:"synthetics_conditions"`
+}
+
+type SyntheticsCondition struct {
+	Condition SyntheticsConditionBody `json:"synthetics_condition"`
+}
+
+type SyntheticsConditionBody struct {
+	Id         *int64 `json:"id,omitempty"`
+	Name       string `json:"name"`
+	MonitorId  string `json:"monitor_id"`
+	RunbookUrl string `json:"runbook_url,omitempty"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// Equals compares the content of two conditions, ignoring their New Relic ids
+func (condition SyntheticsConditionBody) Equals(other SyntheticsConditionBody) bool {
+	return condition.getHashKey() == other.getHashKey()
+}
+
+func (condition SyntheticsConditionBody) getHashKey() string {
+	return fmt.Sprintf(
+		"%s-%s-%s-%t",
+		condition.Name,
+		condition.MonitorId,
+		condition.RunbookUrl,
+		condition.Enabled,
+	)
+}
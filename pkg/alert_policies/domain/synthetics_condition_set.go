@@ -0,0 +1,70 @@
+package domain
+
+type SyntheticsConditionSet struct {
+	conditions map[string]SyntheticsConditionBody
+	ids        map[int64]SyntheticsConditionBody
+}
+
+func NewSyntheticsConditionSet(conditions SyntheticsConditionList) *SyntheticsConditionSet {
+	set := newSyntheticsConditionSet()
+	for _, condition := range conditions.Condition {
+		set.put(condition)
+	}
+
+	return set
+}
+
+func NewSyntheticsConditionSetFromSlice(conditions []*SyntheticsCondition) *SyntheticsConditionSet {
+	set := newSyntheticsConditionSet()
+	for _, condition := range conditions {
+		set.put(condition.Condition)
+	}
+
+	return set
+}
+
+func newSyntheticsConditionSet() *SyntheticsConditionSet {
+	return &SyntheticsConditionSet{
+		conditions: make(map[string]SyntheticsConditionBody),
+		ids:        make(map[int64]SyntheticsConditionBody),
+	}
+}
+
+func (set SyntheticsConditionSet) put(condition SyntheticsConditionBody) {
+	if condition.Id != nil {
+		set.ids[*condition.Id] = condition
+	}
+
+	if _, ok := set.conditions[condition.Name]; ok {
+		return
+	}
+	set.conditions[condition.Name] = condition
+}
+
+// Get returns the condition with the same identity as the given one.
+// Conditions are matched by their New Relic id when it is known and by their name otherwise.
+func (set SyntheticsConditionSet) Get(condition SyntheticsConditionBody) (SyntheticsConditionBody, bool) {
+	if condition.Id != nil {
+		if existing, ok := set.ids[*condition.Id]; ok {
+			return existing, true
+		}
+	}
+
+	existing, ok := set.conditions[condition.Name]
+	return existing, ok
+}
+
+func (set SyntheticsConditionSet) Contains(condition SyntheticsConditionBody) bool {
+	_, ok := set.Get(condition)
+	return ok
+}
+
+// IsDuplicate returns true when the set already holds a different condition with the same name
+func (set SyntheticsConditionSet) IsDuplicate(condition SyntheticsConditionBody) bool {
+	existing, ok := set.conditions[condition.Name]
+	if !ok || existing.Id == nil || condition.Id == nil {
+		return false
+	}
+
+	return *existing.Id != *condition.Id
+}
@@ -72,6 +72,17 @@ func (c *Client) updateWithRetries(key types.NamespacedName, policy *v1alpha1.Al
 	return nil
 }
 
+// UpdateConditionStatus saves the status of a standalone condition
+func (c *Client) UpdateConditionStatus(condition v1alpha1.StandaloneCondition) error {
+	err := c.client.Status().Update(context.TODO(), condition)
+	if err != nil {
+		c.logr.Error(err, "Error updating condition status", "Condition", condition.GetName())
+		return err
+	}
+
+	return nil
+}
+
 func (c *Client) SetFinalizer(policy v1alpha1.AlertPolicy) error {
 	policy.ObjectMeta.Finalizers = []string{"newrelic"}
 	err := c.client.Update(context.TODO(), &policy)
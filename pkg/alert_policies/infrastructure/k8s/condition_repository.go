@@ -0,0 +1,56 @@
+package k8s
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionRepository reads the standalone conditions which are added to alert policies
+type ConditionRepository struct {
+	reader client_go.Reader
+}
+
+func NewConditionRepository(reader client_go.Reader) *ConditionRepository {
+	return &ConditionRepository{
+		reader: reader,
+	}
+}
+
+// GetAttachedConditions returns the NRQL and APM conditions of all namespaces which refer to the policy.
+// Conditions which are being deleted are left out
+func (repository *ConditionRepository) GetAttachedConditions(policy types.NamespacedName) ([]v1alpha1.StandaloneCondition, error) {
+	var nrqlConditions v1alpha1.NrqlAlertConditionList
+	err := repository.reader.List(context.TODO(), &nrqlConditions)
+	if err != nil {
+		return nil, err
+	}
+
+	var apmConditions v1alpha1.ApmAlertConditionList
+	err = repository.reader.List(context.TODO(), &apmConditions)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []v1alpha1.StandaloneCondition
+	for i := range nrqlConditions.Items {
+		result = appendAttached(result, &nrqlConditions.Items[i], policy)
+	}
+	for i := range apmConditions.Items {
+		result = appendAttached(result, &apmConditions.Items[i], policy)
+	}
+
+	return result, nil
+}
+
+func appendAttached(conditions []v1alpha1.StandaloneCondition, condition v1alpha1.StandaloneCondition, policy types.NamespacedName) []v1alpha1.StandaloneCondition {
+	if condition.GetDeletionTimestamp() != nil {
+		return conditions
+	}
+	if condition.GetPolicyRef().NamespacedName(condition.GetNamespace()) != policy {
+		return conditions
+	}
+
+	return append(conditions, condition)
+}
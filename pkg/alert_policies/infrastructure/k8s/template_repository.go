@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemplateRepository reads the condition templates which alert policies refer to
+type TemplateRepository struct {
+	reader client_go.Reader
+}
+
+func NewTemplateRepository(reader client_go.Reader) *TemplateRepository {
+	return &TemplateRepository{
+		reader: reader,
+	}
+}
+
+// GetTemplate returns the spec of a template referenced by a policy in the given namespace
+func (repository *TemplateRepository) GetTemplate(namespace string, reference v1alpha1.AlertConditionTemplateReference) (*v1alpha1.AlertConditionTemplateSpec, error) {
+	switch reference.TemplateKind() {
+	case v1alpha1.AlertConditionTemplateKind:
+		var template v1alpha1.AlertConditionTemplate
+		err := repository.reader.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: reference.Name}, &template)
+		if err != nil {
+			return nil, err
+		}
+
+		return &template.Spec, nil
+	case v1alpha1.ClusterAlertConditionTemplateKind:
+		var template v1alpha1.ClusterAlertConditionTemplate
+		err := repository.reader.Get(context.TODO(), types.NamespacedName{Name: reference.Name}, &template)
+		if err != nil {
+			return nil, err
+		}
+
+		return &template.Spec, nil
+	default:
+		return nil, fmt.Errorf("unknown template kind %s", reference.TemplateKind())
+	}
+}
+
+// GetDependentPolicies returns the policies which refer to a template.
+// Cluster templates can be referenced by the policies of all namespaces
+func (repository *TemplateRepository) GetDependentPolicies(kind string, namespace string, name string) ([]types.NamespacedName, error) {
+	var options []client_go.ListOption
+	if kind == v1alpha1.AlertConditionTemplateKind {
+		options = append(options, client_go.InNamespace(namespace))
+	}
+
+	var policies v1alpha1.AlertPolicyList
+	err := repository.reader.List(context.TODO(), &policies, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []types.NamespacedName
+	for _, policy := range policies.Items {
+		for _, reference := range policy.Spec.Templates {
+			if reference.TemplateKind() == kind && reference.Name == name {
+				result = append(result, types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name})
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -9,52 +9,91 @@ import (
 )
 
 type AlertPolicyRepository struct {
-	client                   internal.NewrelicClient
-	infraClient              internal.NewrelicClient
-	log                      logr.Logger
-	nrqlConditionRepository  *nrqlConditionRepository
-	apmConditionRepository   *apmConditionRepository
-	infraConditionRepository *infraConditionRepository
+	client                             internal.NewrelicClient
+	infraClient                        internal.NewrelicClient
+	log                                logr.Logger
+	nrqlConditionRepository            *nrqlConditionRepository
+	apmConditionRepository             *apmConditionRepository
+	infraConditionRepository           *infraConditionRepository
+	syntheticsConditionRepository      *syntheticsConditionRepository
+	locationFailureConditionRepository *locationFailureConditionRepository
+	externalServiceConditionRepository *externalServiceConditionRepository
 }
 
-func NewAlertPolicyRepository(log logr.Logger, client internal.NewrelicClient, infraClient internal.NewrelicClient) *AlertPolicyRepository {
+func NewAlertPolicyRepository(log logr.Logger, client internal.NewrelicClient, infraClient internal.NewrelicClient, nerdGraph internal.NerdGraphClient) *AlertPolicyRepository {
 	return &AlertPolicyRepository{
-		client:                   client,
-		infraClient:              infraClient,
-		log:                      log,
-		nrqlConditionRepository:  newNrqlConditionRepository(log, client),
-		apmConditionRepository:   newApmConditionRepository(log, client),
-		infraConditionRepository: newInfraConditionRepository(log, infraClient),
+		client:                             client,
+		infraClient:                        infraClient,
+		log:                                log,
+		nrqlConditionRepository:            newNrqlConditionRepository(log, client, nerdGraph),
+		apmConditionRepository:             newApmConditionRepository(log, client),
+		infraConditionRepository:           newInfraConditionRepository(log, infraClient),
+		syntheticsConditionRepository:      newSyntheticsConditionRepository(log, client),
+		locationFailureConditionRepository: newLocationFailureConditionRepository(log, client),
+		externalServiceConditionRepository: newExternalServiceConditionRepository(log, client),
 	}
 }
 
+// Save creates or updates the policy together with its conditions.
+// When one of the steps fails, the changes applied so far are rolled back
+// so that New Relic is left in the state it had before the policy was saved.
+// Creating a new policy is treated as a checkpoint and is never rolled back.
 func (repository AlertPolicyRepository) Save(policy *domain.AlertPolicy) error {
-	if policy.Policy.Id == nil {
-		err := repository.createPolicy(policy)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := repository.updatePolicy(policy)
-		if err != nil {
-			return err
-		}
-	}
-
-	err := repository.nrqlConditionRepository.saveConditions(policy)
-	if err != nil {
+	journal := newChangeJournal(repository.log)
+	err := repository.saveWithJournal(policy, journal)
+	if err == nil || journal.isEmpty() {
 		return err
 	}
 
-	err = repository.apmConditionRepository.saveConditions(policy)
-	if err != nil {
+	repository.log.Info("Error saving policy, rolling back changes", "PolicyId", *policy.Policy.Id, "Error", err.Error())
+	return newSaveError(err, journal.rollback())
+}
+
+// SaveToCheckpoint works like Save, but only rolls back the changes of the step which failed.
+// The policy and the condition types saved before the failure keep their new state in New Relic
+func (repository AlertPolicyRepository) SaveToCheckpoint(policy *domain.AlertPolicy) error {
+	journal := newChangeJournal(repository.log)
+	err := repository.saveWithJournal(policy, journal)
+	if err == nil || journal.isEmpty() {
 		return err
 	}
 
-	err = repository.infraConditionRepository.saveConditions(policy)
+	checkpoint := journal.lastCheckpoint()
+	repository.log.Info("Error saving policy, rolling back to checkpoint", "PolicyId", *policy.Policy.Id, "Checkpoint", checkpoint, "Error", err.Error())
+	return newCheckpointError(err, checkpoint, journal.rollbackToCheckpoint())
+}
+
+// saveWithJournal saves the policy and then each type of condition, marking a checkpoint after every step
+func (repository AlertPolicyRepository) saveWithJournal(policy *domain.AlertPolicy, journal *changeJournal) error {
+	var err error
+	if policy.Policy.Id == nil {
+		err = repository.createPolicy(policy)
+	} else {
+		err = repository.updatePolicy(policy, journal)
+	}
 	if err != nil {
 		return err
 	}
+	journal.checkpoint("policy")
+
+	steps := []struct {
+		description string
+		save        func(*domain.AlertPolicy, *changeJournal) error
+	}{
+		{"nrql conditions", repository.nrqlConditionRepository.saveConditions},
+		{"apm conditions", repository.apmConditionRepository.saveConditions},
+		{"infra conditions", repository.infraConditionRepository.saveConditions},
+		{"synthetics conditions", repository.syntheticsConditionRepository.saveConditions},
+		{"location failure conditions", repository.locationFailureConditionRepository.saveConditions},
+		{"external service conditions", repository.externalServiceConditionRepository.saveConditions},
+	}
+	for _, step := range steps {
+		err = step.save(policy, journal)
+		if err != nil {
+			return err
+		}
+		journal.checkpoint(step.description)
+	}
 
 	return nil
 }
@@ -94,7 +133,7 @@ func (repository AlertPolicyRepository) createPolicy(policy *domain.AlertPolicy)
 	return nil
 }
 
-func (repository AlertPolicyRepository) updatePolicy(policy *domain.AlertPolicy) error {
+func (repository AlertPolicyRepository) updatePolicy(policy *domain.AlertPolicy, journal *changeJournal) error {
 	existingPolicy, err := repository.getPolicy(*policy.Policy.Id)
 	if err != nil {
 		return err
@@ -108,13 +147,26 @@ func (repository AlertPolicyRepository) updatePolicy(policy *domain.AlertPolicy)
 		return nil
 	}
 
+	repository.log.Info("Updating policy", "Policy", policy)
+	err = repository.putPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	journal.record(fmt.Sprintf("update policy %d", *policy.Policy.Id), func() error {
+		return repository.putPolicy(existingPolicy)
+	})
+
+	return nil
+}
+
+func (repository AlertPolicyRepository) putPolicy(policy *domain.AlertPolicy) error {
 	endpoint := fmt.Sprintf("%s/%d.json", "alerts_policies", *policy.Policy.Id)
 	payload, err := marshal(*policy)
 	if err != nil {
 		return err
 	}
 
-	repository.log.Info("Updating policy", "Policy", policy)
 	response, err := repository.client.PutJson(endpoint, payload)
 	if err != nil {
 		return err
@@ -0,0 +1,845 @@
+package newrelic_test
+
+import (
+	"errors"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/infrastructure/newrelic"
+	"github.com/stretchr/testify/mock"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+)
+
+var logr = log.Log.WithName("test")
+
+func TestAlertPolicyRepository_Save_UpdatesModifiedNrqlCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://old-runbook"),
+		nil,
+	)
+	client.On(
+		"PutJson",
+		"alerts_nrql_conditions/5.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://new-runbook")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_nrql_conditions/5.json", mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+
+	if *policy.NrqlConditions[0].Condition.Id != 5 {
+		t.Error("Condition id should be equal to 5")
+	}
+}
+
+func TestAlertPolicyRepository_Save_KeepsUnmodifiedNrqlCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://runbook"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://runbook")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_ReplacesRenamedNrqlCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "old-condition", "http://runbook"),
+		nil,
+	)
+	client.On(
+		"Delete",
+		"alerts_nrql_conditions/5.json",
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+	client.On(
+		"PostJson",
+		"alerts_nrql_conditions/policies/10.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "new-condition", "http://runbook")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "Delete", "alerts_nrql_conditions/5.json")
+	client.AssertCalled(t, "PostJson", "alerts_nrql_conditions/policies/10.json", mock.Anything)
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_KeepsUnmodifiedBaselineNrqlCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newBaselineNrqlConditionListResponse(5, "test-condition", "upper_only"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithBaselineNrqlCondition(10, "test-policy", "test-condition", "upper_only")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesBaselineDirection(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newBaselineNrqlConditionListResponse(5, "test-condition", "upper_only"),
+		nil,
+	)
+	client.On(
+		"PutJson",
+		"alerts_nrql_conditions/5.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithBaselineNrqlCondition(10, "test-policy", "test-condition", "upper_and_lower")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_nrql_conditions/5.json", mock.MatchedBy(containsString(`"baseline_direction":"upper_and_lower"`)))
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_ReplacesNrqlConditionWithChangedType(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://runbook"),
+		nil,
+	)
+	client.On(
+		"Delete",
+		"alerts_nrql_conditions/5.json",
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+	client.On(
+		"PostJson",
+		"alerts_nrql_conditions/policies/10.json",
+		mock.Anything,
+	).Return(
+		newStringResponse(`{"nrql_condition": {"id": 6}}`),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithBaselineNrqlCondition(10, "test-policy", "test-condition", "upper_only")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "Delete", "alerts_nrql_conditions/5.json")
+	client.AssertCalled(t, "PostJson", "alerts_nrql_conditions/policies/10.json", mock.MatchedBy(containsString(`"type":"baseline"`)))
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+
+	if *policy.NrqlConditions[0].Condition.Id != 6 {
+		t.Error("Condition id should be equal to 6")
+	}
+}
+
+func TestAlertPolicyRepository_Save_KeepsUnmodifiedOutlierNrqlCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newOutlierNrqlConditionListResponse(5, "test-condition", 2),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithOutlierNrqlCondition(10, "test-policy", "test-condition", 2)
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesExpectedGroups(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newOutlierNrqlConditionListResponse(5, "test-condition", 2),
+		nil,
+	)
+	client.On(
+		"PutJson",
+		"alerts_nrql_conditions/5.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithOutlierNrqlCondition(10, "test-policy", "test-condition", 3)
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_nrql_conditions/5.json", mock.MatchedBy(containsString(`"expected_groups":3,"ignore_overlap":true`)))
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesChangedNrqlSignal(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://runbook"),
+		nil,
+	)
+	client.On(
+		"PostJson",
+		"graphql",
+		mock.MatchedBy(isNerdGraphQuery),
+	).Return(
+		newNrqlSignalResponse(60),
+		nil,
+	)
+	client.On(
+		"PostJson",
+		"graphql",
+		mock.MatchedBy(isNerdGraphMutation),
+	).Return(
+		newStringResponse(`{"data": {"alertsNrqlConditionStaticUpdate": {"id": "5"}}}`),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://runbook")
+	policy.NrqlConditions[0].Condition.StreamingSettings = &domain.NrqlStreamingSettings{
+		Signal: domain.NrqlSignal{AggregationWindow: intPtr(120)},
+	}
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertCalled(t, "PostJson", "graphql", mock.MatchedBy(func(payload []byte) bool {
+		return isNerdGraphMutation(payload) &&
+			strings.Contains(string(payload), "alertsNrqlConditionStaticUpdate") &&
+			strings.Contains(string(payload), `"condition":{"signal":{"aggregationWindow":120},"expiration":{}}`)
+	}))
+}
+
+func TestAlertPolicyRepository_Save_KeepsUnchangedNrqlSignal(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://runbook"),
+		nil,
+	)
+	client.On(
+		"PostJson",
+		"graphql",
+		mock.MatchedBy(isNerdGraphQuery),
+	).Return(
+		newNrqlSignalResponse(120),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://runbook")
+	policy.NrqlConditions[0].Condition.StreamingSettings = &domain.NrqlStreamingSettings{
+		Signal: domain.NrqlSignal{
+			AggregationWindow: intPtr(120),
+			AggregationMethod: stringPtr("EVENT_FLOW"),
+		},
+	}
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNumberOfCalls(t, "PostJson", 1)
+	client.AssertNotCalled(t, "PostJson", "graphql", mock.MatchedBy(isNerdGraphMutation))
+}
+
+func TestAlertPolicyRepository_Save_NrqlSignalRequiresAccountId(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://runbook"),
+		nil,
+	)
+
+	nerdGraph := internal.NewNerdGraphClient(client, func() int64 { return 0 })
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, nerdGraph)
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://runbook")
+	policy.NrqlConditions[0].Condition.StreamingSettings = &domain.NrqlStreamingSettings{
+		Signal: domain.NrqlSignal{AggregationWindow: intPtr(120)},
+	}
+	err := repository.Save(policy)
+	if err == nil || !strings.Contains(err.Error(), "accountId") {
+		t.Errorf("Expected an error about the missing accountId, got %v", err)
+	}
+
+	client.AssertNotCalled(t, "PostJson", "graphql", mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_CreatesSyntheticsCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PostJson",
+		"alerts_synthetics_conditions/policies/10.json",
+		mock.Anything,
+	).Return(
+		newStringResponse(`{"synthetics_condition": {"id": 7, "name": "homepage-down", "monitor_id": "abc-123", "enabled": true}}`),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithSyntheticsCondition(10, "test-policy", "homepage-down", "abc-123")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PostJson", "alerts_synthetics_conditions/policies/10.json", mock.MatchedBy(containsString(`"monitor_id":"abc-123"`)))
+	if *policy.SyntheticsConditions[0].Condition.Id != 7 {
+		t.Error("Condition id should be equal to 7")
+	}
+}
+
+func TestAlertPolicyRepository_Save_UpdatesSyntheticsConditionMonitor(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		synthetics: `[{"id": 7, "name": "homepage-down", "monitor_id": "abc-123", "enabled": true}]`,
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PutJson",
+		"alerts_synthetics_conditions/7.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithSyntheticsCondition(10, "test-policy", "homepage-down", "def-456")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_synthetics_conditions/7.json", mock.MatchedBy(containsString(`"monitor_id":"def-456"`)))
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_DeletesRemovedSyntheticsCondition(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		synthetics: `[{"id": 7, "name": "homepage-down", "monitor_id": "abc-123", "enabled": true}]`,
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"Delete",
+		"alerts_synthetics_conditions/7.json",
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	err := repository.Save(newEmptyPolicyWithId(10, "test-policy"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "Delete", "alerts_synthetics_conditions/7.json")
+}
+
+func TestAlertPolicyRepository_Save_KeepsReorderedLocationFailureCondition(t *testing.T) {
+	existing := `[{
+		"id": 8,
+		"name": "checkout-down",
+		"enabled": true,
+		"entities": ["def-456", "abc-123"],
+		"terms": [{"priority": "critical", "threshold": 2}]
+	}]`
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{locationFailure: existing})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithLocationFailureCondition(10, "test-policy", "checkout-down", []string{"abc-123", "def-456"}, 2)
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesLocationFailureThreshold(t *testing.T) {
+	existing := `[{
+		"id": 8,
+		"name": "checkout-down",
+		"enabled": true,
+		"entities": ["abc-123"],
+		"terms": [{"priority": "critical", "threshold": 2}]
+	}]`
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{locationFailure: existing})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PutJson",
+		"alerts_location_failure_conditions/8.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithLocationFailureCondition(10, "test-policy", "checkout-down", []string{"abc-123"}, 3)
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_location_failure_conditions/8.json", mock.MatchedBy(containsString(`"threshold":3`)))
+}
+
+func TestAlertPolicyRepository_Save_CreatesExternalServiceCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PostJson",
+		"alerts_external_service_conditions/policies/10.json",
+		mock.Anything,
+	).Return(
+		newStringResponse(`{"external_service_condition": {"id": 9, "name": "payments-slow"}}`),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithExternalServiceCondition(10, "test-policy", []string{"1", "2"}, "response_time_average")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PostJson", "alerts_external_service_conditions/policies/10.json", mock.MatchedBy(containsString(`"external_service_url":"api.stripe.com"`)))
+	if *policy.ExternalServiceConditions[0].Condition.Id != 9 {
+		t.Error("Condition id should be equal to 9")
+	}
+}
+
+func TestAlertPolicyRepository_Save_KeepsReorderedExternalServiceCondition(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		externalService: newExternalServiceConditionJson(9, []string{"2", "1"}, "response_time_average"),
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithExternalServiceCondition(10, "test-policy", []string{"1", "2"}, "response_time_average")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_KeepsRetainedExternalServiceCondition(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		externalService: newExternalServiceConditionJson(9, []string{"1"}, "response_time_average"),
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newEmptyPolicyWithId(10, "test-policy")
+	policy.ExternalServiceConditions = []*domain.ExternalServiceCondition{
+		{Condition: domain.ExternalServiceConditionBody{Name: "payments-slow"}, Retained: true},
+	}
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_KeepsRetainedApmCondition(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		apm: newApmConditionJson(5, "apdex-low"),
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newEmptyPolicyWithId(10, "test-policy")
+	policy.ApmConditions = []*domain.ApmCondition{
+		{Condition: domain.ApmConditionBody{Name: "apdex-low"}, Retained: true},
+		{Condition: domain.ApmConditionBody{Name: "error-rate"}, Retained: true},
+	}
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesApmConditionThresholdInPlace(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		apm: newApmConditionJson(5, "apdex-low"),
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PutJson",
+		"alerts_conditions/5.json",
+		mock.MatchedBy(containsString(`"threshold":"0.5"`)),
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newEmptyPolicyWithId(10, "test-policy")
+	condition := newApmCondition("apdex-low")
+	condition.Condition.Terms[0].Threshold = "0.5"
+	policy.ApmConditions = []*domain.ApmCondition{condition}
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_conditions/5.json", mock.Anything)
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+
+	if *policy.ApmConditions[0].Condition.Id != 5 {
+		t.Error("Condition id should be equal to 5")
+	}
+}
+
+func TestAlertPolicyRepository_Save_UpdatesExternalServiceMetric(t *testing.T) {
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{
+		externalService: newExternalServiceConditionJson(9, []string{"1"}, "response_time_average"),
+	})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	client.On(
+		"PutJson",
+		"alerts_external_service_conditions/9.json",
+		mock.Anything,
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithExternalServiceCondition(10, "test-policy", []string{"1"}, "throughput")
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_external_service_conditions/9.json", mock.MatchedBy(containsString(`"metric":"throughput"`)))
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_CreatesProcessRunningCondition(t *testing.T) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	infraClient.On(
+		"PostJson",
+		"alerts/conditions",
+		mock.Anything,
+	).Return(
+		newStringResponse(`{"data": {"id": 11, "name": "nginx-down"}}`),
+		nil,
+	)
+
+	noProcesses := 0
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithInfraCondition(10, "test-policy", domain.InfraConditionBody{
+		Name:               "nginx-down",
+		Type:               "infra_process_running",
+		Comparison:         "equal",
+		CriticalThreshold:  domain.InfraThreshold{Value: &noProcesses, DurationMinutes: 5},
+		Enabled:            true,
+		ProcessWhereClause: "commandName = 'nginx'",
+	})
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	infraClient.AssertCalled(t, "PostJson", "alerts/conditions", mock.MatchedBy(containsString(`"critical_threshold":{"value":0,"duration_minutes":5}`)))
+	infraClient.AssertCalled(t, "PostJson", "alerts/conditions", mock.MatchedBy(containsString(`"process_where_clause":"commandName = 'nginx'"`)))
+}
+
+func TestAlertPolicyRepository_Save_KeepsUnmodifiedHostNotReportingCondition(t *testing.T) {
+	existing := `[{
+		"id": 11,
+		"policy_id": 10,
+		"name": "host-down",
+		"type": "infra_host_not_reporting",
+		"enabled": true,
+		"where_clause": "(hostname LIKE 'web-%')",
+		"critical_threshold": {"duration_minutes": 10}
+	}]`
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{infra: existing})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithInfraCondition(10, "test-policy", domain.InfraConditionBody{
+		Name:              "host-down",
+		Type:              "infra_host_not_reporting",
+		PolicyId:          10,
+		CriticalThreshold: domain.InfraThreshold{DurationMinutes: 10},
+		Enabled:           true,
+		WhereClause:       "(hostname LIKE 'web-%')",
+	})
+	err := repository.Save(policy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	infraClient.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	infraClient.AssertNotCalled(t, "Delete", mock.Anything)
+	infraClient.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_KeepsExistingInfraConditionBelow(t *testing.T) {
+	existing := `[{
+		"id": 11,
+		"policy_id": 10,
+		"name": "low-disk",
+		"type": "infra_metric",
+		"comparison": "below",
+		"enabled": true,
+		"event_type": "StorageSample",
+		"select_value": "diskFreePercent",
+		"critical_threshold": {"value": 10, "duration_minutes": 5, "time_function": "all"}
+	}]`
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{infra: existing})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	err := repository.Save(newPolicyWithInfraCondition(10, "test-policy", newLowDiskInfraCondition("below")))
+	if err != nil {
+		t.Error(err)
+	}
+
+	infraClient.AssertNotCalled(t, "PutJson", mock.Anything, mock.Anything)
+	infraClient.AssertNotCalled(t, "Delete", mock.Anything)
+	infraClient.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestAlertPolicyRepository_Save_UpdatesInfraConditionBellowInPlace(t *testing.T) {
+	existing := `[{
+		"id": 11,
+		"policy_id": 10,
+		"name": "low-disk",
+		"type": "infra_metric",
+		"comparison": "bellow",
+		"enabled": true,
+		"event_type": "StorageSample",
+		"select_value": "diskFreePercent",
+		"critical_threshold": {"value": 10, "duration_minutes": 5, "time_function": "all"}
+	}]`
+	client, infraClient := newConditionClients(10, "test-policy", existingConditions{infra: existing})
+	client.On("Get", "alerts_nrql_conditions.json?policy_id=10").Return(newStringResponse(`{"nrql_conditions": []}`), nil)
+	infraClient.On("PutJson", "alerts/conditions/11", mock.Anything).Return(newStringResponse("{}"), nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	err := repository.Save(newPolicyWithInfraCondition(10, "test-policy", newLowDiskInfraCondition("below")))
+	if err != nil {
+		t.Error(err)
+	}
+
+	infraClient.AssertCalled(t, "PutJson", "alerts/conditions/11", mock.MatchedBy(containsString(`"comparison":"below"`)))
+	infraClient.AssertNotCalled(t, "Delete", mock.Anything)
+	infraClient.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func newFailingApmConditionClients(putResult error) (*mocks.NewrelicClient, *mocks.NewrelicClient) {
+	client, infraClient := newEmptyConditionClients(10, "test-policy")
+	client.On(
+		"Get",
+		"alerts_nrql_conditions.json?policy_id=10",
+	).Return(
+		newNrqlConditionListResponse(5, "test-condition", "http://old-runbook"),
+		nil,
+	)
+	client.On(
+		"PutJson",
+		"alerts_nrql_conditions/5.json",
+		mock.MatchedBy(containsString("http://new-runbook")),
+	).Return(
+		newStringResponse("{}"),
+		nil,
+	)
+	client.On(
+		"PutJson",
+		"alerts_nrql_conditions/5.json",
+		mock.MatchedBy(containsString("http://old-runbook")),
+	).Return(
+		newStringResponse("{}"),
+		putResult,
+	)
+	client.On(
+		"PostJson",
+		"alerts_conditions/policies/10.json",
+		mock.Anything,
+	).Return(
+		newErrorResponse(422, `{"error": {"title": "Invalid metric"}}`),
+		nil,
+	)
+
+	return client, infraClient
+}
+
+func TestAlertPolicyRepository_Save_RollsBackAppliedChangesOnError(t *testing.T) {
+	client, infraClient := newFailingApmConditionClients(nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://new-runbook")
+	policy.ApmConditions = []*domain.ApmCondition{newApmCondition("test-apm-condition")}
+	err := repository.Save(policy)
+	if err == nil {
+		t.Fatal("Save should return an error")
+	}
+
+	var saveErr newrelic.SaveError
+	if !errors.As(err, &saveErr) {
+		t.Fatalf("Error should be a SaveError, got %T", err)
+	}
+	if !saveErr.RolledBack() {
+		t.Error("All changes should be rolled back")
+	}
+
+	client.AssertCalled(t, "PutJson", "alerts_nrql_conditions/5.json", mock.MatchedBy(containsString("http://old-runbook")))
+}
+
+func TestAlertPolicyRepository_Save_ReportsFailedRollback(t *testing.T) {
+	client, infraClient := newFailingApmConditionClients(errors.New("connection reset"))
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://new-runbook")
+	policy.ApmConditions = []*domain.ApmCondition{newApmCondition("test-apm-condition")}
+	err := repository.Save(policy)
+
+	var saveErr newrelic.SaveError
+	if !errors.As(err, &saveErr) {
+		t.Fatalf("Error should be a SaveError, got %T", err)
+	}
+	if saveErr.RolledBack() {
+		t.Error("Rollback should be reported as failed")
+	}
+}
+
+func TestAlertPolicyRepository_SaveToCheckpoint_KeepsCompletedSteps(t *testing.T) {
+	client, infraClient := newFailingApmConditionClients(nil)
+
+	repository := newrelic.NewAlertPolicyRepository(logr, client, infraClient, newNerdGraphClient(client))
+	policy := newPolicyWithNrqlCondition(10, "test-policy", "test-condition", "http://new-runbook")
+	policy.ApmConditions = []*domain.ApmCondition{newApmCondition("test-apm-condition")}
+	err := repository.SaveToCheckpoint(policy)
+
+	var saveErr newrelic.SaveError
+	if !errors.As(err, &saveErr) {
+		t.Fatalf("Error should be a SaveError, got %T", err)
+	}
+	if saveErr.RolledBack() {
+		t.Error("Changes before the checkpoint should not be rolled back")
+	}
+	if saveErr.Checkpoint() != "nrql conditions" {
+		t.Errorf("Save should stop at the nrql conditions checkpoint, got '%s'", saveErr.Checkpoint())
+	}
+
+	client.AssertNotCalled(t, "PutJson", "alerts_nrql_conditions/5.json", mock.MatchedBy(containsString("http://old-runbook")))
+}
+
+func containsString(substring string) func([]byte) bool {
+	return func(payload []byte) bool {
+		return strings.Contains(string(payload), substring)
+	}
+}
@@ -38,38 +38,83 @@ func (repository apmConditionRepository) getConditions(policyId int64) (*domain.
 	return &conditionList, nil
 }
 
-func (repository apmConditionRepository) saveConditions(policy *domain.AlertPolicy) error {
-	existingConditions, err := repository.getConditions(*policy.Policy.Id)
+func (repository apmConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
 	if err != nil {
 		return err
 	}
 
 	newConditionsSet := domain.NewApmConditionSetFromSlice(policy.ApmConditions)
+	existingConditionSet := domain.NewApmConditionSet(*existingConditions)
 	for _, condition := range existingConditions.Condition {
-		if newConditionsSet.Contains(condition) {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
 			continue
 		}
+
 		err := repository.deleteConditions(*condition.Id)
 		if err != nil {
 			return err
 		}
+
+		deletedCondition := &domain.ApmCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete alert condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
 	}
 
-	existingConditionSet := domain.NewApmConditionSet(*existingConditions)
 	for _, newCondition := range policy.ApmConditions {
-		if existingConditionSet.Contains(newCondition.Condition) {
+		if newCondition.Retained {
+			continue
+		}
+
+		existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+		if !ok {
+			err := repository.createCondition(policyId, newCondition, journal)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		newCondition.Condition.Id = existingCondition.Id
+		if existingCondition.Equals(newCondition.Condition) {
 			continue
 		}
 
-		err := repository.saveCondition(*policy.Policy.Id, newCondition)
+		err := repository.updateCondition(policyId, newCondition)
 		if err != nil {
 			return err
 		}
+
+		previousCondition := &domain.ApmCondition{Condition: existingCondition}
+		journal.record(fmt.Sprintf("update alert condition %d", *existingCondition.Id), func() error {
+			return repository.updateCondition(policyId, previousCondition)
+		})
 	}
 
 	return nil
 }
 
+func (repository apmConditionRepository) createCondition(policyId int64, condition *domain.ApmCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
+	}
+
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create alert condition %d", conditionId), func() error {
+		return repository.deleteConditions(conditionId)
+	})
+
+	return nil
+}
+
 func (repository apmConditionRepository) deleteConditions(conditionId int64) error {
 	repository.log.Info("Deleting alert condition", "ConditionId", conditionId)
 
@@ -97,5 +142,26 @@ func (repository apmConditionRepository) saveCondition(policyId int64, condition
 		return err
 	}
 
+	err = json.NewDecoder(response.Body).Decode(condition)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository apmConditionRepository) updateCondition(policyId int64, condition *domain.ApmCondition) error {
+	repository.log.Info("Updating alert condition", "Policy Id", policyId, "ApmConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_conditions/%d.json", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
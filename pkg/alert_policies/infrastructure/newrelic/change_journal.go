@@ -0,0 +1,141 @@
+package newrelic
+
+import (
+	"fmt"
+	"github.com/go-logr/logr"
+)
+
+// changeJournal records every change applied to New Relic while saving a policy,
+// together with the operation which reverts it.
+// Checkpoints mark the steps of the save which completed, so that a failed save can stop after the last of them.
+type changeJournal struct {
+	log         logr.Logger
+	entries     []journalEntry
+	checkpoints []journalCheckpoint
+}
+
+type journalEntry struct {
+	description string
+	revert      func() error
+}
+
+// journalCheckpoint is a completed step, which covers the entries recorded before it
+type journalCheckpoint struct {
+	description string
+	entries     int
+}
+
+func newChangeJournal(log logr.Logger) *changeJournal {
+	return &changeJournal{
+		log: log,
+	}
+}
+
+func (journal *changeJournal) record(description string, revert func() error) {
+	journal.entries = append(journal.entries, journalEntry{
+		description: description,
+		revert:      revert,
+	})
+}
+
+func (journal *changeJournal) checkpoint(description string) {
+	journal.checkpoints = append(journal.checkpoints, journalCheckpoint{
+		description: description,
+		entries:     len(journal.entries),
+	})
+}
+
+func (journal *changeJournal) isEmpty() bool {
+	return len(journal.entries) == 0
+}
+
+// lastCheckpoint returns the description of the last completed step, or an empty string when no step completed
+func (journal *changeJournal) lastCheckpoint() string {
+	if len(journal.checkpoints) == 0 {
+		return ""
+	}
+
+	return journal.checkpoints[len(journal.checkpoints)-1].description
+}
+
+// rollback reverts the recorded changes in reverse order.
+// It stops at the first change which cannot be reverted, leaving every earlier change in place.
+func (journal *changeJournal) rollback() error {
+	return journal.revertFrom(0)
+}
+
+// rollbackToCheckpoint reverts the changes recorded after the last checkpoint,
+// keeping the changes of the completed steps in place
+func (journal *changeJournal) rollbackToCheckpoint() error {
+	if len(journal.checkpoints) == 0 {
+		return journal.rollback()
+	}
+
+	return journal.revertFrom(journal.checkpoints[len(journal.checkpoints)-1].entries)
+}
+
+func (journal *changeJournal) revertFrom(start int) error {
+	for i := len(journal.entries) - 1; i >= start; i-- {
+		entry := journal.entries[i]
+		journal.log.Info("Reverting change", "Change", entry.description)
+
+		err := entry.revert()
+		if err != nil {
+			return fmt.Errorf("could not revert change '%s', %d change(s) left in place: %s", entry.description, i+1, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// SaveError is returned when saving a policy fails after some changes have already been applied in New Relic
+type SaveError struct {
+	cause       error
+	rollbackErr error
+	checkpoint  string
+}
+
+func newSaveError(cause error, rollbackErr error) SaveError {
+	return SaveError{
+		cause:       cause,
+		rollbackErr: rollbackErr,
+	}
+}
+
+func newCheckpointError(cause error, checkpoint string, rollbackErr error) SaveError {
+	return SaveError{
+		cause:       cause,
+		rollbackErr: rollbackErr,
+		checkpoint:  checkpoint,
+	}
+}
+
+func (err SaveError) Error() string {
+	if err.rollbackErr != nil {
+		return fmt.Sprintf("%s; rollback failed: %s", err.cause.Error(), err.rollbackErr.Error())
+	}
+	if err.checkpoint != "" {
+		return fmt.Sprintf("%s; stopped at checkpoint '%s'", err.cause.Error(), err.checkpoint)
+	}
+
+	return fmt.Sprintf("%s; all changes were rolled back", err.cause.Error())
+}
+
+func (err SaveError) Unwrap() error {
+	return err.cause
+}
+
+// RolledBack returns true when all changes applied before the failure were reverted
+func (err SaveError) RolledBack() bool {
+	return err.rollbackErr == nil && err.checkpoint == ""
+}
+
+// Checkpoint returns the last step whose changes were kept in New Relic,
+// or an empty string when the save did not stop at a checkpoint
+func (err SaveError) Checkpoint() string {
+	if err.rollbackErr != nil {
+		return ""
+	}
+
+	return err.checkpoint
+}
@@ -0,0 +1,159 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/go-logr/logr"
+	"io/ioutil"
+)
+
+type externalServiceConditionRepository struct {
+	client internal.NewrelicClient
+	log    logr.Logger
+}
+
+func newExternalServiceConditionRepository(log logr.Logger, client internal.NewrelicClient) *externalServiceConditionRepository {
+	return &externalServiceConditionRepository{
+		client: client,
+		log:    log,
+	}
+}
+
+func (repository externalServiceConditionRepository) getConditions(policyId int64) (*domain.ExternalServiceConditionList, error) {
+	endpoint := fmt.Sprintf("alerts_external_service_conditions.json?policy_id=%d", policyId)
+	response, err := repository.client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditionList domain.ExternalServiceConditionList
+	err = json.NewDecoder(response.Body).Decode(&conditionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conditionList, nil
+}
+
+func (repository externalServiceConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
+	if err != nil {
+		return err
+	}
+
+	newConditionsSet := domain.NewExternalServiceConditionSetFromSlice(policy.ExternalServiceConditions)
+	existingConditionSet := domain.NewExternalServiceConditionSet(*existingConditions)
+	for _, condition := range existingConditions.Condition {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
+			continue
+		}
+
+		err := repository.deleteCondition(*condition.Id)
+		if err != nil {
+			return err
+		}
+
+		deletedCondition := &domain.ExternalServiceCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete external service condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
+	}
+
+	for _, newCondition := range policy.ExternalServiceConditions {
+		if newCondition.Retained {
+			continue
+		}
+
+		existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+		if !ok {
+			err := repository.createCondition(policyId, newCondition, journal)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		newCondition.Condition.Id = existingCondition.Id
+		if existingCondition.Equals(newCondition.Condition) {
+			continue
+		}
+
+		err := repository.updateCondition(newCondition)
+		if err != nil {
+			return err
+		}
+
+		previousCondition := &domain.ExternalServiceCondition{Condition: existingCondition}
+		journal.record(fmt.Sprintf("update external service condition %d", *existingCondition.Id), func() error {
+			return repository.updateCondition(previousCondition)
+		})
+	}
+
+	return nil
+}
+
+func (repository externalServiceConditionRepository) createCondition(policyId int64, condition *domain.ExternalServiceCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
+	}
+
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create external service condition %d", conditionId), func() error {
+		return repository.deleteCondition(conditionId)
+	})
+
+	return nil
+}
+
+func (repository externalServiceConditionRepository) deleteCondition(conditionId int64) error {
+	repository.log.Info("Deleting external service condition", "ConditionId", conditionId)
+
+	endpoint := fmt.Sprintf("alerts_external_service_conditions/%d.json", conditionId)
+	_, err := repository.client.Delete(endpoint)
+
+	return err
+}
+
+func (repository externalServiceConditionRepository) saveCondition(policyId int64, condition *domain.ExternalServiceCondition) error {
+	repository.log.Info("Saving external service condition", "Policy Id", policyId, "ExternalServiceConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_external_service_conditions/policies/%d.json", policyId)
+	response, err := repository.client.PostJson(endpoint, payload)
+	if response != nil && response.StatusCode >= 300 {
+		responseContent, _ := ioutil.ReadAll(response.Body)
+		return errors.New(string(responseContent))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.NewDecoder(response.Body).Decode(condition)
+}
+
+func (repository externalServiceConditionRepository) updateCondition(condition *domain.ExternalServiceCondition) error {
+	repository.log.Info("Updating external service condition", "ExternalServiceConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_external_service_conditions/%d.json", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
 	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
 	"io/ioutil"
 	"net/http"
@@ -104,3 +106,400 @@ func newStringResponse(response string) *http.Response {
 		Close:      false,
 	}
 }
+
+func newPolicyWithNrqlCondition(id int64, name string, conditionName string, runbookUrl string) *domain.AlertPolicy {
+	policy := newEmptyPolicyWithId(id, name)
+	policy.NrqlConditions = []*domain.NrqlCondition{
+		{
+			Condition: domain.NrqlConditionBody{
+				Type:       "static",
+				Name:       conditionName,
+				RunbookURL: runbookUrl,
+				Enabled:    true,
+				Terms: []domain.Term{
+					{
+						Duration:     "5",
+						Operator:     "above",
+						Priority:     "critical",
+						Threshold:    "10",
+						TimeFunction: "all",
+					},
+				},
+				ValueFunction: "single_value",
+				Nrql: domain.Nrql{
+					Query:      "SELECT count(*) FROM Transaction",
+					SinceValue: "5",
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
+func newNrqlConditionListResponse(conditionId int64, conditionName string, runbookUrl string) *http.Response {
+	return newStringResponse(fmt.Sprintf(`
+		{
+			"nrql_conditions": [{
+				"id": %d,
+				"type": "static",
+				"name": "%s",
+				"runbook_url": "%s",
+				"enabled": true,
+				"terms": [{
+					"duration": "5",
+					"operator": "above",
+					"priority": "critical",
+					"threshold": "10",
+					"time_function": "all"
+				}],
+				"value_function": "single_value",
+				"nrql": {
+					"query": "SELECT count(*) FROM Transaction",
+					"since_value": "5"
+				}
+			}]
+		}
+	`, conditionId, conditionName, runbookUrl))
+}
+
+// newNerdGraphClient sends the NerdGraph requests of account 1 through the mocked client to the "graphql" path
+func newNerdGraphClient(client *mocks.NewrelicClient) internal.NerdGraphClient {
+	return internal.NewNerdGraphClient(client, func() int64 { return 1 })
+}
+
+func isNerdGraphQuery(payload []byte) bool {
+	return strings.HasPrefix(string(payload), `{"query":"query`)
+}
+
+func isNerdGraphMutation(payload []byte) bool {
+	return strings.HasPrefix(string(payload), `{"query":"mutation`)
+}
+
+func newNrqlSignalResponse(aggregationWindow int) *http.Response {
+	return newStringResponse(fmt.Sprintf(`
+		{
+			"data": {
+				"actor": {
+					"account": {
+						"alerts": {
+							"nrqlCondition": {
+								"signal": {
+									"aggregationWindow": %d,
+									"aggregationMethod": "EVENT_FLOW",
+									"aggregationDelay": 120,
+									"aggregationTimer": null,
+									"evaluationOffset": null,
+									"fillOption": "NONE",
+									"fillValue": null
+								},
+								"expiration": {
+									"expirationDuration": null,
+									"openViolationOnExpiration": false,
+									"closeViolationsOnExpiration": false
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`, aggregationWindow))
+}
+
+func newPolicyWithBaselineNrqlCondition(id int64, name string, conditionName string, baselineDirection string) *domain.AlertPolicy {
+	policy := newPolicyWithNrqlCondition(id, name, conditionName, "http://runbook")
+	policy.NrqlConditions[0].Condition.Type = "baseline"
+	policy.NrqlConditions[0].Condition.BaselineDirection = baselineDirection
+	policy.NrqlConditions[0].Condition.Terms[0].Threshold = "3"
+
+	return policy
+}
+
+func newBaselineNrqlConditionListResponse(conditionId int64, conditionName string, baselineDirection string) *http.Response {
+	return newStringResponse(fmt.Sprintf(`
+		{
+			"nrql_conditions": [{
+				"id": %d,
+				"type": "baseline",
+				"name": "%s",
+				"runbook_url": "http://runbook",
+				"enabled": true,
+				"terms": [{
+					"duration": "5",
+					"operator": "above",
+					"priority": "critical",
+					"threshold": "3",
+					"time_function": "all"
+				}],
+				"value_function": "single_value",
+				"baseline_direction": "%s",
+				"nrql": {
+					"query": "SELECT count(*) FROM Transaction",
+					"since_value": "5"
+				}
+			}]
+		}
+	`, conditionId, conditionName, baselineDirection))
+}
+
+func newPolicyWithOutlierNrqlCondition(id int64, name string, conditionName string, expectedGroups int) *domain.AlertPolicy {
+	policy := newPolicyWithNrqlCondition(id, name, conditionName, "http://runbook")
+	policy.NrqlConditions[0].Condition.Type = "outlier"
+	policy.NrqlConditions[0].Condition.ExpectedGroups = expectedGroups
+	policy.NrqlConditions[0].Condition.IgnoreOverlap = true
+	policy.NrqlConditions[0].Condition.Nrql.Query = "SELECT count(*) FROM Transaction FACET host"
+
+	return policy
+}
+
+func newOutlierNrqlConditionListResponse(conditionId int64, conditionName string, expectedGroups int) *http.Response {
+	return newStringResponse(fmt.Sprintf(`
+		{
+			"nrql_conditions": [{
+				"id": %d,
+				"type": "outlier",
+				"name": "%s",
+				"runbook_url": "http://runbook",
+				"enabled": true,
+				"terms": [{
+					"duration": "5",
+					"operator": "above",
+					"priority": "critical",
+					"threshold": "10",
+					"time_function": "all"
+				}],
+				"value_function": "single_value",
+				"expected_groups": %d,
+				"ignore_overlap": true,
+				"nrql": {
+					"query": "SELECT count(*) FROM Transaction FACET host",
+					"since_value": "5"
+				}
+			}]
+		}
+	`, conditionId, conditionName, expectedGroups))
+}
+
+// existingConditions holds the JSON arrays of conditions which New Relic returns for a policy.
+// Empty fields stand for no conditions
+type existingConditions struct {
+	apm             string
+	infra           string
+	synthetics      string
+	locationFailure string
+	externalService string
+}
+
+func newEmptyConditionClients(policyId int64, name string) (*mocks.NewrelicClient, *mocks.NewrelicClient) {
+	return newConditionClients(policyId, name, existingConditions{})
+}
+
+// newConditionClients returns clients for a policy without NRQL conditions,
+// and with the given APM, infra, Synthetics and external service conditions
+func newConditionClients(policyId int64, name string, existing existingConditions) (*mocks.NewrelicClient, *mocks.NewrelicClient) {
+	client := new(mocks.NewrelicClient)
+	client.On(
+		"GetJson",
+		"alerts_policies.json",
+	).Return(
+		newArrayResponse(policyId, name),
+		nil,
+	)
+	client.On(
+		"Get",
+		fmt.Sprintf("alerts_conditions.json?policy_id=%d", policyId),
+	).Return(
+		newStringResponse(fmt.Sprintf(`{"conditions": %s}`, jsonArrayOrEmpty(existing.apm))),
+		nil,
+	)
+	client.On(
+		"Get",
+		fmt.Sprintf("alerts_synthetics_conditions.json?policy_id=%d", policyId),
+	).Return(
+		newStringResponse(fmt.Sprintf(`{"synthetics_conditions": %s}`, jsonArrayOrEmpty(existing.synthetics))),
+		nil,
+	)
+	client.On(
+		"Get",
+		fmt.Sprintf("alerts_location_failure_conditions/policies/%d.json", policyId),
+	).Return(
+		newStringResponse(fmt.Sprintf(`{"location_failure_conditions": %s}`, jsonArrayOrEmpty(existing.locationFailure))),
+		nil,
+	)
+	client.On(
+		"Get",
+		fmt.Sprintf("alerts_external_service_conditions.json?policy_id=%d", policyId),
+	).Return(
+		newStringResponse(fmt.Sprintf(`{"external_service_conditions": %s}`, jsonArrayOrEmpty(existing.externalService))),
+		nil,
+	)
+
+	infraClient := new(mocks.NewrelicClient)
+	infraClient.On(
+		"Get",
+		fmt.Sprintf("alerts/conditions?policy_id=%d", policyId),
+	).Return(
+		newStringResponse(fmt.Sprintf(`{"data": %s}`, jsonArrayOrEmpty(existing.infra))),
+		nil,
+	)
+
+	return client, infraClient
+}
+
+func jsonArrayOrEmpty(array string) string {
+	if array == "" {
+		return "[]"
+	}
+
+	return array
+}
+
+func newPolicyWithExternalServiceCondition(id int64, name string, entities []string, metric string) *domain.AlertPolicy {
+	policy := newEmptyPolicyWithId(id, name)
+	policy.ExternalServiceConditions = []*domain.ExternalServiceCondition{
+		{
+			Condition: domain.ExternalServiceConditionBody{
+				Name:               "payments-slow",
+				Type:               domain.ExternalServiceConditionTypeApm,
+				Enabled:            true,
+				Entities:           entities,
+				ExternalServiceUrl: "api.stripe.com",
+				Metric:             metric,
+				Terms: []domain.Term{
+					{
+						Duration:     "5",
+						Operator:     "above",
+						Priority:     "critical",
+						Threshold:    "2",
+						TimeFunction: "all",
+					},
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
+func newExternalServiceConditionJson(conditionId int64, entities []string, metric string) string {
+	content, err := json.Marshal(newPolicyWithExternalServiceCondition(0, "", entities, metric).ExternalServiceConditions[0].Condition)
+	if err != nil {
+		panic(err)
+	}
+
+	var condition map[string]interface{}
+	if err := json.Unmarshal(content, &condition); err != nil {
+		panic(err)
+	}
+	condition["id"] = conditionId
+
+	content, err = json.Marshal([]interface{}{condition})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(content)
+}
+
+func newPolicyWithInfraCondition(id int64, name string, condition domain.InfraConditionBody) *domain.AlertPolicy {
+	policy := newEmptyPolicyWithId(id, name)
+	policy.InfraConditions = []*domain.InfraCondition{{Condition: condition}}
+
+	return policy
+}
+
+func newLowDiskInfraCondition(comparison string) domain.InfraConditionBody {
+	return domain.InfraConditionBody{
+		Name:              "low-disk",
+		Type:              "infra_metric",
+		PolicyId:          10,
+		Comparison:        comparison,
+		CriticalThreshold: domain.InfraThreshold{Value: intPtr(10), DurationMinutes: 5, TimeFunction: "all"},
+		Enabled:           true,
+		EventType:         "StorageSample",
+		SelectValue:       "diskFreePercent",
+	}
+}
+
+func newPolicyWithSyntheticsCondition(id int64, name string, conditionName string, monitorId string) *domain.AlertPolicy {
+	policy := newEmptyPolicyWithId(id, name)
+	policy.SyntheticsConditions = []*domain.SyntheticsCondition{
+		{
+			Condition: domain.SyntheticsConditionBody{
+				Name:      conditionName,
+				MonitorId: monitorId,
+				Enabled:   true,
+			},
+		},
+	}
+
+	return policy
+}
+
+func newPolicyWithLocationFailureCondition(id int64, name string, conditionName string, monitorIds []string, critical int) *domain.AlertPolicy {
+	policy := newEmptyPolicyWithId(id, name)
+	policy.LocationFailureConditions = []*domain.LocationFailureCondition{
+		{
+			Condition: domain.LocationFailureConditionBody{
+				Name:     conditionName,
+				Enabled:  true,
+				Entities: monitorIds,
+				Terms: []domain.LocationTerm{
+					{Priority: "critical", Threshold: critical},
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
+func newApmCondition(conditionName string) *domain.ApmCondition {
+	return &domain.ApmCondition{
+		Condition: domain.ApmConditionBody{
+			Name:           conditionName,
+			Type:           "apm_app_metric",
+			Enabled:        true,
+			Entities:       []string{"1"},
+			Metric:         "apdex",
+			ConditionScope: "application",
+			Terms: []domain.Term{
+				{
+					Duration:     "5",
+					Operator:     "below",
+					Priority:     "critical",
+					Threshold:    "0.7",
+					TimeFunction: "all",
+				},
+			},
+		},
+	}
+}
+
+func newApmConditionJson(conditionId int64, conditionName string) string {
+	condition := newApmCondition(conditionName).Condition
+	condition.Id = &conditionId
+	content, err := json.Marshal([]domain.ApmConditionBody{condition})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(content)
+}
+
+func newErrorResponse(statusCode int, message string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(message)),
+		Close:      false,
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
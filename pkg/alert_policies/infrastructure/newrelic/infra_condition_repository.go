@@ -38,35 +38,76 @@ func (repository infraConditionRepository) getConditions(policyId int64) (*domai
 	return &conditionList, nil
 }
 
-func (repository infraConditionRepository) saveConditions(policy *domain.AlertPolicy) error {
-	existingConditions, err := repository.getConditions(*policy.Policy.Id)
+func (repository infraConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
 	if err != nil {
 		return err
 	}
 
 	newConditionsSet := domain.NewInfraConditionSetFromSlice(policy.InfraConditions)
+	existingConditionSet := domain.NewInfraConditionSet(*existingConditions)
 	for _, condition := range existingConditions.Condition {
-		if newConditionsSet.Contains(condition) {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
 			continue
 		}
+
 		err := repository.deleteConditions(*condition.Id)
 		if err != nil {
 			return err
 		}
+
+		deletedCondition := &domain.InfraCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete infra condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
 	}
 
-	existingConditionSet := domain.NewInfraConditionSet(*existingConditions)
 	for _, newCondition := range policy.InfraConditions {
-		if existingConditionSet.Contains(newCondition.Condition) {
+		existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+		if !ok {
+			err := repository.createCondition(policyId, newCondition, journal)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
-		err := repository.saveCondition(*policy.Policy.Id, newCondition)
+		newCondition.Condition.Id = existingCondition.Id
+		if existingCondition.Equals(newCondition.Condition) {
+			continue
+		}
+
+		err := repository.updateCondition(policyId, newCondition)
 		if err != nil {
 			return err
 		}
+
+		previousCondition := &domain.InfraCondition{Condition: existingCondition}
+		journal.record(fmt.Sprintf("update infra condition %d", *existingCondition.Id), func() error {
+			return repository.updateCondition(policyId, previousCondition)
+		})
+	}
+
+	return nil
+}
+
+func (repository infraConditionRepository) createCondition(policyId int64, condition *domain.InfraCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
 	}
 
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create infra condition %d", conditionId), func() error {
+		return repository.deleteConditions(conditionId)
+	})
+
 	return nil
 }
 
@@ -97,5 +138,27 @@ func (repository infraConditionRepository) saveCondition(policyId int64, conditi
 		return err
 	}
 
+	err = json.NewDecoder(response.Body).Decode(condition)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository infraConditionRepository) updateCondition(policyId int64, condition *domain.InfraCondition) error {
+	repository.log.Info("Updating infra condition", "Policy Id", policyId, "InfraConditionBody", condition)
+	condition.Condition.PolicyId = policyId
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts/conditions/%d", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
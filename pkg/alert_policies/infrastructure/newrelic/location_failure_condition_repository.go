@@ -0,0 +1,155 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/go-logr/logr"
+	"io/ioutil"
+)
+
+type locationFailureConditionRepository struct {
+	client internal.NewrelicClient
+	log    logr.Logger
+}
+
+func newLocationFailureConditionRepository(log logr.Logger, client internal.NewrelicClient) *locationFailureConditionRepository {
+	return &locationFailureConditionRepository{
+		client: client,
+		log:    log,
+	}
+}
+
+func (repository locationFailureConditionRepository) getConditions(policyId int64) (*domain.LocationFailureConditionList, error) {
+	endpoint := fmt.Sprintf("alerts_location_failure_conditions/policies/%d.json", policyId)
+	response, err := repository.client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditionList domain.LocationFailureConditionList
+	err = json.NewDecoder(response.Body).Decode(&conditionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conditionList, nil
+}
+
+func (repository locationFailureConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
+	if err != nil {
+		return err
+	}
+
+	newConditionsSet := domain.NewLocationFailureConditionSetFromSlice(policy.LocationFailureConditions)
+	existingConditionSet := domain.NewLocationFailureConditionSet(*existingConditions)
+	for _, condition := range existingConditions.Condition {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
+			continue
+		}
+
+		err := repository.deleteCondition(*condition.Id)
+		if err != nil {
+			return err
+		}
+
+		deletedCondition := &domain.LocationFailureCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete multi-location Synthetics condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
+	}
+
+	for _, newCondition := range policy.LocationFailureConditions {
+		existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+		if !ok {
+			err := repository.createCondition(policyId, newCondition, journal)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		newCondition.Condition.Id = existingCondition.Id
+		if existingCondition.Equals(newCondition.Condition) {
+			continue
+		}
+
+		err := repository.updateCondition(newCondition)
+		if err != nil {
+			return err
+		}
+
+		previousCondition := &domain.LocationFailureCondition{Condition: existingCondition}
+		journal.record(fmt.Sprintf("update multi-location Synthetics condition %d", *existingCondition.Id), func() error {
+			return repository.updateCondition(previousCondition)
+		})
+	}
+
+	return nil
+}
+
+func (repository locationFailureConditionRepository) createCondition(policyId int64, condition *domain.LocationFailureCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
+	}
+
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create multi-location Synthetics condition %d", conditionId), func() error {
+		return repository.deleteCondition(conditionId)
+	})
+
+	return nil
+}
+
+func (repository locationFailureConditionRepository) deleteCondition(conditionId int64) error {
+	repository.log.Info("Deleting multi-location Synthetics condition", "ConditionId", conditionId)
+
+	endpoint := fmt.Sprintf("alerts_location_failure_conditions/%d.json", conditionId)
+	_, err := repository.client.Delete(endpoint)
+
+	return err
+}
+
+func (repository locationFailureConditionRepository) saveCondition(policyId int64, condition *domain.LocationFailureCondition) error {
+	repository.log.Info("Saving multi-location Synthetics condition", "Policy Id", policyId, "LocationFailureConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_location_failure_conditions/policies/%d.json", policyId)
+	response, err := repository.client.PostJson(endpoint, payload)
+	if response != nil && response.StatusCode >= 300 {
+		responseContent, _ := ioutil.ReadAll(response.Body)
+		return errors.New(string(responseContent))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.NewDecoder(response.Body).Decode(condition)
+}
+
+func (repository locationFailureConditionRepository) updateCondition(condition *domain.LocationFailureCondition) error {
+	repository.log.Info("Updating multi-location Synthetics condition", "LocationFailureConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_location_failure_conditions/%d.json", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+
+	return err
+}
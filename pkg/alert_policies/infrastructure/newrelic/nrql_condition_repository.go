@@ -11,14 +11,16 @@ import (
 )
 
 type nrqlConditionRepository struct {
-	client internal.NewrelicClient
-	log    logr.Logger
+	client            internal.NewrelicClient
+	streamingSettings *nrqlStreamingSettingsRepository
+	log               logr.Logger
 }
 
-func newNrqlConditionRepository(log logr.Logger, client internal.NewrelicClient) *nrqlConditionRepository {
+func newNrqlConditionRepository(log logr.Logger, client internal.NewrelicClient, nerdGraph internal.NerdGraphClient) *nrqlConditionRepository {
 	return &nrqlConditionRepository{
-		client: client,
-		log:    log,
+		client:            client,
+		streamingSettings: newNrqlStreamingSettingsRepository(log, nerdGraph),
+		log:               log,
 	}
 }
 
@@ -38,15 +40,17 @@ func (repository nrqlConditionRepository) getConditions(policyId int64) (*domain
 	return &conditionList, nil
 }
 
-func (repository nrqlConditionRepository) saveConditions(policy *domain.AlertPolicy) error {
-	existingConditions, err := repository.getConditions(*policy.Policy.Id)
+func (repository nrqlConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
 	if err != nil {
 		return err
 	}
 
 	newConditionsSet := domain.NewNrqlConditionSetFromSlice(policy.NrqlConditions)
+	existingConditionSet := domain.NewNrqlConditionSet(*existingConditions)
 	for _, condition := range existingConditions.Condition {
-		if newConditionsSet.Contains(condition) {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
 			continue
 		}
 
@@ -54,14 +58,21 @@ func (repository nrqlConditionRepository) saveConditions(policy *domain.AlertPol
 		if err != nil {
 			return err
 		}
+
+		deletedCondition := &domain.NrqlCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete NRQL condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
 	}
 
-	existingConditionSet := domain.NewNrqlConditionSet(*existingConditions)
 	for _, newCondition := range policy.NrqlConditions {
-		if existingConditionSet.Contains(newCondition.Condition) {
-			continue
+		err := repository.applyCondition(policyId, existingConditionSet, newCondition, journal)
+		if err != nil {
+			return err
 		}
-		err := repository.saveCondition(*policy.Policy.Id, newCondition)
+
+		err = repository.streamingSettings.saveSettings(newCondition.Condition, journal)
 		if err != nil {
 			return err
 		}
@@ -70,6 +81,72 @@ func (repository nrqlConditionRepository) saveConditions(policy *domain.AlertPol
 	return nil
 }
 
+// applyCondition creates, updates or replaces the condition through the REST API,
+// leaving the New Relic id of the saved condition in newCondition
+func (repository nrqlConditionRepository) applyCondition(policyId int64, existingConditionSet *domain.NrqlConditionSet, newCondition *domain.NrqlCondition, journal *changeJournal) error {
+	existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+	if !ok {
+		return repository.createCondition(policyId, newCondition, journal)
+	}
+
+	newCondition.Condition.Id = existingCondition.Id
+	if existingCondition.Equals(newCondition.Condition) {
+		return nil
+	}
+
+	if !existingCondition.HasSameType(newCondition.Condition) {
+		return repository.replaceCondition(policyId, existingCondition, newCondition, journal)
+	}
+
+	err := repository.updateCondition(policyId, newCondition)
+	if err != nil {
+		return err
+	}
+
+	previousCondition := &domain.NrqlCondition{Condition: existingCondition}
+	journal.record(fmt.Sprintf("update NRQL condition %d", *existingCondition.Id), func() error {
+		return repository.updateCondition(policyId, previousCondition)
+	})
+
+	return nil
+}
+
+func (repository nrqlConditionRepository) createCondition(policyId int64, condition *domain.NrqlCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
+	}
+
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create NRQL condition %d", conditionId), func() error {
+		return repository.deleteConditions(conditionId)
+	})
+
+	return nil
+}
+
+// replaceCondition deletes the existing condition and creates the new one,
+// for changes which New Relic does not accept as an update
+func (repository nrqlConditionRepository) replaceCondition(policyId int64, existingCondition domain.NrqlConditionBody, newCondition *domain.NrqlCondition, journal *changeJournal) error {
+	err := repository.deleteConditions(*existingCondition.Id)
+	if err != nil {
+		return err
+	}
+
+	deletedCondition := &domain.NrqlCondition{Condition: existingCondition}
+	deletedCondition.Condition.Id = nil
+	journal.record(fmt.Sprintf("delete NRQL condition %d", *existingCondition.Id), func() error {
+		return repository.saveCondition(policyId, deletedCondition)
+	})
+
+	newCondition.Condition.Id = nil
+	return repository.createCondition(policyId, newCondition, journal)
+}
+
 func (repository nrqlConditionRepository) deleteConditions(conditionId int64) error {
 	repository.log.Info("Deleting condition", "ConditionId", conditionId)
 
@@ -97,5 +174,26 @@ func (repository nrqlConditionRepository) saveCondition(policyId int64, conditio
 		return err
 	}
 
+	err = json.NewDecoder(response.Body).Decode(condition)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository nrqlConditionRepository) updateCondition(policyId int64, condition *domain.NrqlCondition) error {
+	repository.log.Info("Updating NRQL condition", "Policy Id", policyId, "NrqlConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_nrql_conditions/%d.json", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,145 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/go-logr/logr"
+	"strconv"
+)
+
+const nrqlStreamingSettingsQuery = `query($accountId: Int!, $id: ID!) {
+  actor {
+    account(id: $accountId) {
+      alerts {
+        nrqlCondition(id: $id) {
+          signal {
+            aggregationWindow
+            aggregationMethod
+            aggregationDelay
+            aggregationTimer
+            evaluationOffset
+            fillOption
+            fillValue
+          }
+          expiration {
+            expirationDuration
+            openViolationOnExpiration
+            closeViolationsOnExpiration
+          }
+        }
+      }
+    }
+  }
+}`
+
+const nrqlStreamingSettingsMutation = `mutation($accountId: Int!, $id: ID!, $condition: %s!) {
+  %s(accountId: $accountId, id: $id, condition: $condition) {
+    id
+  }
+}`
+
+type nrqlConditionMutation struct {
+	name      string
+	inputType string
+}
+
+// NerdGraph has a separate update mutation for each type of NRQL condition
+var nrqlConditionMutations = map[string]nrqlConditionMutation{
+	"static":   {name: "alertsNrqlConditionStaticUpdate", inputType: "AlertsNrqlConditionUpdateStaticInput"},
+	"baseline": {name: "alertsNrqlConditionBaselineUpdate", inputType: "AlertsNrqlConditionUpdateBaselineInput"},
+	"outlier":  {name: "alertsNrqlConditionOutlierUpdate", inputType: "AlertsNrqlConditionUpdateOutlierInput"},
+}
+
+type nrqlStreamingSettingsResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				NrqlCondition *domain.NrqlStreamingSettings `json:"nrqlCondition"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+// nrqlStreamingSettingsRepository saves the settings of NRQL conditions which the REST API does not support
+type nrqlStreamingSettingsRepository struct {
+	client internal.NerdGraphClient
+	log    logr.Logger
+}
+
+func newNrqlStreamingSettingsRepository(log logr.Logger, client internal.NerdGraphClient) *nrqlStreamingSettingsRepository {
+	return &nrqlStreamingSettingsRepository{
+		client: client,
+		log:    log,
+	}
+}
+
+// saveSettings updates the streaming settings of a condition which already exists in New Relic.
+// Settings are only sent when they differ from the current ones, and the previous settings are recorded in the journal.
+func (repository nrqlStreamingSettingsRepository) saveSettings(condition domain.NrqlConditionBody, journal *changeJournal) error {
+	if condition.StreamingSettings == nil || condition.Id == nil {
+		return nil
+	}
+
+	if repository.client.AccountId() == 0 {
+		return errors.New("the signal and expiration of NRQL conditions can only be saved when the accountId is set in the operator configuration")
+	}
+
+	conditionId := *condition.Id
+	current, err := repository.getSettings(conditionId)
+	if err != nil {
+		return err
+	}
+
+	if condition.StreamingSettings.IsSatisfiedBy(*current) {
+		return nil
+	}
+
+	err = repository.updateSettings(condition.Type, conditionId, *condition.StreamingSettings)
+	if err != nil {
+		return err
+	}
+
+	journal.record(fmt.Sprintf("update signal of NRQL condition %d", conditionId), func() error {
+		return repository.updateSettings(condition.Type, conditionId, *current)
+	})
+
+	return nil
+}
+
+func (repository nrqlStreamingSettingsRepository) getSettings(conditionId int64) (*domain.NrqlStreamingSettings, error) {
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"id":        strconv.FormatInt(conditionId, 10),
+	}
+
+	var response nrqlStreamingSettingsResponse
+	err := repository.client.Query(nrqlStreamingSettingsQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := response.Actor.Account.Alerts.NrqlCondition
+	if settings == nil {
+		return nil, fmt.Errorf("NRQL condition %d was not found in account %d", conditionId, repository.client.AccountId())
+	}
+
+	return settings, nil
+}
+
+func (repository nrqlStreamingSettingsRepository) updateSettings(conditionType string, conditionId int64, settings domain.NrqlStreamingSettings) error {
+	repository.log.Info("Updating signal of NRQL condition", "ConditionId", conditionId, "Settings", settings)
+	mutation, ok := nrqlConditionMutations[conditionType]
+	if !ok {
+		return fmt.Errorf("the signal of NRQL conditions of type %s cannot be updated", conditionType)
+	}
+
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"id":        strconv.FormatInt(conditionId, 10),
+		"condition": settings,
+	}
+
+	return repository.client.Query(fmt.Sprintf(nrqlStreamingSettingsMutation, mutation.inputType, mutation.name), variables, nil)
+}
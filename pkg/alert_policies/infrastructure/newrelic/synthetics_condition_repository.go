@@ -0,0 +1,155 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/alert_policies/domain"
+	"github.com/go-logr/logr"
+	"io/ioutil"
+)
+
+type syntheticsConditionRepository struct {
+	client internal.NewrelicClient
+	log    logr.Logger
+}
+
+func newSyntheticsConditionRepository(log logr.Logger, client internal.NewrelicClient) *syntheticsConditionRepository {
+	return &syntheticsConditionRepository{
+		client: client,
+		log:    log,
+	}
+}
+
+func (repository syntheticsConditionRepository) getConditions(policyId int64) (*domain.SyntheticsConditionList, error) {
+	endpoint := fmt.Sprintf("alerts_synthetics_conditions.json?policy_id=%d", policyId)
+	response, err := repository.client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditionList domain.SyntheticsConditionList
+	err = json.NewDecoder(response.Body).Decode(&conditionList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conditionList, nil
+}
+
+func (repository syntheticsConditionRepository) saveConditions(policy *domain.AlertPolicy, journal *changeJournal) error {
+	policyId := *policy.Policy.Id
+	existingConditions, err := repository.getConditions(policyId)
+	if err != nil {
+		return err
+	}
+
+	newConditionsSet := domain.NewSyntheticsConditionSetFromSlice(policy.SyntheticsConditions)
+	existingConditionSet := domain.NewSyntheticsConditionSet(*existingConditions)
+	for _, condition := range existingConditions.Condition {
+		if newConditionsSet.Contains(condition) && !existingConditionSet.IsDuplicate(condition) {
+			continue
+		}
+
+		err := repository.deleteCondition(*condition.Id)
+		if err != nil {
+			return err
+		}
+
+		deletedCondition := &domain.SyntheticsCondition{Condition: condition}
+		deletedCondition.Condition.Id = nil
+		journal.record(fmt.Sprintf("delete Synthetics condition %d", *condition.Id), func() error {
+			return repository.saveCondition(policyId, deletedCondition)
+		})
+	}
+
+	for _, newCondition := range policy.SyntheticsConditions {
+		existingCondition, ok := existingConditionSet.Get(newCondition.Condition)
+		if !ok {
+			err := repository.createCondition(policyId, newCondition, journal)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		newCondition.Condition.Id = existingCondition.Id
+		if existingCondition.Equals(newCondition.Condition) {
+			continue
+		}
+
+		err := repository.updateCondition(newCondition)
+		if err != nil {
+			return err
+		}
+
+		previousCondition := &domain.SyntheticsCondition{Condition: existingCondition}
+		journal.record(fmt.Sprintf("update Synthetics condition %d", *existingCondition.Id), func() error {
+			return repository.updateCondition(previousCondition)
+		})
+	}
+
+	return nil
+}
+
+func (repository syntheticsConditionRepository) createCondition(policyId int64, condition *domain.SyntheticsCondition, journal *changeJournal) error {
+	err := repository.saveCondition(policyId, condition)
+	if err != nil {
+		return err
+	}
+
+	if condition.Condition.Id == nil {
+		return nil
+	}
+
+	conditionId := *condition.Condition.Id
+	journal.record(fmt.Sprintf("create Synthetics condition %d", conditionId), func() error {
+		return repository.deleteCondition(conditionId)
+	})
+
+	return nil
+}
+
+func (repository syntheticsConditionRepository) deleteCondition(conditionId int64) error {
+	repository.log.Info("Deleting Synthetics condition", "ConditionId", conditionId)
+
+	endpoint := fmt.Sprintf("alerts_synthetics_conditions/%d.json", conditionId)
+	_, err := repository.client.Delete(endpoint)
+
+	return err
+}
+
+func (repository syntheticsConditionRepository) saveCondition(policyId int64, condition *domain.SyntheticsCondition) error {
+	repository.log.Info("Saving Synthetics condition", "Policy Id", policyId, "SyntheticsConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_synthetics_conditions/policies/%d.json", policyId)
+	response, err := repository.client.PostJson(endpoint, payload)
+	if response != nil && response.StatusCode >= 300 {
+		responseContent, _ := ioutil.ReadAll(response.Body)
+		return errors.New(string(responseContent))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.NewDecoder(response.Body).Decode(condition)
+}
+
+func (repository syntheticsConditionRepository) updateCondition(condition *domain.SyntheticsCondition) error {
+	repository.log.Info("Updating Synthetics condition", "SyntheticsConditionBody", condition)
+	payload, err := json.Marshal(&condition)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("alerts_synthetics_conditions/%d.json", *condition.Condition.Id)
+	_, err = repository.client.PutJson(endpoint, payload)
+
+	return err
+}
@@ -0,0 +1,10 @@
+package apis
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1beta1"
+)
+
+func init() {
+	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
+	AddToSchemes = append(AddToSchemes, v1beta1.SchemeBuilder.AddToScheme)
+}
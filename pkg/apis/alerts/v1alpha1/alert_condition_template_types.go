@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AlertConditionTemplateKind        = "AlertConditionTemplate"
+	ClusterAlertConditionTemplateKind = "ClusterAlertConditionTemplate"
+)
+
+const (
+	TemplateParameterTypeString = "string"
+	TemplateParameterTypeInt    = "int"
+	TemplateParameterTypeFloat  = "float"
+	TemplateParameterTypeBool   = "bool"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertConditionTemplate holds alert conditions which the alert policies of its namespace can include
+// +kubebuilder:resource:path=alertconditiontemplates,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this template"
+type AlertConditionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertConditionTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterAlertConditionTemplate holds alert conditions which the alert policies of all namespaces can include
+// +kubebuilder:resource:path=clusteralertconditiontemplates,scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this template"
+type ClusterAlertConditionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertConditionTemplateSpec `json:"spec,omitempty"`
+}
+
+// AlertConditionTemplateSpec defines the conditions of a template. \
+// The string fields of the conditions can contain [Go template](https://golang.org/pkg/text/template/) placeholders,
+// such as `{{ .appName }}`, which are replaced by the parameter values of the policy including the template
+type AlertConditionTemplateSpec struct {
+	// The parameters which policies pass to the template
+	// +optional
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+	// A list of APM alert conditions to add to the policies including the template
+	// +optional
+	ApmConditions []ApmCondition `json:"apmConditions,omitempty"`
+	// A list of NRQL alert conditions to add to the policies including the template
+	// +optional
+	NrqlConditions []NrqlCondition `json:"nrqlConditions,omitempty"`
+	// A list of Infrastructure alert conditions to add to the policies including the template
+	// +optional
+	InfraConditions []InfraCondition `json:"infraConditions,omitempty"`
+}
+
+type TemplateParameter struct {
+	// The name of the parameter, which placeholders refer to as `{{ .<name> }}`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+	// The format the value of the parameter must have. Available options are: \
+	// - `string` \
+	// - `int` \
+	// - `float` \
+	// - `bool` \
+	// Placeholders are always replaced by the value as text, so the type only checks the value passed by a policy,
+	// e.g. for a threshold. Numeric and boolean fields of the conditions cannot contain placeholders. \
+	// Defaults to `string`
+	// +kubebuilder:validation:Enum=string;int;float;bool
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// The value used when a policy does not pass the parameter. Parameters without a default are required
+	// +optional
+	Default *string `json:"default,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// AlertConditionTemplateReference includes the conditions of a template in a policy
+type AlertConditionTemplateReference struct {
+	// The kind of the template. Available options are: \
+	// - `AlertConditionTemplate` - a template in the namespace of the policy \
+	// - `ClusterAlertConditionTemplate` \
+	// Defaults to `AlertConditionTemplate`
+	// +kubebuilder:validation:Enum=AlertConditionTemplate;ClusterAlertConditionTemplate
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+	// The name of the template
+	Name string `json:"name"`
+	// The values of the template parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// TemplateKind returns the kind of the referenced template, applying the default
+func (reference AlertConditionTemplateReference) TemplateKind() string {
+	if reference.Kind == nil {
+		return DefaultTemplateKind
+	}
+
+	return *reference.Kind
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AlertConditionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertConditionTemplate `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterAlertConditionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterAlertConditionTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertConditionTemplate{}, &AlertConditionTemplateList{})
+	SchemeBuilder.Register(&ClusterAlertConditionTemplate{}, &ClusterAlertConditionTemplateList{})
+}
@@ -0,0 +1,156 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StandaloneCondition is implemented by the condition resources which are added to an AlertPolicy through their policyRef
+type StandaloneCondition interface {
+	runtime.Object
+	metav1.Object
+
+	GetPolicyRef() PolicyReference
+	GetConditionName() string
+	GetStatus() AlertConditionStatus
+	SetStatus(status AlertConditionStatus)
+}
+
+// PolicyReference refers to the AlertPolicy a standalone condition is added to
+type PolicyReference struct {
+	// The name of the AlertPolicy
+	Name string `json:"name"`
+	// The namespace of the AlertPolicy. Defaults to the namespace of the condition
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NamespacedName returns the policy the reference of a condition in the given namespace points to
+func (reference PolicyReference) NamespacedName(namespace string) types.NamespacedName {
+	if reference.Namespace != "" {
+		namespace = reference.Namespace
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: reference.Name}
+}
+
+// AlertConditionStatus defines the observed state of a standalone condition
+type AlertConditionStatus struct {
+	v1alpha1.Status `json:",inline"`
+	// The AlertPolicy the condition was added to, as <namespace>/<name>
+	// +optional
+	Policy string `json:"policy,omitempty"`
+}
+
+func NewConditionError(policy types.NamespacedName, err error) AlertConditionStatus {
+	return AlertConditionStatus{
+		Status: v1alpha1.NewError(nil, err),
+		Policy: policy.String(),
+	}
+}
+
+func NewConditionReady(policy types.NamespacedName) AlertConditionStatus {
+	return AlertConditionStatus{
+		Status: v1alpha1.NewReady(nil),
+		Policy: policy.String(),
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NrqlAlertCondition is a NRQL condition which is added to the AlertPolicy it refers to
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=nrqlalertconditions,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name of this condition"
+// +kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".spec.policyRef.name",description="The policy of this condition"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this condition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this condition"
+type NrqlAlertCondition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NrqlAlertConditionSpec `json:"spec,omitempty"`
+	Status AlertConditionStatus   `json:"status,omitempty"`
+}
+
+type NrqlAlertConditionSpec struct {
+	// The policy the condition is added to
+	PolicyRef     PolicyReference `json:"policyRef"`
+	NrqlCondition `json:",inline"`
+}
+
+func (condition *NrqlAlertCondition) GetPolicyRef() PolicyReference {
+	return condition.Spec.PolicyRef
+}
+
+func (condition *NrqlAlertCondition) GetConditionName() string {
+	return condition.Spec.Name
+}
+
+func (condition *NrqlAlertCondition) GetStatus() AlertConditionStatus {
+	return condition.Status
+}
+
+func (condition *NrqlAlertCondition) SetStatus(status AlertConditionStatus) {
+	condition.Status = status
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApmAlertCondition is an APM condition which is added to the AlertPolicy it refers to
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=apmalertconditions,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name of this condition"
+// +kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".spec.policyRef.name",description="The policy of this condition"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this condition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this condition"
+type ApmAlertCondition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApmAlertConditionSpec `json:"spec,omitempty"`
+	Status AlertConditionStatus  `json:"status,omitempty"`
+}
+
+type ApmAlertConditionSpec struct {
+	// The policy the condition is added to
+	PolicyRef    PolicyReference `json:"policyRef"`
+	ApmCondition `json:",inline"`
+}
+
+func (condition *ApmAlertCondition) GetPolicyRef() PolicyReference {
+	return condition.Spec.PolicyRef
+}
+
+func (condition *ApmAlertCondition) GetConditionName() string {
+	return condition.Spec.Name
+}
+
+func (condition *ApmAlertCondition) GetStatus() AlertConditionStatus {
+	return condition.Status
+}
+
+func (condition *ApmAlertCondition) SetStatus(status AlertConditionStatus) {
+	condition.Status = status
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NrqlAlertConditionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NrqlAlertCondition `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApmAlertConditionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApmAlertCondition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NrqlAlertCondition{}, &NrqlAlertConditionList{})
+	SchemeBuilder.Register(&ApmAlertCondition{}, &ApmAlertConditionList{})
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// blank assignments to verify that the standalone conditions implement admission.Validator
+var _ admission.Validator = &NrqlAlertCondition{}
+var _ admission.Validator = &ApmAlertCondition{}
+
+func (condition *NrqlAlertCondition) ValidateCreate() error {
+	return newConditionInvalidError("NrqlAlertCondition", condition.Name, condition.Spec.Validate(field.NewPath("spec")))
+}
+
+func (condition *NrqlAlertCondition) ValidateUpdate(old runtime.Object) error {
+	return condition.ValidateCreate()
+}
+
+func (condition *NrqlAlertCondition) ValidateDelete() error {
+	return nil
+}
+
+func (condition *ApmAlertCondition) ValidateCreate() error {
+	return newConditionInvalidError("ApmAlertCondition", condition.Name, condition.Spec.Validate(field.NewPath("spec")))
+}
+
+func (condition *ApmAlertCondition) ValidateUpdate(old runtime.Object) error {
+	return condition.ValidateCreate()
+}
+
+func (condition *ApmAlertCondition) ValidateDelete() error {
+	return nil
+}
+
+// Validate checks the reference to the policy and the condition like the NRQL conditions of an AlertPolicy
+func (spec NrqlAlertConditionSpec) Validate(path *field.Path) field.ErrorList {
+	errs := spec.PolicyRef.validate(path.Child("policyRef"))
+	if spec.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+
+	return append(errs, spec.NrqlCondition.validate(path)...)
+}
+
+// Validate checks the reference to the policy and the condition like the APM conditions of an AlertPolicy
+func (spec ApmAlertConditionSpec) Validate(path *field.Path) field.ErrorList {
+	errs := spec.PolicyRef.validate(path.Child("policyRef"))
+	if spec.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+
+	return append(errs, spec.ApmCondition.validate(path)...)
+}
+
+func (reference PolicyReference) validate(path *field.Path) field.ErrorList {
+	if reference.Name == "" {
+		return field.ErrorList{field.Required(path.Child("name"), "")}
+	}
+
+	return nil
+}
+
+func newConditionInvalidError(kind string, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
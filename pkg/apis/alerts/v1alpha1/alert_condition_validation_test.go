@@ -0,0 +1,61 @@
+package v1alpha1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"io/ioutil"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+func TestValidate_NrqlAlertConditionWithoutPolicyRef(t *testing.T) {
+	condition := readExampleConditions(t)[0].(*v1alpha1.NrqlAlertCondition)
+	condition.Spec.PolicyRef.Name = ""
+
+	err := condition.ValidateCreate()
+	if err == nil || !strings.Contains(err.Error(), "spec.policyRef.name: Required value") {
+		t.Errorf("Expected a missing policyRef error, got %v", err)
+	}
+}
+
+func TestValidate_ApmAlertConditionWithoutName(t *testing.T) {
+	condition := readExampleConditions(t)[1].(*v1alpha1.ApmAlertCondition)
+	condition.Spec.Name = ""
+
+	err := condition.ValidateCreate()
+	if err == nil || !strings.Contains(err.Error(), "spec.name: Required value") {
+		t.Errorf("Expected a missing name error, got %v", err)
+	}
+}
+
+func TestValidate_ConditionExamples(t *testing.T) {
+	for _, condition := range readExampleConditions(t) {
+		validator := condition.(interface{ ValidateCreate() error })
+		if err := validator.ValidateCreate(); err != nil {
+			t.Errorf("Expected %s to be valid, got %v", condition.GetName(), err)
+		}
+	}
+}
+
+// readExampleConditions returns the NRQL and the APM condition of hack/examples/alertcondition_cr.yaml
+func readExampleConditions(t *testing.T) []v1alpha1.StandaloneCondition {
+	content, err := ioutil.ReadFile("../../../../hack/examples/alertcondition_cr.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	documents := strings.Split(string(content), "\n---\n")
+	if len(documents) != 2 {
+		t.Fatalf("Expected 2 conditions, got %d", len(documents))
+	}
+
+	var nrqlCondition v1alpha1.NrqlAlertCondition
+	if err := yaml.UnmarshalStrict([]byte(documents[0]), &nrqlCondition); err != nil {
+		t.Fatal(err)
+	}
+	var apmCondition v1alpha1.ApmAlertCondition
+	if err := yaml.UnmarshalStrict([]byte(documents[1]), &apmCondition); err != nil {
+		t.Fatal(err)
+	}
+
+	return []v1alpha1.StandaloneCondition{&nrqlCondition, &apmCondition}
+}
@@ -0,0 +1,153 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apm"
+)
+
+const (
+	// DefaultConditionEnabled is used when a condition does not set the enabled field
+	DefaultConditionEnabled = true
+	// DefaultConditionScope is used when an APM condition does not set the conditionScope field
+	DefaultConditionScope = "application"
+	// DefaultMissingEntities is used when an APM or external service condition does not set the missingEntities field
+	DefaultMissingEntities = MissingEntitiesFail
+	// DefaultNrqlConditionType is used when a NRQL condition does not set the type field
+	DefaultNrqlConditionType = NrqlConditionTypeStatic
+	// DefaultIgnoreOverlap is used when an outlier NRQL condition does not set the ignoreOverlap field
+	DefaultIgnoreOverlap = false
+	// DefaultInfraConditionType is used when an infra condition does not set the type field
+	DefaultInfraConditionType = InfraConditionTypeMetric
+	// DefaultTemplateKind is used when a template reference does not set the kind field
+	DefaultTemplateKind = AlertConditionTemplateKind
+	// DefaultTemplateParameterType is used when a template parameter does not set the type field
+	DefaultTemplateParameterType = TemplateParameterTypeString
+	// DefaultMutingRuleEnabled is used when a muting rule does not set the enabled field
+	DefaultMutingRuleEnabled = true
+	// DefaultMutingRuleOperator is used when the condition of a muting rule does not set the operator field
+	DefaultMutingRuleOperator = MutingRuleOperatorAnd
+)
+
+const (
+	MissingEntitiesFail = "fail"
+	MissingEntitiesSkip = "skip"
+	MissingEntitiesWait = "wait"
+)
+
+const (
+	NrqlConditionTypeStatic   = "static"
+	NrqlConditionTypeBaseline = "baseline"
+	NrqlConditionTypeOutlier  = "outlier"
+)
+
+const (
+	InfraConditionTypeMetric           = "infra_metric"
+	InfraConditionTypeProcessRunning   = "infra_process_running"
+	InfraConditionTypeHostNotReporting = "infra_host_not_reporting"
+)
+
+const (
+	BaselineDirectionUpperOnly     = "upper_only"
+	BaselineDirectionLowerOnly     = "lower_only"
+	BaselineDirectionUpperAndLower = "upper_and_lower"
+)
+
+const (
+	AggregationMethodEventFlow  = "event_flow"
+	AggregationMethodEventTimer = "event_timer"
+	AggregationMethodCadence    = "cadence"
+)
+
+const (
+	FillOptionNone      = "none"
+	FillOptionLastValue = "last_value"
+	FillOptionStatic    = "static"
+)
+
+// PolicyDefaults holds the values written into the conditions of an AlertPolicy
+// when the corresponding fields are left empty
+type PolicyDefaults struct {
+	Enabled        bool
+	ConditionScope string
+}
+
+func NewPolicyDefaults() PolicyDefaults {
+	return PolicyDefaults{
+		Enabled:        DefaultConditionEnabled,
+		ConditionScope: DefaultConditionScope,
+	}
+}
+
+// SetDefaults fills the empty fields of all conditions, so that the stored
+// resource shows exactly what is sent to New Relic
+func (policy *AlertPolicy) SetDefaults(defaults PolicyDefaults) {
+	for i := range policy.Spec.ApmConditions {
+		condition := &policy.Spec.ApmConditions[i]
+		if condition.Enabled == nil {
+			condition.Enabled = boolPtr(defaults.Enabled)
+		}
+		if condition.ConditionScope == nil {
+			condition.ConditionScope = stringPtr(conditionScopeFor(condition.Type, defaults.ConditionScope))
+		}
+		if condition.MissingEntities == nil {
+			condition.MissingEntities = stringPtr(DefaultMissingEntities)
+		}
+	}
+
+	for i := range policy.Spec.NrqlConditions {
+		condition := &policy.Spec.NrqlConditions[i]
+		if condition.Enabled == nil {
+			condition.Enabled = boolPtr(defaults.Enabled)
+		}
+		if condition.Type == nil {
+			condition.Type = stringPtr(DefaultNrqlConditionType)
+		}
+		if *condition.Type == NrqlConditionTypeOutlier && condition.IgnoreOverlap == nil {
+			condition.IgnoreOverlap = boolPtr(DefaultIgnoreOverlap)
+		}
+	}
+
+	for i := range policy.Spec.InfraConditions {
+		condition := &policy.Spec.InfraConditions[i]
+		if condition.Enabled == nil {
+			condition.Enabled = boolPtr(defaults.Enabled)
+		}
+		if condition.Type == nil {
+			condition.Type = stringPtr(DefaultInfraConditionType)
+		}
+	}
+
+	for i := range policy.Spec.SyntheticsConditions {
+		condition := &policy.Spec.SyntheticsConditions[i]
+		if condition.Enabled == nil {
+			condition.Enabled = boolPtr(defaults.Enabled)
+		}
+	}
+
+	for i := range policy.Spec.ExternalServiceConditions {
+		condition := &policy.Spec.ExternalServiceConditions[i]
+		if condition.Enabled == nil {
+			condition.Enabled = boolPtr(defaults.Enabled)
+		}
+		if condition.MissingEntities == nil {
+			condition.MissingEntities = stringPtr(DefaultMissingEntities)
+		}
+	}
+}
+
+// conditionScopeFor falls back to DefaultConditionScope when the condition type
+// does not support the configured scope, e.g. `instance` for browser conditions
+func conditionScopeFor(conditionType string, conditionScope string) string {
+	if apm.SupportsConditionScope(conditionType, conditionScope) {
+		return conditionScope
+	}
+
+	return DefaultConditionScope
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+)
+
+var (
+	SaveOutcomeApplied          = "Applied"
+	SaveOutcomeReverted         = "Reverted"
+	SaveOutcomePartiallyApplied = "PartiallyApplied"
+	SaveOutcomeCheckpoint       = "StoppedAtCheckpoint"
+	SaveOutcomeNotAttempted     = "NotAttempted"
+)
+
+// AlertPolicyStatus defines the observed state of an AlertPolicy
+type AlertPolicyStatus struct {
+	v1alpha1.Status `json:",inline"`
+	// The outcome of the last attempt to save the policy in New Relic. \
+	// Can be one of: \
+	// - `Applied` - all changes were saved in New Relic \
+	// - `Reverted` - saving failed and New Relic was left in its previous state \
+	// - `PartiallyApplied` - saving failed and the changes could not be fully rolled back. The `reason` field describes where the rollback stopped \
+	// - `StoppedAtCheckpoint` - saving failed and the changes of the steps completed before the failure were kept, as configured by the `saveFailureMode` of the operator. The `reason` field names the last completed step \
+	// - `NotAttempted` - the policy could not be built, e.g. because a template or monitor is missing, so nothing was sent to New Relic \
+	// +optional
+	SaveOutcome string `json:"saveOutcome,omitempty"`
+	// The applications of APM conditions which do not exist in New Relic
+	// +optional
+	UnresolvedEntities []UnresolvedEntity `json:"unresolvedEntities,omitempty"`
+	// The New Relic entities the applications and entity selectors of APM and external service conditions resolved to
+	// +optional
+	ResolvedEntities []ResolvedEntity `json:"resolvedEntities,omitempty"`
+}
+
+// UnresolvedEntity is an application referenced by an APM condition which does not exist in New Relic
+type UnresolvedEntity struct {
+	// The name of the APM condition
+	Condition string `json:"condition"`
+	// The name of the application, or the entity selector which did not match any application
+	Entity string `json:"entity"`
+}
+
+// ResolvedEntity lists the New Relic entities an application name or entity selector of a condition resolved to
+type ResolvedEntity struct {
+	// The name of the condition
+	Condition string `json:"condition"`
+	// The name of the application, or the entity selector
+	Entity string `json:"entity"`
+	// The kind of the entities, which depends on the condition type. \
+	// Can be one of `application`, `browser_application`, `mobile_application` or `key_transaction`
+	Kind string `json:"kind"`
+	// The New Relic ids of the entities
+	Ids []int `json:"ids"`
+}
+
+func NewPolicyError(newrelicId *int64, err error, saveOutcome string, unresolved []UnresolvedEntity) AlertPolicyStatus {
+	return AlertPolicyStatus{
+		Status:             v1alpha1.NewError(newrelicId, err),
+		SaveOutcome:        saveOutcome,
+		UnresolvedEntities: unresolved,
+	}
+}
+
+// WithResolvedEntities returns a copy of the status which lists the resolved entities
+func (status AlertPolicyStatus) WithResolvedEntities(resolved []ResolvedEntity) AlertPolicyStatus {
+	status.ResolvedEntities = resolved
+	return status
+}
+
+func NewPolicyReady(newrelicId *int64, unresolved []UnresolvedEntity) AlertPolicyStatus {
+	return AlertPolicyStatus{
+		Status:             v1alpha1.NewReady(newrelicId),
+		SaveOutcome:        SaveOutcomeApplied,
+		UnresolvedEntities: unresolved,
+	}
+}
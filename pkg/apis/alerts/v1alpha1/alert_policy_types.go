@@ -1,7 +1,6 @@
 package v1alpha1
 
 import (
-	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -9,6 +8,7 @@ import (
 
 // AlertPolicy is the Schema for the newrelicalertpolicies API
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=alertpolicies,scope=Namespaced
 // +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this policy"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this policy"
@@ -18,8 +18,8 @@ type AlertPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   AlertPolicySpec `json:"spec,omitempty"`
-	Status v1alpha1.Status `json:"status,omitempty"`
+	Spec   AlertPolicySpec   `json:"spec,omitempty"`
+	Status AlertPolicyStatus `json:"status,omitempty"`
 }
 
 // AlertPolicySpec defines the desired state of AlertPolicy.
@@ -43,6 +43,15 @@ type AlertPolicySpec struct {
 	// A list of Infrastructure alert conditions to attach to the policy
 	// +optional
 	InfraConditions []InfraCondition `json:"infraConditions,omitempty"`
+	// A list of Synthetics alert conditions to attach to the policy
+	// +optional
+	SyntheticsConditions []SyntheticsCondition `json:"syntheticsConditions,omitempty"`
+	// A list of APM external service alert conditions to attach to the policy
+	// +optional
+	ExternalServiceConditions []ExternalServiceCondition `json:"externalServiceConditions,omitempty"`
+	// A list of condition templates whose conditions are added to the policy
+	// +optional
+	Templates []AlertConditionTemplateReference `json:"templates,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,607 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apm"
+	"github.com/personio/newrelic-alert-manager/pkg/nrql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net/url"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strconv"
+	"strings"
+)
+
+// The constraints below are documented in the New Relic Alerts REST API reference:
+// https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names
+var (
+	apmViolationCloseTimers = []int{1, 2, 4, 8, 12, 24}
+)
+
+const (
+	minApmDurationMinutes   = 5
+	maxApmDurationMinutes   = 120
+	minSinceMinutes         = 1
+	maxSinceMinutes         = 120
+	minNrqlDurationMinutes  = 1
+	maxNrqlDurationMinutes  = 120
+	minInfraDurationMinutes = 1
+	maxInfraDurationMinutes = 60
+	minStandardDeviations   = 1
+	maxStandardDeviations   = 1000
+	userDefinedMetric       = "user_defined"
+)
+
+// blank assignment to verify that AlertPolicy implements admission.Validator
+var _ admission.Validator = &AlertPolicy{}
+
+func (policy *AlertPolicy) ValidateCreate() error {
+	return policy.validate()
+}
+
+// ValidateUpdate only checks updates which change the spec. Policies stored before a rule was added
+// must still accept the finalizer updates of the operator, otherwise they could never be deleted
+func (policy *AlertPolicy) ValidateUpdate(old runtime.Object) error {
+	if policy.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldPolicy, ok := old.(*AlertPolicy); ok && reflect.DeepEqual(policy.Spec, oldPolicy.Spec) {
+		return nil
+	}
+
+	return policy.validate()
+}
+
+func (policy *AlertPolicy) ValidateDelete() error {
+	return nil
+}
+
+func (policy *AlertPolicy) validate() error {
+	errs := policy.Spec.Validate(field.NewPath("spec"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind("AlertPolicy").GroupKind(), policy.Name, errs)
+}
+
+// Validate checks the spec against the constraints which New Relic enforces, or silently ignores,
+// but which cannot be expressed in the CRD schema
+func (spec AlertPolicySpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+
+	apmNames := make(map[string]bool)
+	for i, condition := range spec.ApmConditions {
+		conditionPath := path.Child("apmConditions").Index(i)
+		errs = append(errs, validateUniqueName(apmNames, condition.Name, conditionPath.Child("name"))...)
+		errs = append(errs, condition.validate(conditionPath)...)
+	}
+
+	nrqlNames := make(map[string]bool)
+	for i, condition := range spec.NrqlConditions {
+		conditionPath := path.Child("nrqlConditions").Index(i)
+		errs = append(errs, validateUniqueName(nrqlNames, condition.Name, conditionPath.Child("name"))...)
+		errs = append(errs, condition.validate(conditionPath)...)
+	}
+
+	infraNames := make(map[string]bool)
+	for i, condition := range spec.InfraConditions {
+		conditionPath := path.Child("infraConditions").Index(i)
+		errs = append(errs, validateUniqueName(infraNames, condition.Name, conditionPath.Child("name"))...)
+		errs = append(errs, condition.validate(conditionPath)...)
+	}
+
+	syntheticsNames := make(map[string]bool)
+	for i, condition := range spec.SyntheticsConditions {
+		conditionPath := path.Child("syntheticsConditions").Index(i)
+		errs = append(errs, validateUniqueName(syntheticsNames, condition.Name, conditionPath.Child("name"))...)
+		errs = append(errs, condition.validate(conditionPath)...)
+	}
+
+	externalServiceNames := make(map[string]bool)
+	for i, condition := range spec.ExternalServiceConditions {
+		conditionPath := path.Child("externalServiceConditions").Index(i)
+		errs = append(errs, validateUniqueName(externalServiceNames, condition.Name, conditionPath.Child("name"))...)
+		errs = append(errs, condition.validate(conditionPath)...)
+	}
+
+	for i, template := range spec.Templates {
+		if template.Name == "" {
+			errs = append(errs, field.Required(path.Child("templates").Index(i).Child("name"), ""))
+		}
+	}
+
+	return errs
+}
+
+func (condition ApmCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(condition.Entities) == 0 && len(condition.EntitySelectors) == 0 {
+		errs = append(errs, field.Required(path.Child("entities"), "at least one application or entity selector is required"))
+	}
+	for i, selector := range condition.EntitySelectors {
+		errs = append(errs, selector.Validate(path.Child("entitySelectors").Index(i))...)
+	}
+
+	if condition.Metric == "" {
+		errs = append(errs, field.Required(path.Child("metric"), ""))
+	} else if err := apm.ValidateMetric(condition.Type, condition.Metric); err != nil {
+		errs = append(errs, field.Invalid(path.Child("metric"), condition.Metric, err.Error()))
+	}
+	if condition.ConditionScope != nil {
+		if err := apm.ValidateConditionScope(condition.Type, *condition.ConditionScope); err != nil {
+			errs = append(errs, field.Invalid(path.Child("conditionScope"), *condition.ConditionScope, err.Error()))
+		}
+	}
+
+	errs = append(errs, validateThresholds(condition.CriticalThreshold, condition.WarningThreshold, path)...)
+	errs = append(errs, validateDurationRange(condition.CriticalThreshold.DurationMinutes, minApmDurationMinutes, maxApmDurationMinutes, path.Child("alertThreshold", "durationMinutes"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateDurationRange(condition.WarningThreshold.DurationMinutes, minApmDurationMinutes, maxApmDurationMinutes, path.Child("warningThreshold", "durationMinutes"))...)
+	}
+
+	if condition.UserDefined != nil {
+		if condition.Metric != userDefinedMetric {
+			errs = append(errs, field.Invalid(path.Child("metric"), condition.Metric, "must be user_defined when userDefined is set"))
+		}
+		if condition.UserDefined.Metric == "" {
+			errs = append(errs, field.Required(path.Child("userDefined", "metric"), "the name of the custom metric is required"))
+		}
+	} else if condition.Metric == userDefinedMetric {
+		errs = append(errs, field.Required(path.Child("userDefined"), "must be set when the metric is user_defined"))
+	}
+
+	errs = append(errs, validateViolationCloseTimer(condition.ViolationCloseTimer, apmViolationCloseTimers, path.Child("violationCloseTimer"))...)
+	errs = append(errs, validateRunbookUrl(condition.RunbookUrl, path.Child("runbookUrl"))...)
+
+	return errs
+}
+
+func (condition NrqlCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	conditionType := condition.conditionType()
+	if condition.Query == "" {
+		errs = append(errs, field.Required(path.Child("query"), ""))
+	} else {
+		for _, problem := range nrql.Errors(nrql.Lint(condition.Query, condition.QueryRules())) {
+			errs = append(errs, field.Invalid(path.Child("query"), condition.Query, problem.String()))
+		}
+	}
+
+	if condition.Since < minSinceMinutes || condition.Since > maxSinceMinutes {
+		errs = append(errs, field.Invalid(path.Child("sinceMinutes"), condition.Since, "must be between 1 and 120"))
+	}
+
+	errs = append(errs, validateThresholds(condition.AlertThreshold, condition.WarningThreshold, path)...)
+	if conditionType != NrqlConditionTypeBaseline && condition.BaselineDirection != nil {
+		errs = append(errs, field.Forbidden(path.Child("baselineDirection"), "can only be set for baseline conditions"))
+	}
+	if conditionType != NrqlConditionTypeOutlier && condition.ExpectedGroups != nil {
+		errs = append(errs, field.Forbidden(path.Child("expectedGroups"), "can only be set for outlier conditions"))
+	}
+	if conditionType != NrqlConditionTypeOutlier && condition.IgnoreOverlap != nil {
+		errs = append(errs, field.Forbidden(path.Child("ignoreOverlap"), "can only be set for outlier conditions"))
+	}
+	switch conditionType {
+	case NrqlConditionTypeBaseline:
+		errs = append(errs, condition.validateBaseline(path)...)
+	case NrqlConditionTypeOutlier:
+		errs = append(errs, condition.validateOutlier(path)...)
+	}
+	errs = append(errs, validateDurationRange(condition.AlertThreshold.DurationMinutes, minNrqlDurationMinutes, maxNrqlDurationMinutes, path.Child("alertThreshold", "durationMinutes"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateDurationRange(condition.WarningThreshold.DurationMinutes, minNrqlDurationMinutes, maxNrqlDurationMinutes, path.Child("warningThreshold", "durationMinutes"))...)
+	}
+	if condition.Signal != nil {
+		errs = append(errs, condition.Signal.validate(path.Child("signal"))...)
+	}
+	if condition.Expiration != nil {
+		errs = append(errs, condition.Expiration.validate(path.Child("expiration"))...)
+	}
+
+	errs = append(errs, validateRunbookUrl(condition.RunbookUrl, path.Child("runbookUrl"))...)
+
+	return errs
+}
+
+// validateBaseline checks the thresholds of a baseline condition, whose values are standard deviations from the baseline
+func (condition NrqlCondition) validateBaseline(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.BaselineDirection == nil {
+		errs = append(errs, field.Required(path.Child("baselineDirection"), "must be set for baseline conditions"))
+	}
+	errs = append(errs, condition.validateSingleValueAbove(path)...)
+
+	errs = append(errs, validateStandardDeviations(condition.AlertThreshold, path.Child("alertThreshold"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateStandardDeviations(*condition.WarningThreshold, path.Child("warningThreshold"))...)
+	}
+
+	return errs
+}
+
+// validateOutlier checks the settings of an outlier condition. The FACET clause is checked by the query rules
+func (condition NrqlCondition) validateOutlier(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.ExpectedGroups == nil {
+		errs = append(errs, field.Required(path.Child("expectedGroups"), "must be set for outlier conditions"))
+	} else if *condition.ExpectedGroups < 1 {
+		errs = append(errs, field.Invalid(path.Child("expectedGroups"), *condition.ExpectedGroups, "must be at least 1"))
+	}
+
+	return append(errs, condition.validateSingleValueAbove(path)...)
+}
+
+// validateSingleValueAbove checks the constraints shared by baseline and outlier conditions
+func (condition NrqlCondition) validateSingleValueAbove(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.ValueFunction != "single_value" {
+		errs = append(errs, field.NotSupported(path.Child("valueFunction"), condition.ValueFunction, []string{"single_value"}))
+	}
+
+	errs = append(errs, validateAboveOperator(condition.AlertThreshold, path.Child("alertThreshold"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateAboveOperator(*condition.WarningThreshold, path.Child("warningThreshold"))...)
+	}
+
+	return errs
+}
+
+func (signal NrqlSignal) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	method := ""
+	if signal.AggregationMethod != nil {
+		method = *signal.AggregationMethod
+	}
+	if signal.AggregationDelay != nil && method != AggregationMethodEventFlow && method != AggregationMethodCadence {
+		errs = append(errs, field.Forbidden(path.Child("aggregationDelay"), "only allowed for the event_flow and cadence aggregation methods"))
+	}
+	if signal.AggregationTimer != nil && method != AggregationMethodEventTimer {
+		errs = append(errs, field.Forbidden(path.Child("aggregationTimer"), "only allowed for the event_timer aggregation method"))
+	}
+	if signal.EvaluationOffset != nil && signal.AggregationMethod != nil {
+		errs = append(errs, field.Forbidden(path.Child("evaluationOffset"), "cannot be combined with aggregationMethod"))
+	}
+
+	isStatic := signal.FillOption != nil && *signal.FillOption == FillOptionStatic
+	if isStatic && signal.FillValue == nil {
+		errs = append(errs, field.Required(path.Child("fillValue"), "required when fillOption is static"))
+	}
+	if !isStatic && signal.FillValue != nil {
+		errs = append(errs, field.Forbidden(path.Child("fillValue"), "only allowed when fillOption is static"))
+	}
+	if signal.FillValue != nil {
+		if _, err := strconv.ParseFloat(*signal.FillValue, 64); err != nil {
+			errs = append(errs, field.Invalid(path.Child("fillValue"), *signal.FillValue, "must be a number"))
+		}
+	}
+
+	return errs
+}
+
+func (expiration NrqlExpiration) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if expiration.ExpirationDuration != nil {
+		return nil
+	}
+	if expiration.OpenViolationOnExpiration != nil && *expiration.OpenViolationOnExpiration {
+		errs = append(errs, field.Required(path.Child("expirationDuration"), "required when openViolationOnExpiration is set"))
+	}
+	if expiration.CloseViolationsOnExpiration != nil && *expiration.CloseViolationsOnExpiration {
+		errs = append(errs, field.Required(path.Child("expirationDuration"), "required when closeViolationsOnExpiration is set"))
+	}
+
+	return errs
+}
+
+// QueryRules returns the lint rules for the query of the condition
+func (condition NrqlCondition) QueryRules() []nrql.Rule {
+	if condition.conditionType() == NrqlConditionTypeOutlier {
+		return nrql.OutlierConditionRules()
+	}
+
+	return nrql.AlertConditionRules()
+}
+
+func (condition NrqlCondition) conditionType() string {
+	if condition.Type == nil {
+		return DefaultNrqlConditionType
+	}
+
+	return *condition.Type
+}
+
+func (condition InfraCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	conditionType := condition.conditionType()
+	errs = append(errs, validateDurationRange(condition.CriticalThreshold.DurationMinutes, minInfraDurationMinutes, maxInfraDurationMinutes, path.Child("alertThreshold", "durationMinutes"))...)
+	if condition.WarningThreshold != nil {
+		warningPath := path.Child("warningThreshold")
+		errs = append(errs, validateDurationRange(condition.WarningThreshold.DurationMinutes, minInfraDurationMinutes, maxInfraDurationMinutes, warningPath.Child("durationMinutes"))...)
+		if condition.Comparison != "" {
+			errs = append(errs, validateWarningValue(
+				condition.Comparison,
+				float64(condition.CriticalThreshold.Value),
+				float64(condition.WarningThreshold.Value),
+				warningPath.Child("value"),
+			)...)
+		}
+	}
+
+	switch conditionType {
+	case InfraConditionTypeMetric:
+		errs = append(errs, condition.validateMetric(path)...)
+	case InfraConditionTypeProcessRunning:
+		errs = append(errs, condition.validateProcessRunning(path)...)
+	case InfraConditionTypeHostNotReporting:
+		errs = append(errs, condition.validateHostNotReporting(path)...)
+	}
+
+	if conditionType != InfraConditionTypeProcessRunning && condition.ProcessWhereClause != "" {
+		errs = append(errs, field.Forbidden(path.Child("processWhereClause"), "only allowed for infra_process_running conditions"))
+	}
+
+	errs = append(errs, validateRunbookUrl(condition.RunbookUrl, path.Child("runbookUrl"))...)
+
+	return errs
+}
+
+func (condition InfraCondition) conditionType() string {
+	if condition.Type == nil {
+		return DefaultInfraConditionType
+	}
+
+	return *condition.Type
+}
+
+func (condition InfraCondition) validateMetric(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.Comparison == "" {
+		errs = append(errs, field.Required(path.Child("comparison"), ""))
+	}
+	if condition.SelectValue == "" {
+		errs = append(errs, field.Required(path.Child("selectValue"), ""))
+	}
+
+	errs = append(errs, validateInfraTimeFunction(condition.CriticalThreshold, path.Child("alertThreshold"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateInfraTimeFunction(*condition.WarningThreshold, path.Child("warningThreshold"))...)
+	}
+
+	return errs
+}
+
+func (condition InfraCondition) validateProcessRunning(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.Comparison == "" {
+		errs = append(errs, field.Required(path.Child("comparison"), ""))
+	}
+	if condition.ProcessWhereClause == "" {
+		errs = append(errs, field.Required(path.Child("processWhereClause"), "selects the processes to count"))
+	}
+
+	errs = append(errs, condition.forbidMetricFields(path)...)
+
+	return errs
+}
+
+func (condition InfraCondition) validateHostNotReporting(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.Comparison != "" {
+		errs = append(errs, field.Forbidden(path.Child("comparison"), "not allowed for infra_host_not_reporting conditions"))
+	}
+	if condition.CriticalThreshold.Value != 0 {
+		errs = append(errs, field.Forbidden(path.Child("alertThreshold", "value"), "not allowed for infra_host_not_reporting conditions"))
+	}
+	if condition.WarningThreshold != nil {
+		errs = append(errs, field.Forbidden(path.Child("warningThreshold"), "not allowed for infra_host_not_reporting conditions"))
+	}
+
+	errs = append(errs, condition.forbidMetricFields(path)...)
+
+	return errs
+}
+
+// forbidMetricFields rejects the fields which only apply to infra_metric conditions
+func (condition InfraCondition) forbidMetricFields(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	detail := "only allowed for infra_metric conditions"
+	if condition.SelectValue != "" {
+		errs = append(errs, field.Forbidden(path.Child("selectValue"), detail))
+	}
+	if condition.EventType != "" {
+		errs = append(errs, field.Forbidden(path.Child("eventType"), detail))
+	}
+	if condition.IntegrationProvider != "" {
+		errs = append(errs, field.Forbidden(path.Child("integrationProvider"), detail))
+	}
+	if condition.CriticalThreshold.TimeFunction != "" {
+		errs = append(errs, field.Forbidden(path.Child("alertThreshold", "timeFunction"), detail))
+	}
+	if condition.WarningThreshold != nil && condition.WarningThreshold.TimeFunction != "" {
+		errs = append(errs, field.Forbidden(path.Child("warningThreshold", "timeFunction"), detail))
+	}
+
+	return errs
+}
+
+func validateInfraTimeFunction(threshold InfraThreshold, path *field.Path) field.ErrorList {
+	if threshold.TimeFunction == "" {
+		return field.ErrorList{field.Required(path.Child("timeFunction"), "")}
+	}
+
+	return nil
+}
+
+func (condition SyntheticsCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	monitorsPath := path.Child("monitors")
+	if len(condition.Monitors) == 0 {
+		errs = append(errs, field.Required(monitorsPath, "at least one monitor is required"))
+	}
+
+	if condition.LocationThresholds == nil {
+		if len(condition.Monitors) > 1 {
+			errs = append(errs, field.Invalid(monitorsPath, condition.Monitors, "must list exactly one monitor unless locationThresholds is set"))
+		}
+	} else {
+		thresholdsPath := path.Child("locationThresholds")
+		critical := condition.LocationThresholds.Critical
+		if critical < 1 {
+			errs = append(errs, field.Invalid(thresholdsPath.Child("critical"), critical, "must be at least 1"))
+		}
+		if warning := condition.LocationThresholds.Warning; warning != nil && (*warning < 1 || *warning >= critical) {
+			errs = append(errs, field.Invalid(thresholdsPath.Child("warning"), *warning, "must be at least 1 and less than the critical threshold"))
+		}
+	}
+
+	errs = append(errs, validateRunbookUrl(condition.RunbookUrl, path.Child("runbookUrl"))...)
+
+	return errs
+}
+
+func (condition ExternalServiceCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(condition.Entities) == 0 {
+		errs = append(errs, field.Required(path.Child("entities"), "at least one application is required"))
+	}
+
+	urlPath := path.Child("externalServiceUrl")
+	if condition.ExternalServiceUrl == "" {
+		errs = append(errs, field.Required(urlPath, ""))
+	} else if strings.ContainsAny(condition.ExternalServiceUrl, "/:") {
+		errs = append(errs, field.Invalid(urlPath, condition.ExternalServiceUrl, "must be the host name of the external service, without scheme, port or path"))
+	}
+
+	errs = append(errs, validateThresholds(condition.CriticalThreshold, condition.WarningThreshold, path)...)
+	errs = append(errs, validateDurationRange(condition.CriticalThreshold.DurationMinutes, minApmDurationMinutes, maxApmDurationMinutes, path.Child("alertThreshold", "durationMinutes"))...)
+	if condition.WarningThreshold != nil {
+		errs = append(errs, validateDurationRange(condition.WarningThreshold.DurationMinutes, minApmDurationMinutes, maxApmDurationMinutes, path.Child("warningThreshold", "durationMinutes"))...)
+	}
+
+	errs = append(errs, validateRunbookUrl(condition.RunbookUrl, path.Child("runbookUrl"))...)
+
+	return errs
+}
+
+// validateUniqueName rejects conditions sharing a name, since conditions are matched with New Relic by their name
+func validateUniqueName(names map[string]bool, name string, path *field.Path) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if names[name] {
+		return field.ErrorList{field.Duplicate(path, name)}
+	}
+
+	names[name] = true
+	return nil
+}
+
+func validateThresholds(critical Threshold, warning *Threshold, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	criticalValue, err := strconv.ParseFloat(critical.Value, 64)
+	if err != nil {
+		errs = append(errs, field.Invalid(path.Child("alertThreshold", "value"), critical.Value, "must be a number"))
+	}
+
+	if warning == nil {
+		return errs
+	}
+
+	warningPath := path.Child("warningThreshold", "value")
+	warningValue, warningErr := strconv.ParseFloat(warning.Value, 64)
+	if warningErr != nil {
+		return append(errs, field.Invalid(warningPath, warning.Value, "must be a number"))
+	}
+
+	if err == nil && warning.Operator == critical.Operator {
+		errs = append(errs, validateWarningValue(critical.Operator, criticalValue, warningValue, warningPath)...)
+	}
+
+	return errs
+}
+
+// validateWarningValue rejects warning thresholds which would only be breached after the critical threshold
+func validateWarningValue(operator string, critical float64, warning float64, path *field.Path) field.ErrorList {
+	switch operator {
+	case "above":
+		if warning > critical {
+			return field.ErrorList{field.Invalid(path, warning, "must not be greater than the alertThreshold value when the operator is above")}
+		}
+	case "below", "bellow":
+		if warning < critical {
+			return field.ErrorList{field.Invalid(path, warning, "must not be less than the alertThreshold value when the operator is below")}
+		}
+	}
+
+	return nil
+}
+
+func validateAboveOperator(threshold Threshold, path *field.Path) field.ErrorList {
+	if threshold.Operator != "above" {
+		return field.ErrorList{field.NotSupported(path.Child("operator"), threshold.Operator, []string{"above"})}
+	}
+
+	return nil
+}
+
+func validateStandardDeviations(threshold Threshold, path *field.Path) field.ErrorList {
+	value, err := strconv.ParseFloat(threshold.Value, 64)
+	if err == nil && (value < minStandardDeviations || value > maxStandardDeviations) {
+		return field.ErrorList{field.Invalid(path.Child("value"), threshold.Value, "must be between 1 and 1000 standard deviations")}
+	}
+
+	return nil
+}
+
+func validateDurationRange(duration int, min int, max int, path *field.Path) field.ErrorList {
+	if duration < min || duration > max {
+		return field.ErrorList{field.Invalid(path, duration, "must be between "+strconv.Itoa(min)+" and "+strconv.Itoa(max))}
+	}
+
+	return nil
+}
+
+func validateViolationCloseTimer(timer int, allowed []int, path *field.Path) field.ErrorList {
+	if timer != 0 && !containsInt(allowed, timer) {
+		return field.ErrorList{field.NotSupported(path, timer, toStrings(allowed))}
+	}
+
+	return nil
+}
+
+func validateRunbookUrl(runbookUrl string, path *field.Path) field.ErrorList {
+	if runbookUrl == "" {
+		return nil
+	}
+
+	parsed, err := url.ParseRequestURI(runbookUrl)
+	if err != nil || parsed.Host == "" {
+		return field.ErrorList{field.Invalid(path, runbookUrl, "must be an absolute URL")}
+	}
+
+	return nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toStrings(values []int) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strconv.Itoa(v)
+	}
+
+	return result
+}
@@ -0,0 +1,824 @@
+package v1alpha1_test
+
+import (
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"testing"
+)
+
+func TestValidate_ValidSpec(t *testing.T) {
+	spec := newValidSpec()
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_DuplicateConditionNames(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions = append(spec.NrqlConditions, spec.NrqlConditions[0])
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeDuplicate, "spec.nrqlConditions[1].name")
+}
+
+func TestValidate_SameNameInDifferentConditionTypes(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Name = spec.ApmConditions[0].Name
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_WarningLooserThanCritical(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].WarningThreshold.Value = "90"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].warningThreshold.value")
+}
+
+func TestValidate_WarningLooserThanCriticalBelow(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].AlertThreshold.Operator = "below"
+	spec.NrqlConditions[0].WarningThreshold.Operator = "below"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].warningThreshold.value")
+}
+
+func TestValidate_InfraWarningLooserThanCritical(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions[0].WarningThreshold.Value = 95
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.infraConditions[0].warningThreshold.value")
+}
+
+func TestValidate_NonNumericThreshold(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].CriticalThreshold.Value = "high"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].alertThreshold.value")
+}
+
+func TestValidate_SinceMinutesOutOfRange(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Since = 121
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].sinceMinutes")
+}
+
+func TestValidate_DurationOutOfRange(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions[0].CriticalThreshold.DurationMinutes = 61
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.infraConditions[0].alertThreshold.durationMinutes")
+}
+
+func TestValidate_UserDefinedWithoutUserDefinedMetric(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].UserDefined = &v1alpha1.UserDefined{
+		Metric:        "Custom/Metric",
+		ValueFunction: "average",
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].metric")
+}
+
+func TestValidate_UserDefinedMetricWithoutUserDefined(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].Metric = "user_defined"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.apmConditions[0].userDefined")
+}
+
+func TestValidate_MetricNotSupportedByType(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].Type = "apm_jvm_metric"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].metric")
+}
+
+func TestValidate_ConditionScopeNotSupportedByType(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].Type = "apm_kt_metric"
+	spec.ApmConditions[0].ConditionScope = stringPtr("instance")
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].conditionScope")
+}
+
+func TestValidate_UnsupportedViolationCloseTimer(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].ViolationCloseTimer = 3
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeNotSupported, "spec.apmConditions[0].violationCloseTimer")
+}
+
+func TestValidate_RelativeRunbookUrl(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].RunbookUrl = "runbooks/errors"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].runbookUrl")
+}
+
+func TestValidate_InvalidNrqlQuery(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Query = "SELECT count(*) FROM TransactionError SINCE 5 minutes ago"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].query")
+}
+
+func TestValidate_NrqlQueryAcceptedByNewRelic(t *testing.T) {
+	queries := []string{
+		"SELECT apdex(duration, t: 0.5) FROM Transaction",
+		"SELECT count(*) FROM Log WHERE message RLIKE r'.*timeout \\d+.*'",
+		"SELECT count(*) FROM Log WHERE capture(message, r'status=(?P<status>\\d+)') = '500'",
+		"SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample WHERE cpuPercent > 90)",
+	}
+
+	for _, query := range queries {
+		spec := newValidSpec()
+		spec.NrqlConditions[0].Query = query
+
+		errs := spec.Validate(field.NewPath("spec"))
+		if len(errs) != 0 {
+			t.Errorf("Expected %q to be valid, got %v", query, errs)
+		}
+	}
+}
+
+func TestValidate_ApmConditionWithEntitySelectors(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].Entities = nil
+	spec.ApmConditions[0].EntitySelectors = []common.EntitySelector{
+		{Name: "my-app"},
+		{NamePattern: "payments-.*"},
+		{Label: "Team:Payments"},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_ApmConditionWithoutEntities(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].Entities = nil
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.apmConditions[0].entities")
+}
+
+func TestValidate_EntitySelectorWithSeveralFields(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].EntitySelectors = []common.EntitySelector{
+		{Name: "my-app", Label: "Team:Payments"},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].entitySelectors[0]")
+}
+
+func TestValidate_EntitySelectorWithInvalidLabel(t *testing.T) {
+	spec := newValidSpec()
+	spec.ApmConditions[0].EntitySelectors = []common.EntitySelector{
+		{Label: "Payments"},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.apmConditions[0].entitySelectors[0].label")
+}
+
+func TestValidate_BaselineCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newBaselineCondition()
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_BaselineWithoutDirection(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newBaselineCondition()
+	spec.NrqlConditions[0].BaselineDirection = nil
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.nrqlConditions[0].baselineDirection")
+}
+
+func TestValidate_BaselineDirectionOnStaticCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].BaselineDirection = stringPtr("upper_only")
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].baselineDirection")
+}
+
+func TestValidate_BaselineOperatorBelow(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newBaselineCondition()
+	spec.NrqlConditions[0].AlertThreshold.Operator = "below"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeNotSupported, "spec.nrqlConditions[0].alertThreshold.operator")
+}
+
+func TestValidate_BaselineStandardDeviationsOutOfRange(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newBaselineCondition()
+	spec.NrqlConditions[0].WarningThreshold.Value = "0.5"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].warningThreshold.value")
+}
+
+func TestValidate_BaselineSumValueFunction(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newBaselineCondition()
+	spec.NrqlConditions[0].ValueFunction = "sum"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeNotSupported, "spec.nrqlConditions[0].valueFunction")
+}
+
+func TestValidate_OutlierCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newOutlierCondition()
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_OutlierWithoutFacet(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newOutlierCondition()
+	spec.NrqlConditions[0].Query = "SELECT average(cpuUsedCores) FROM K8sContainerSample"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.nrqlConditions[0].query")
+}
+
+func TestValidate_OutlierWithoutExpectedGroups(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newOutlierCondition()
+	spec.NrqlConditions[0].ExpectedGroups = nil
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.nrqlConditions[0].expectedGroups")
+}
+
+func TestValidate_OutlierOperatorBelow(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0] = newOutlierCondition()
+	spec.NrqlConditions[0].AlertThreshold.Operator = "below"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeNotSupported, "spec.nrqlConditions[0].alertThreshold.operator")
+}
+
+func TestValidate_ExpectedGroupsOnStaticCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].ExpectedGroups = intPtr(2)
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].expectedGroups")
+}
+
+func TestValidate_NrqlSignal(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		AggregationWindow: intPtr(120),
+		AggregationMethod: stringPtr("event_flow"),
+		AggregationDelay:  intPtr(60),
+		FillOption:        stringPtr("static"),
+		FillValue:         stringPtr("0"),
+	}
+	spec.NrqlConditions[0].Expiration = &v1alpha1.NrqlExpiration{
+		ExpirationDuration:        intPtr(600),
+		OpenViolationOnExpiration: boolPtr(true),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_NrqlAggregationTimerWithEventFlow(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		AggregationMethod: stringPtr("event_flow"),
+		AggregationTimer:  intPtr(60),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].signal.aggregationTimer")
+}
+
+func TestValidate_NrqlAggregationDelayWithEventTimer(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		AggregationMethod: stringPtr("event_timer"),
+		AggregationDelay:  intPtr(60),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].signal.aggregationDelay")
+}
+
+func TestValidate_NrqlEvaluationOffsetWithAggregationMethod(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		AggregationMethod: stringPtr("cadence"),
+		EvaluationOffset:  intPtr(3),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].signal.evaluationOffset")
+}
+
+func TestValidate_NrqlStaticFillWithoutValue(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		FillOption: stringPtr("static"),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.nrqlConditions[0].signal.fillValue")
+}
+
+func TestValidate_NrqlFillValueWithoutStaticFill(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Signal = &v1alpha1.NrqlSignal{
+		FillOption: stringPtr("last_value"),
+		FillValue:  stringPtr("0"),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.nrqlConditions[0].signal.fillValue")
+}
+
+func TestValidate_NrqlExpirationWithoutDuration(t *testing.T) {
+	spec := newValidSpec()
+	spec.NrqlConditions[0].Expiration = &v1alpha1.NrqlExpiration{
+		CloseViolationsOnExpiration: boolPtr(true),
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.nrqlConditions[0].expiration.expirationDuration")
+}
+
+func TestValidate_InfraMetricWithoutSelectValue(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions[0].SelectValue = ""
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.infraConditions[0].selectValue")
+}
+
+func TestValidate_InfraProcessRunningCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions = []v1alpha1.InfraCondition{newProcessRunningCondition()}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_InfraProcessRunningWithoutProcessWhereClause(t *testing.T) {
+	spec := newValidSpec()
+	condition := newProcessRunningCondition()
+	condition.ProcessWhereClause = ""
+	spec.InfraConditions = []v1alpha1.InfraCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.infraConditions[0].processWhereClause")
+}
+
+func TestValidate_InfraProcessRunningWithSelectValue(t *testing.T) {
+	spec := newValidSpec()
+	condition := newProcessRunningCondition()
+	condition.SelectValue = "cpuPercent"
+	spec.InfraConditions = []v1alpha1.InfraCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.infraConditions[0].selectValue")
+}
+
+func TestValidate_ProcessWhereClauseOnMetricCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions[0].ProcessWhereClause = "commandName = 'nginx'"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.infraConditions[0].processWhereClause")
+}
+
+func TestValidate_InfraHostNotReportingCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.InfraConditions = []v1alpha1.InfraCondition{newHostNotReportingCondition()}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_InfraHostNotReportingWithComparison(t *testing.T) {
+	spec := newValidSpec()
+	condition := newHostNotReportingCondition()
+	condition.Comparison = "above"
+	spec.InfraConditions = []v1alpha1.InfraCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.infraConditions[0].comparison")
+}
+
+func TestValidate_InfraHostNotReportingWithWarning(t *testing.T) {
+	spec := newValidSpec()
+	condition := newHostNotReportingCondition()
+	condition.WarningThreshold = &v1alpha1.InfraThreshold{DurationMinutes: 5}
+	spec.InfraConditions = []v1alpha1.InfraCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeForbidden, "spec.infraConditions[0].warningThreshold")
+}
+
+func TestValidate_SyntheticsCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{Name: "homepage-down", Monitors: []string{"homepage"}},
+		{
+			Name:               "checkout-down",
+			Monitors:           []string{"checkout", "checkout-eu"},
+			LocationThresholds: &v1alpha1.LocationThresholds{Critical: 3, Warning: intPtr(1)},
+		},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_SyntheticsWithoutMonitors(t *testing.T) {
+	spec := newValidSpec()
+	spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{{Name: "homepage-down"}}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.syntheticsConditions[0].monitors")
+}
+
+func TestValidate_SyntheticsMultipleMonitorsWithoutLocationThresholds(t *testing.T) {
+	spec := newValidSpec()
+	spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{Name: "homepage-down", Monitors: []string{"homepage", "homepage-eu"}},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.syntheticsConditions[0].monitors")
+}
+
+func TestValidate_SyntheticsWarningNotBelowCritical(t *testing.T) {
+	spec := newValidSpec()
+	spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{
+			Name:               "checkout-down",
+			Monitors:           []string{"checkout"},
+			LocationThresholds: &v1alpha1.LocationThresholds{Critical: 2, Warning: intPtr(2)},
+		},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.syntheticsConditions[0].locationThresholds.warning")
+}
+
+func TestValidate_DuplicateSyntheticsConditionNames(t *testing.T) {
+	spec := newValidSpec()
+	spec.SyntheticsConditions = []v1alpha1.SyntheticsCondition{
+		{Name: "homepage-down", Monitors: []string{"homepage"}},
+		{Name: "homepage-down", Monitors: []string{"homepage-eu"}},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeDuplicate, "spec.syntheticsConditions[1].name")
+}
+
+func TestValidate_ExternalServiceCondition(t *testing.T) {
+	spec := newValidSpec()
+	spec.ExternalServiceConditions = []v1alpha1.ExternalServiceCondition{newExternalServiceCondition()}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_ExternalServiceUrlWithScheme(t *testing.T) {
+	spec := newValidSpec()
+	condition := newExternalServiceCondition()
+	condition.ExternalServiceUrl = "https://api.stripe.com/v1"
+	spec.ExternalServiceConditions = []v1alpha1.ExternalServiceCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.externalServiceConditions[0].externalServiceUrl")
+}
+
+func TestValidate_ExternalServiceWithoutEntities(t *testing.T) {
+	spec := newValidSpec()
+	condition := newExternalServiceCondition()
+	condition.Entities = nil
+	spec.ExternalServiceConditions = []v1alpha1.ExternalServiceCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.externalServiceConditions[0].entities")
+}
+
+func TestValidate_ExternalServiceDurationOutOfRange(t *testing.T) {
+	spec := newValidSpec()
+	condition := newExternalServiceCondition()
+	condition.CriticalThreshold.DurationMinutes = 1
+	spec.ExternalServiceConditions = []v1alpha1.ExternalServiceCondition{condition}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.externalServiceConditions[0].alertThreshold.durationMinutes")
+}
+
+func TestValidate_TemplateWithoutName(t *testing.T) {
+	spec := newValidSpec()
+	spec.Templates = []v1alpha1.AlertConditionTemplateReference{
+		{Name: "service-defaults"},
+		{Parameters: map[string]string{"appName": "checkout"}},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.templates[1].name")
+}
+
+func TestValidateCreate_ReturnsInvalidError(t *testing.T) {
+	policy := &v1alpha1.AlertPolicy{Spec: newValidSpec()}
+	policy.Name = "my-policy"
+	policy.Spec.Name = ""
+
+	err := policy.ValidateCreate()
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestValidateUpdate_ReturnsInvalidErrorForChangedSpec(t *testing.T) {
+	old := &v1alpha1.AlertPolicy{Spec: newValidSpec()}
+	policy := old.DeepCopy()
+	policy.Spec.Name = ""
+
+	err := policy.ValidateUpdate(old)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestValidateUpdate_IgnoresUnchangedSpec(t *testing.T) {
+	old := &v1alpha1.AlertPolicy{Spec: newValidSpec()}
+	old.Spec.NrqlConditions[0].Since = 0
+	policy := old.DeepCopy()
+	policy.Finalizers = []string{"newrelic"}
+
+	if err := policy.ValidateUpdate(old); err != nil {
+		t.Errorf("Expected a finalizer update of an invalid policy to be allowed, got %v", err)
+	}
+}
+
+func TestValidateUpdate_IgnoresDeletedPolicy(t *testing.T) {
+	old := &v1alpha1.AlertPolicy{Spec: newValidSpec()}
+	old.Spec.NrqlConditions[0].Since = 0
+	deletionTimestamp := metav1.Now()
+	old.DeletionTimestamp = &deletionTimestamp
+	old.Finalizers = []string{"newrelic"}
+	policy := old.DeepCopy()
+	policy.Finalizers = nil
+	policy.Spec.Name = ""
+
+	if err := policy.ValidateUpdate(old); err != nil {
+		t.Errorf("Expected the finalizer of a deleted policy to be removable, got %v", err)
+	}
+}
+
+func TestValidate_Examples(t *testing.T) {
+	files, err := filepath.Glob("../../../../hack/examples/alertpolicy_*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("Expected example policies")
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var policy v1alpha1.AlertPolicy
+		if err := yaml.Unmarshal(content, &policy); err != nil {
+			t.Fatalf("Error parsing %s: %v", file, err)
+		}
+
+		if err := policy.ValidateCreate(); err != nil {
+			t.Errorf("Expected %s to be valid, got %v", file, err)
+		}
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func newBaselineCondition() v1alpha1.NrqlCondition {
+	return v1alpha1.NrqlCondition{
+		Name:              "throughput",
+		Type:              stringPtr("baseline"),
+		BaselineDirection: stringPtr("upper_and_lower"),
+		Query:             "SELECT count(*) FROM Transaction",
+		Since:             3,
+		ValueFunction:     "single_value",
+		AlertThreshold: v1alpha1.Threshold{
+			TimeFunction:    "all",
+			Operator:        "above",
+			Value:           "3",
+			DurationMinutes: 5,
+		},
+		WarningThreshold: &v1alpha1.Threshold{
+			TimeFunction:    "all",
+			Operator:        "above",
+			Value:           "2",
+			DurationMinutes: 5,
+		},
+	}
+}
+
+func newOutlierCondition() v1alpha1.NrqlCondition {
+	return v1alpha1.NrqlCondition{
+		Name:           "pod-cpu",
+		Type:           stringPtr("outlier"),
+		ExpectedGroups: intPtr(1),
+		Query:          "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET podName",
+		Since:          5,
+		ValueFunction:  "single_value",
+		AlertThreshold: v1alpha1.Threshold{
+			TimeFunction:    "all",
+			Operator:        "above",
+			Value:           "3",
+			DurationMinutes: 5,
+		},
+	}
+}
+
+func newProcessRunningCondition() v1alpha1.InfraCondition {
+	return v1alpha1.InfraCondition{
+		Name:       "nginx-down",
+		Type:       stringPtr("infra_process_running"),
+		Comparison: "equal",
+		CriticalThreshold: v1alpha1.InfraThreshold{
+			Value:           0,
+			DurationMinutes: 5,
+		},
+		WhereClause:        "hostname LIKE 'web-%'",
+		ProcessWhereClause: "commandName = 'nginx'",
+	}
+}
+
+func newHostNotReportingCondition() v1alpha1.InfraCondition {
+	return v1alpha1.InfraCondition{
+		Name: "host-down",
+		Type: stringPtr("infra_host_not_reporting"),
+		CriticalThreshold: v1alpha1.InfraThreshold{
+			DurationMinutes: 10,
+		},
+		WhereClause: "hostname LIKE 'web-%'",
+	}
+}
+
+func newExternalServiceCondition() v1alpha1.ExternalServiceCondition {
+	return v1alpha1.ExternalServiceCondition{
+		Name:               "payments-slow",
+		Entities:           []string{"checkout"},
+		ExternalServiceUrl: "api.stripe.com",
+		Metric:             "response_time_average",
+		CriticalThreshold: v1alpha1.Threshold{
+			TimeFunction:    "all",
+			Operator:        "above",
+			Value:           "2",
+			DurationMinutes: 5,
+		},
+	}
+}
+
+func assertError(t *testing.T, errs field.ErrorList, errorType field.ErrorType, path string) {
+	t.Helper()
+	for _, err := range errs {
+		if err.Type == errorType && err.Field == path {
+			return
+		}
+	}
+
+	t.Errorf("Expected error of type %s for %s, got %v", errorType, path, errs)
+}
+
+func newValidSpec() v1alpha1.AlertPolicySpec {
+	return v1alpha1.AlertPolicySpec{
+		Name:               "my-policy",
+		IncidentPreference: "per_policy",
+		ApmConditions: []v1alpha1.ApmCondition{
+			{
+				Name:     "error-rate",
+				Type:     "apm_app_metric",
+				Entities: []string{"my-app"},
+				Metric:   "error_percentage",
+				CriticalThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "80",
+					DurationMinutes: 5,
+				},
+				WarningThreshold: &v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "50",
+					DurationMinutes: 5,
+				},
+				ViolationCloseTimer: 24,
+				RunbookUrl:          "https://example.com/runbook",
+			},
+		},
+		NrqlConditions: []v1alpha1.NrqlCondition{
+			{
+				Name:          "errors",
+				Query:         "SELECT count(*) FROM TransactionError",
+				Since:         5,
+				ValueFunction: "single_value",
+				AlertThreshold: v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "100",
+					DurationMinutes: 5,
+				},
+				WarningThreshold: &v1alpha1.Threshold{
+					TimeFunction:    "all",
+					Operator:        "above",
+					Value:           "50",
+					DurationMinutes: 5,
+				},
+			},
+		},
+		InfraConditions: []v1alpha1.InfraCondition{
+			{
+				Name:       "high-cpu",
+				Comparison: "above",
+				CriticalThreshold: v1alpha1.InfraThreshold{
+					TimeFunction:    "all",
+					Value:           90,
+					DurationMinutes: 5,
+				},
+				WarningThreshold: &v1alpha1.InfraThreshold{
+					TimeFunction:    "all",
+					Value:           80,
+					DurationMinutes: 5,
+				},
+				EventType:   "SystemSample",
+				SelectValue: "cpuPercent",
+			},
+		},
+	}
+}
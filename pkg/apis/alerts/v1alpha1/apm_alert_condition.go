@@ -1,5 +1,9 @@
 package v1alpha1
 
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+)
+
 type ApmCondition struct {
 	// The name of the alert condition that will be created in New Relic
 	Name string `json:"name"`
@@ -18,8 +22,24 @@ type ApmCondition struct {
 	// +kubebuilder:validation:Enum=instance;application
 	// +optional
 	ConditionScope *string `json:"conditionScope,omitempty"`
-	// A list of application names from APM to monitor
-	Entities []string `json:"entities"`
+	// A list of application names from APM to monitor. \
+	// Either entities or entitySelectors, or both, should be set
+	// +optional
+	Entities []string `json:"entities,omitempty"`
+	// Selects the applications to monitor by name, by a pattern of their name or by label. \
+	// The selected applications are added to the entities and looked up again on every resync
+	// +optional
+	EntitySelectors []v1alpha1.EntitySelector `json:"entitySelectors,omitempty"`
+	// What to do when an application in entities does not exist in New Relic. \
+	// Can be one of: \
+	// - `fail` - the policy is not saved until the application exists \
+	// - `skip` - the condition is created for the remaining applications, or left unchanged when none of them exist \
+	// - `wait` - the condition is left unchanged until all applications exist \
+	// Missing applications are listed in the status of the policy, and are looked up again periodically. \
+	// Defaults to `fail`
+	// +kubebuilder:validation:Enum=fail;skip;wait
+	// +optional
+	MissingEntities *string `json:"missingEntities,omitempty"`
 	// +optional
 	ViolationCloseTimer int `json:"violationCloseTimer,omitempty"`
 	// +optional
@@ -0,0 +1,12 @@
+package v1alpha1
+
+// v1alpha1 is the storage version of the alerts group. All other versions
+// are converted to and from these types by the conversion webhook
+
+func (*AlertPolicy) Hub() {}
+
+func (*SlackNotificationChannel) Hub() {}
+
+func (*EmailNotificationChannel) Hub() {}
+
+func (*OpsgenieNotificationChannel) Hub() {}
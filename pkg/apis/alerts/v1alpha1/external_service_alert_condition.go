@@ -0,0 +1,33 @@
+package v1alpha1
+
+type ExternalServiceCondition struct {
+	// The name of the external service condition that will be created in New Relic
+	Name string `json:"name"`
+	// +optional
+	// +default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// A list of application names from APM whose calls to the external service are monitored
+	Entities []string `json:"entities"`
+	// What to do when an application in entities does not exist in New Relic. \
+	// Behaves like the missingEntities field of APM conditions. Defaults to `fail`
+	// +kubebuilder:validation:Enum=fail;skip;wait
+	// +optional
+	MissingEntities *string `json:"missingEntities,omitempty"`
+	// The host name of the external service as shown in APM, e.g. `api.stripe.com`
+	ExternalServiceUrl string `json:"externalServiceUrl"`
+	// The metric of the calls to the external service to monitor. Should be one of: \
+	// - `response_time_average` \
+	// - `response_time_minimum` \
+	// - `response_time_maximum` \
+	// - `throughput` \
+	// Please refer to the [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/rest-api-calls-new-relic-alerts#external-services-conditions) for more details
+	// +kubebuilder:validation:Enum=response_time_average;response_time_minimum;response_time_maximum;throughput
+	Metric string `json:"metric"`
+	// +optional
+	RunbookUrl string `json:"runbookUrl,omitempty"`
+	// Once the alertThreshold is breached, a critical incident will be generated
+	CriticalThreshold Threshold `json:"alertThreshold"`
+	// Once the warningThreshold is breached, a warning will be generated
+	// +optional
+	WarningThreshold *Threshold `json:"warningThreshold,omitempty"`
+}
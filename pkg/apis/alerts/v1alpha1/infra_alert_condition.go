@@ -3,12 +3,21 @@ package v1alpha1
 type InfraCondition struct {
 	// The name of the infra condition that will be created in New Relic
 	Name string `json:"name"`
-	// Available options are: \
+	// The type of the condition. Available options are: \
+	// - `infra_metric` - compares the selectValue of an event type or integration provider with the thresholds \
+	// - `infra_process_running` - compares the number of processes matching processWhereClause with the thresholds \
+	// - `infra_host_not_reporting` - opens a violation when a host stops reporting for the durationMinutes of the alertThreshold \
+	// Defaults to `infra_metric`
+	// +kubebuilder:validation:Enum=infra_metric;infra_process_running;infra_host_not_reporting
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// Required for `infra_metric` and `infra_process_running` conditions. Available options are: \
 	// - `above` \
 	// - `below` \
 	// - `equal` \
 	// +kubebuilder:validation:Enum=equal;above;bellow
-	Comparison string `json:"comparison"`
+	// +optional
+	Comparison string `json:"comparison,omitempty"`
 	// Once the alertThreshold is breached, a critical incident will be generated
 	CriticalThreshold InfraThreshold `json:"alertThreshold"`
 	// Once the warningThreshold is breached, a warning will be generated
@@ -24,17 +33,25 @@ type InfraCondition struct {
 	// When setting up alerts on integrations, specify the corresponding integration provider. \
 	// Examples can include SqsQueue, Kubernetes, RdsDbInstance etc. \
 	// For more information, please refer to the `integration_provider` field in the official [New Relic documentation](https://docs.newrelic.com/docs/infrastructure/new-relic-infrastructure/infrastructure-alert-conditions/rest-api-calls-new-relic-infrastructure-alerts#definitions)
-	IntegrationProvider string `json:"integrationProvider"`
+	// +optional
+	IntegrationProvider string `json:"integrationProvider,omitempty"`
 	// +optional
 	RunbookUrl string `json:"runbookUrl,omitempty"`
 	// The attribute name from the Event sample or Integration provider which identifies the metric to be tracked.
 	// Examples for Sqs include `provider.approximateAgeOfOldestMessage.Average` and `provider.numberOfEmptyReceives.Average`.
 	// For more information, please refer to the `select_value` field in the official [New Relic documentation](https://docs.newrelic.com/docs/infrastructure/new-relic-infrastructure/infrastructure-alert-conditions/rest-api-calls-new-relic-infrastructure-alerts#definitions)
-	SelectValue string `json:"selectValue"`
+	// Required for `infra_metric` conditions
+	// +optional
+	SelectValue string `json:"selectValue,omitempty"`
 	// +optional
 	ViolationCloseTimer int `json:"violationCloseTimer,omitempty"`
-	// An expression used for filtering data from the IntegrationProvider
+	// An expression used for filtering data from the IntegrationProvider, or the hosts of
+	// `infra_process_running` and `infra_host_not_reporting` conditions
 	WhereClause string `json:"whereClause,omitempty"`
+	// An expression selecting the processes to count in `infra_process_running` conditions,
+	// e.g. `commandName = 'nginx'`
+	// +optional
+	ProcessWhereClause string `json:"processWhereClause,omitempty"`
 }
 
 type InfraThreshold struct {
@@ -42,9 +59,14 @@ type InfraThreshold struct {
 	// Available options are: \
 	// - `all` - all data points are in violation within the given period \
 	// - `any` - at least one data point is in violation within the given period \
+	// Required for `infra_metric` conditions
 	// +kubebuilder:validation:Enum=all;any
-	TimeFunction string `json:"timeFunction"`
-	Value        int    `json:"value"`
+	// +optional
+	TimeFunction string `json:"timeFunction,omitempty"`
+	// The value of the metric, or the number of processes, to compare with. \
+	// Not used by `infra_host_not_reporting` conditions
+	// +optional
+	Value int `json:"value"`
 	// For how long the violation should be active before an incident is triggered \
 	DurationMinutes int `json:"durationMinutes"`
 }
@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	MutingRuleOperatorAnd = "and"
+	MutingRuleOperatorOr  = "or"
+)
+
+const (
+	MutingRuleAttributePolicyId = "policyId"
+	MutingRuleIsBlank           = "is_blank"
+	MutingRuleIsNotBlank        = "is_not_blank"
+)
+
+const (
+	MutingRuleRepeatDaily   = "daily"
+	MutingRuleRepeatWeekly  = "weekly"
+	MutingRuleRepeatMonthly = "monthly"
+)
+
+// MutingRuleTimeLayout is the layout of the local start and end times of a muting rule schedule
+const MutingRuleTimeLayout = "2006-01-02T15:04:05"
+
+var mutingRuleWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// MutingRuleSpec defines the desired state of MutingRule
+type MutingRuleSpec struct {
+	// The name of the muting rule in New Relic
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The violations which are muted
+	Condition MutingRuleConditionGroup `json:"condition"`
+	// When the rule mutes violations. Rules without a schedule mute violations for as long as they are enabled
+	// +optional
+	Schedule *MutingRuleSchedule `json:"schedule,omitempty"`
+}
+
+type MutingRuleConditionGroup struct {
+	// How the conditions are combined. \
+	// Available options are `and` and `or`. Defaults to `and`
+	// +kubebuilder:validation:Enum=and;or
+	// +optional
+	Operator *string `json:"operator,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Conditions []MutingRuleCondition `json:"conditions"`
+}
+
+// MutingRuleCondition matches an attribute of violations
+type MutingRuleCondition struct {
+	// The attribute of the violation, such as `policyId`, `policyName`, `conditionId`, `conditionName`, `conditionType`,
+	// `entity.guid`, `targetName`, or `tags.<tag name>` for the tags of the entity
+	Attribute string `json:"attribute"`
+	// Available options are: \
+	// `equals`, `not_equals`, `in`, `not_in`, `contains`, `not_contains`, `starts_with`, `not_starts_with`,
+	// `ends_with`, `not_ends_with`, `is_blank` and `is_not_blank`
+	// +kubebuilder:validation:Enum=equals;not_equals;in;not_in;contains;not_contains;starts_with;not_starts_with;ends_with;not_ends_with;is_blank;is_not_blank
+	Operator string `json:"operator"`
+	// The values the attribute is compared with
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// AlertPolicy resources whose New Relic ids are added to the values. Only allowed for the `policyId` attribute
+	// +optional
+	PolicyRefs []PolicyReference `json:"policyRefs,omitempty"`
+}
+
+type MutingRuleSchedule struct {
+	// The local time the first muting window starts, as `YYYY-MM-DDThh:mm:ss`
+	StartTime string `json:"startTime"`
+	// The local time the first muting window ends, as `YYYY-MM-DDThh:mm:ss`
+	EndTime string `json:"endTime"`
+	// The time zone of the start and end time, such as `Europe/Berlin`
+	TimeZone string `json:"timeZone"`
+	// How often the muting window repeats. Windows which do not repeat are one-time maintenance windows. \
+	// Available options are `daily`, `weekly` and `monthly`
+	// +kubebuilder:validation:Enum=daily;weekly;monthly
+	// +optional
+	Repeat *string `json:"repeat,omitempty"`
+	// The days on which a weekly window repeats, such as `monday`. Defaults to the day of the start time
+	// +optional
+	WeeklyRepeatDays []string `json:"weeklyRepeatDays,omitempty"`
+	// The local time after which the window no longer repeats, as `YYYY-MM-DDThh:mm:ss`. Cannot be combined with repeatCount
+	// +optional
+	EndRepeat *string `json:"endRepeat,omitempty"`
+	// The number of times the window repeats. Cannot be combined with endRepeat
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RepeatCount *int `json:"repeatCount,omitempty"`
+}
+
+// MutingRuleStatus defines the observed state of MutingRule
+type MutingRuleStatus struct {
+	v1alpha1.Status `json:",inline"`
+	// Whether the rule currently mutes violations, as reported by New Relic. \
+	// Can be one of `Active`, `Inactive`, `Scheduled` or `Ended`
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+func NewMutingRuleError(newrelicId *int64, err error) MutingRuleStatus {
+	return MutingRuleStatus{
+		Status: v1alpha1.NewError(newrelicId, err),
+	}
+}
+
+func NewMutingRuleReady(newrelicId *int64, state string) MutingRuleStatus {
+	return MutingRuleStatus{
+		Status: v1alpha1.NewReady(newrelicId),
+		State:  state,
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MutingRule mutes the violations of alert conditions, permanently or during scheduled maintenance windows
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=mutingrules,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name of this muting rule"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this muting rule"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="Whether this muting rule currently mutes violations"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this muting rule"
+type MutingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MutingRuleSpec   `json:"spec,omitempty"`
+	Status MutingRuleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MutingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MutingRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MutingRule{}, &MutingRuleList{})
+}
@@ -0,0 +1,160 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"time"
+)
+
+// blank assignment to verify that MutingRule implements admission.Validator
+var _ admission.Validator = &MutingRule{}
+
+func (rule *MutingRule) ValidateCreate() error {
+	return rule.validate()
+}
+
+// ValidateUpdate only checks updates which change the spec, like the one of AlertPolicy
+func (rule *MutingRule) ValidateUpdate(old runtime.Object) error {
+	if rule.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldRule, ok := old.(*MutingRule); ok && reflect.DeepEqual(rule.Spec, oldRule.Spec) {
+		return nil
+	}
+
+	return rule.validate()
+}
+
+func (rule *MutingRule) ValidateDelete() error {
+	return nil
+}
+
+func (rule *MutingRule) validate() error {
+	errs := rule.Spec.Validate(field.NewPath("spec"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind("MutingRule").GroupKind(), rule.Name, errs)
+}
+
+// Validate checks the constraints of New Relic muting rules which cannot be expressed in the CRD schema
+func (spec MutingRuleSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+
+	conditionsPath := path.Child("condition", "conditions")
+	if len(spec.Condition.Conditions) == 0 {
+		errs = append(errs, field.Required(conditionsPath, "at least one condition is required"))
+	}
+	for i, condition := range spec.Condition.Conditions {
+		errs = append(errs, condition.validate(conditionsPath.Index(i))...)
+	}
+
+	if spec.Schedule != nil {
+		errs = append(errs, spec.Schedule.validate(path.Child("schedule"))...)
+	}
+
+	return errs
+}
+
+func (condition MutingRuleCondition) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if condition.Attribute == "" {
+		errs = append(errs, field.Required(path.Child("attribute"), ""))
+	}
+	if len(condition.PolicyRefs) > 0 && condition.Attribute != MutingRuleAttributePolicyId {
+		errs = append(errs, field.Forbidden(path.Child("policyRefs"), "only allowed for the policyId attribute"))
+	}
+	for i, reference := range condition.PolicyRefs {
+		errs = append(errs, reference.validate(path.Child("policyRefs").Index(i))...)
+	}
+
+	hasValues := len(condition.Values) > 0 || len(condition.PolicyRefs) > 0
+	isBlankOperator := condition.Operator == MutingRuleIsBlank || condition.Operator == MutingRuleIsNotBlank
+	if isBlankOperator && hasValues {
+		errs = append(errs, field.Forbidden(path.Child("values"), "not allowed for the is_blank and is_not_blank operators"))
+	}
+	if !isBlankOperator && !hasValues {
+		errs = append(errs, field.Required(path.Child("values"), "values or policyRefs are required"))
+	}
+
+	return errs
+}
+
+func (schedule MutingRuleSchedule) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if schedule.TimeZone == "" {
+		errs = append(errs, field.Required(path.Child("timeZone"), ""))
+	}
+
+	startTime, startErrs := parseMutingRuleTime(schedule.StartTime, path.Child("startTime"))
+	endTime, endErrs := parseMutingRuleTime(schedule.EndTime, path.Child("endTime"))
+	errs = append(errs, startErrs...)
+	errs = append(errs, endErrs...)
+	if len(startErrs) == 0 && len(endErrs) == 0 && !endTime.After(startTime) {
+		errs = append(errs, field.Invalid(path.Child("endTime"), schedule.EndTime, "must be after the startTime"))
+	}
+
+	if schedule.Repeat == nil {
+		if len(schedule.WeeklyRepeatDays) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("weeklyRepeatDays"), "only allowed for weekly windows"))
+		}
+		if schedule.EndRepeat != nil {
+			errs = append(errs, field.Forbidden(path.Child("endRepeat"), "only allowed for repeating windows"))
+		}
+		if schedule.RepeatCount != nil {
+			errs = append(errs, field.Forbidden(path.Child("repeatCount"), "only allowed for repeating windows"))
+		}
+		return errs
+	}
+
+	if len(schedule.WeeklyRepeatDays) > 0 && *schedule.Repeat != MutingRuleRepeatWeekly {
+		errs = append(errs, field.Forbidden(path.Child("weeklyRepeatDays"), "only allowed for weekly windows"))
+	}
+	for i, day := range schedule.WeeklyRepeatDays {
+		if !containsString(mutingRuleWeekdays, day) {
+			errs = append(errs, field.NotSupported(path.Child("weeklyRepeatDays").Index(i), day, mutingRuleWeekdays))
+		}
+	}
+	if schedule.EndRepeat != nil && schedule.RepeatCount != nil {
+		errs = append(errs, field.Forbidden(path.Child("repeatCount"), "cannot be combined with endRepeat"))
+	}
+	if schedule.EndRepeat != nil {
+		endRepeat, endRepeatErrs := parseMutingRuleTime(*schedule.EndRepeat, path.Child("endRepeat"))
+		errs = append(errs, endRepeatErrs...)
+		if len(endRepeatErrs) == 0 && len(endErrs) == 0 && endRepeat.Before(endTime) {
+			errs = append(errs, field.Invalid(path.Child("endRepeat"), *schedule.EndRepeat, "must not be before the endTime"))
+		}
+	}
+
+	return errs
+}
+
+func parseMutingRuleTime(value string, path *field.Path) (time.Time, field.ErrorList) {
+	if value == "" {
+		return time.Time{}, field.ErrorList{field.Required(path, "")}
+	}
+
+	parsed, err := time.Parse(MutingRuleTimeLayout, value)
+	if err != nil {
+		return time.Time{}, field.ErrorList{field.Invalid(path, value, "must be a local time formatted as YYYY-MM-DDThh:mm:ss")}
+	}
+
+	return parsed, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package v1alpha1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"io/ioutil"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+func TestValidate_MutingRuleExample(t *testing.T) {
+	rule := readExampleMutingRule(t)
+
+	if err := rule.ValidateCreate(); err != nil {
+		t.Errorf("Expected the example to be valid, got %v", err)
+	}
+}
+
+func TestValidate_MutingRulePolicyRefsOfOtherAttribute(t *testing.T) {
+	rule := readExampleMutingRule(t)
+	rule.Spec.Condition.Conditions[0].Attribute = "policyName"
+
+	assertMutingRuleError(t, rule, "spec.condition.conditions[0].policyRefs: Forbidden: only allowed for the policyId attribute")
+}
+
+func TestValidateUpdate_MutingRuleIgnoresUnchangedSpec(t *testing.T) {
+	old := readExampleMutingRule(t)
+	old.Spec.Condition.Conditions[1].Values = nil
+	rule := old.DeepCopy()
+	rule.Finalizers = []string{"newrelic"}
+
+	if err := rule.ValidateUpdate(old); err != nil {
+		t.Errorf("Expected a finalizer update of an invalid rule to be allowed, got %v", err)
+	}
+}
+
+func TestValidate_MutingRuleConditionWithoutValues(t *testing.T) {
+	rule := readExampleMutingRule(t)
+	rule.Spec.Condition.Conditions[1].Values = nil
+
+	assertMutingRuleError(t, rule, "spec.condition.conditions[1].values: Required value")
+
+	rule.Spec.Condition.Conditions[1].Operator = v1alpha1.MutingRuleIsBlank
+	if err := rule.ValidateCreate(); err != nil {
+		t.Errorf("Expected is_blank conditions without values to be valid, got %v", err)
+	}
+}
+
+func TestValidate_MutingRuleScheduleTimes(t *testing.T) {
+	rule := readExampleMutingRule(t)
+	rule.Spec.Schedule.EndTime = "2020-06-06T01:00:00"
+
+	assertMutingRuleError(t, rule, "spec.schedule.endTime: Invalid value: \"2020-06-06T01:00:00\": must be after the startTime")
+
+	rule.Spec.Schedule.EndTime = "2020-06-06 04:00"
+	assertMutingRuleError(t, rule, "must be a local time formatted as YYYY-MM-DDThh:mm:ss")
+}
+
+func TestValidate_MutingRuleRepeat(t *testing.T) {
+	rule := readExampleMutingRule(t)
+	rule.Spec.Schedule.EndRepeat = stringPtr("2020-07-01T00:00:00")
+	rule.Spec.Schedule.WeeklyRepeatDays = []string{"caturday"}
+
+	assertMutingRuleError(t, rule, "spec.schedule.repeatCount: Forbidden: cannot be combined with endRepeat")
+	assertMutingRuleError(t, rule, "spec.schedule.weeklyRepeatDays[0]: Unsupported value: \"caturday\"")
+
+	rule = readExampleMutingRule(t)
+	rule.Spec.Schedule.Repeat = nil
+	assertMutingRuleError(t, rule, "spec.schedule.weeklyRepeatDays: Forbidden: only allowed for weekly windows")
+	assertMutingRuleError(t, rule, "spec.schedule.repeatCount: Forbidden: only allowed for repeating windows")
+}
+
+func assertMutingRuleError(t *testing.T, rule *v1alpha1.MutingRule, expected string) {
+	t.Helper()
+	err := rule.ValidateCreate()
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func readExampleMutingRule(t *testing.T) *v1alpha1.MutingRule {
+	content, err := ioutil.ReadFile("../../../../hack/examples/mutingrule_cr.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rule v1alpha1.MutingRule
+	if err := yaml.UnmarshalStrict(content, &rule); err != nil {
+		t.Fatal(err)
+	}
+
+	return &rule
+}
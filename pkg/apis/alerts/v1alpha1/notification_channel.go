@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"github.com/personio/newrelic-alert-manager/pkg/notification_channels/domain"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,7 +16,36 @@ type NotificationChannel interface {
 	GetPolicySelector() labels.Selector
 	GetStatus() NotificationChannelStatus
 	SetStatus(status NotificationChannelStatus)
-	NewChannel(policies AlertPolicyList) *domain.NotificationChannel
+	NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel
+}
+
+// SecretReferencingChannel is implemented by notification channels which read
+// sensitive values from secrets in their own namespace
+type SecretReferencingChannel interface {
+	GetSecretKeyRefs() []corev1.SecretKeySelector
+}
+
+// SecretValues holds the values of the secret keys referenced by a notification channel
+type SecretValues map[string]string
+
+func (values SecretValues) Get(ref corev1.SecretKeySelector) (string, bool) {
+	value, found := values[secretValueKey(ref)]
+	return value, found
+}
+
+func (values SecretValues) Set(ref corev1.SecretKeySelector, value string) {
+	values[secretValueKey(ref)] = value
+}
+
+func secretValueKey(ref corev1.SecretKeySelector) string {
+	return ref.Name + "/" + ref.Key
+}
+
+// ChannelDefaults holds the values configured for the operator which are used
+// when a notification channel leaves the corresponding field empty
+type ChannelDefaults struct {
+	SlackWebhookUrl string
+	OpsgenieApiKey  string
 }
 
 type AbstractNotificationChannel struct {
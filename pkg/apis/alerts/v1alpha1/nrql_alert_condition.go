@@ -6,6 +6,33 @@ type NrqlCondition struct {
 	// +optional
 	// +default=true
 	Enabled *bool `json:"enabled,omitempty"`
+	// The type of the condition. \
+	// Available options are: \
+	// - `static` - the thresholds are compared to the value returned by the query \
+	// - `baseline` - the thresholds are the number of standard deviations the value may deviate from its baseline. \
+	// The operator of baseline thresholds must be `above`, and their value between 1 and 1000 \
+	// - `outlier` - violations are opened when a group of a faceted query deviates from the other groups. \
+	// The query must contain a `FACET` clause, and the operator of outlier thresholds must be `above` \
+	// Defaults to `static`
+	// +kubebuilder:validation:Enum=static;baseline;outlier
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// The direction in which the value of a baseline condition may deviate from its baseline. Required for baseline conditions. \
+	// Available options are: \
+	// - `upper_only` - only values above the baseline open violations \
+	// - `lower_only` - only values below the baseline open violations \
+	// - `upper_and_lower` - values above and below the baseline open violations
+	// +kubebuilder:validation:Enum=upper_only;lower_only;upper_and_lower
+	// +optional
+	BaselineDirection *string `json:"baselineDirection,omitempty"`
+	// The number of groups the results of an outlier condition are expected to fall into. Required for outlier conditions
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpectedGroups *int `json:"expectedGroups,omitempty"`
+	// Whether an outlier condition opens violations while groups overlap each other. Defaults to `false` for outlier conditions. \
+	// For more information, please refer to the official [New Relic documentation](https://docs.newrelic.com/docs/alerts/new-relic-alerts/defining-conditions/create-nrql-alert-conditions-outlier-detection)
+	// +optional
+	IgnoreOverlap *bool `json:"ignoreOverlap,omitempty"`
 	// The NRQL query associated with the condition
 	Query string `json:"query"`
 	// Defines the `SINCE` clause in the NRQL query
@@ -13,6 +40,7 @@ type NrqlCondition struct {
 	// Available options are: \
 	// - `single_value` \
 	// - `sum` \
+	// Baseline and outlier conditions only support `single_value`. \
 	// For more information, please refer to the official [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#value_function)
 	// +kubebuilder:validation:Enum=single_value;sum
 	ValueFunction string `json:"valueFunction"`
@@ -23,6 +51,73 @@ type NrqlCondition struct {
 	WarningThreshold *Threshold `json:"warningThreshold,omitempty"`
 	// +optional
 	RunbookUrl string `json:"runbookUrl,omitempty"`
+	// How the results of the query are aggregated and evaluated. \
+	// New Relic only accepts these settings through NerdGraph, which requires the `accountId` of the operator configuration. \
+	// Fields which are left empty keep the value they have in New Relic
+	// +optional
+	Signal *NrqlSignal `json:"signal,omitempty"`
+	// What happens when the query stops returning data, also known as loss of signal. \
+	// New Relic only accepts these settings through NerdGraph, which requires the `accountId` of the operator configuration. \
+	// Fields which are left empty keep the value they have in New Relic
+	// +optional
+	Expiration *NrqlExpiration `json:"expiration,omitempty"`
+}
+
+type NrqlSignal struct {
+	// The length in seconds of the windows into which the results of the query are aggregated
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=7200
+	// +optional
+	AggregationWindow *int `json:"aggregationWindow,omitempty"`
+	// Defines when an aggregation window is evaluated. \
+	// Available options are: \
+	// - `event_flow` - once data for a later window arrives, for data which arrives steadily \
+	// - `event_timer` - once no data arrived for the aggregationTimer, for sparse data \
+	// - `cadence` - after the aggregationDelay has passed on the clock of New Relic
+	// +kubebuilder:validation:Enum=event_flow;event_timer;cadence
+	// +optional
+	AggregationMethod *string `json:"aggregationMethod,omitempty"`
+	// The number of seconds to wait for late data before evaluating a window. Only used by `event_flow` and `cadence`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	AggregationDelay *int `json:"aggregationDelay,omitempty"`
+	// The number of seconds without new data after which a window is evaluated. Only used by `event_timer`
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=1200
+	// +optional
+	AggregationTimer *int `json:"aggregationTimer,omitempty"`
+	// The number of aggregation windows to wait for late data, the predecessor of aggregationMethod. \
+	// Cannot be combined with aggregationMethod
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	EvaluationOffset *int `json:"evaluationOffset,omitempty"`
+	// How windows without data are filled. \
+	// Available options are: \
+	// - `none` - the windows are left empty \
+	// - `last_value` - the windows are filled with the last value of the signal \
+	// - `static` - the windows are filled with the fillValue
+	// +kubebuilder:validation:Enum=none;last_value;static
+	// +optional
+	FillOption *string `json:"fillOption,omitempty"`
+	// The value windows without data are filled with. Required when fillOption is `static`
+	// +optional
+	FillValue *string `json:"fillValue,omitempty"`
+}
+
+type NrqlExpiration struct {
+	// The number of seconds without data after which the signal is considered lost
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=172800
+	// +optional
+	ExpirationDuration *int `json:"expirationDuration,omitempty"`
+	// Whether a violation is opened when the signal is lost
+	// +optional
+	OpenViolationOnExpiration *bool `json:"openViolationOnExpiration,omitempty"`
+	// Whether the open violations of the condition are closed when the signal is lost
+	// +optional
+	CloseViolationsOnExpiration *bool `json:"closeViolationsOnExpiration,omitempty"`
 }
 
 type Threshold struct {
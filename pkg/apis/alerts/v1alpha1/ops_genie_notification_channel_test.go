@@ -0,0 +1,37 @@
+package v1alpha1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestOpsgenieNotificationChannel_ApiKey(t *testing.T) {
+	ref := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "opsgenie"},
+		Key:                  "apiKey",
+	}
+	secrets := v1alpha1.SecretValues{}
+	secrets.Set(ref, "secret-key")
+	defaults := v1alpha1.ChannelDefaults{OpsgenieApiKey: "default-key"}
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.OpsgenieNotificationChannelSpec
+		secrets  v1alpha1.SecretValues
+		expected string
+	}{
+		{name: "default", expected: "default-key"},
+		{name: "inline", spec: v1alpha1.OpsgenieNotificationChannelSpec{ApiKey: "inline-key"}, expected: "inline-key"},
+		{name: "secret", spec: v1alpha1.OpsgenieNotificationChannelSpec{ApiKey: "inline-key", ApiKeySecretRef: &ref}, secrets: secrets, expected: "secret-key"},
+		{name: "missing optional secret", spec: v1alpha1.OpsgenieNotificationChannelSpec{ApiKeySecretRef: &ref}, expected: "default-key"},
+	}
+
+	for _, test := range tests {
+		channel := v1alpha1.OpsgenieNotificationChannel{Spec: test.spec}
+		result := channel.NewChannel(v1alpha1.AlertPolicyList{}, defaults, test.secrets)
+		if result.Channel.Configuration.ApiKey != test.expected {
+			t.Errorf("%s: expected API key %s, got %s", test.name, test.expected, result.Channel.Configuration.ApiKey)
+		}
+	}
+}
@@ -2,10 +2,10 @@ package v1alpha1
 
 import (
 	"github.com/personio/newrelic-alert-manager/pkg/notification_channels/domain"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"os"
 	"strings"
 )
 
@@ -13,6 +13,7 @@ import (
 
 // NotificationChannel is the Schema for the OpsgenieNotificationChannels API
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=opsgenienotificationchannels,scope=Namespaced
 // +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
@@ -34,6 +35,10 @@ type OpsgenieNotificationChannelSpec struct {
 	// If left empty, the default API key specified when deploying the operator will be used
 	// +optional
 	ApiKey string `json:"api_key,omitempty"`
+	// A reference to a secret key holding the Opsgenie API Key. The secret must be in the namespace of the channel.
+	// Takes precedence over api_key
+	// +optional
+	ApiKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
 	// A list of teams
 	// +optional
 	Teams []string `json:"teams,omitempty"`
@@ -47,14 +52,14 @@ type OpsgenieNotificationChannelSpec struct {
 	PolicySelector labels.Set `json:"policySelector,omitempty"`
 }
 
-func (channel OpsgenieNotificationChannel) NewChannel(policies AlertPolicyList) *domain.NotificationChannel {
+func (channel OpsgenieNotificationChannel) NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel {
 	return &domain.NotificationChannel{
 		Channel: domain.Channel{
 			Id:   channel.Status.NewrelicId,
 			Name: channel.Spec.Name,
 			Type: "opsgenie",
 			Configuration: domain.Configuration{
-				ApiKey:     channel.getApiKey(),
+				ApiKey:     channel.getApiKey(defaults, secrets),
 				Teams:      strings.Join(channel.Spec.Teams, ", "),
 				Tags:       strings.Join(channel.Spec.Tags, ", "),
 				Recipients: strings.Join(channel.Spec.Recipients, ", "),
@@ -70,12 +75,25 @@ func (channel OpsgenieNotificationChannel) GetPolicySelector() labels.Selector {
 	return channel.Spec.PolicySelector.AsSelector()
 }
 
-func (channel OpsgenieNotificationChannel) getApiKey() string {
+func (channel OpsgenieNotificationChannel) GetSecretKeyRefs() []corev1.SecretKeySelector {
+	if channel.Spec.ApiKeySecretRef == nil {
+		return nil
+	}
+
+	return []corev1.SecretKeySelector{*channel.Spec.ApiKeySecretRef}
+}
+
+func (channel OpsgenieNotificationChannel) getApiKey(defaults ChannelDefaults, secrets SecretValues) string {
+	if channel.Spec.ApiKeySecretRef != nil {
+		if value, found := secrets.Get(*channel.Spec.ApiKeySecretRef); found {
+			return value
+		}
+	}
 	if channel.Spec.ApiKey != "" {
 		return channel.Spec.ApiKey
 	}
 
-	return os.Getenv("DEFAULT_OPS_GENIE_API_KEY")
+	return defaults.OpsgenieApiKey
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
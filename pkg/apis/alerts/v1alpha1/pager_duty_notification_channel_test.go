@@ -0,0 +1,48 @@
+package v1alpha1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestPagerDutyNotificationChannel_ServiceKey(t *testing.T) {
+	ref := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "pagerduty"},
+		Key:                  "serviceKey",
+	}
+	channel := v1alpha1.PagerDutyNotificationChannel{
+		Spec: v1alpha1.PagerDutyNotificationChannelSpec{Name: "pagerduty", ServiceKeySecretRef: ref},
+	}
+
+	refs := channel.GetSecretKeyRefs()
+	if len(refs) != 1 || refs[0] != ref {
+		t.Errorf("expected secret key refs [%v], got %v", ref, refs)
+	}
+
+	first := v1alpha1.SecretValues{}
+	first.Set(ref, "key-1")
+	second := v1alpha1.SecretValues{}
+	second.Set(ref, "key-2")
+
+	firstChannel := channel.NewChannel(v1alpha1.AlertPolicyList{}, v1alpha1.ChannelDefaults{}, first)
+	secondChannel := channel.NewChannel(v1alpha1.AlertPolicyList{}, v1alpha1.ChannelDefaults{}, second)
+	if firstChannel.Channel.Type != "pagerduty" {
+		t.Errorf("expected type pagerduty, got %s", firstChannel.Channel.Type)
+	}
+	if firstChannel.Channel.Configuration.ServiceKey != "key-1" {
+		t.Errorf("expected service key key-1, got %s", firstChannel.Channel.Configuration.ServiceKey)
+	}
+	if !firstChannel.Equals(*secondChannel) {
+		t.Error("Channels should only differ in their service key")
+	}
+
+	firstChannel.Channel.Configuration.PreviousVersion = firstChannel.Channel.Configuration.Version()
+	secondChannel.Channel.Configuration.PreviousVersion = firstChannel.Channel.Configuration.PreviousVersion
+	if firstChannel.Channel.Configuration.IsModified() {
+		t.Error("Configuration should not be modified for the same service key")
+	}
+	if !secondChannel.Channel.Configuration.IsModified() {
+		t.Error("Configuration should be modified when the service key changes")
+	}
+}
@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/notification_channels/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PagerDutyNotificationChannel is the Schema for the pagerdutynotificationchannels API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=pagerdutynotificationchannels,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
+// +kubebuilder:printcolumn:name="Newrelic ID",type="string",JSONPath=".status.newrelicId",description="The New Relic ID of this channel"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this channel"
+type PagerDutyNotificationChannel struct {
+	AbstractNotificationChannel `json:",inline"`
+	metav1.TypeMeta             `json:",inline"`
+	metav1.ObjectMeta           `json:"metadata,omitempty"`
+
+	Spec PagerDutyNotificationChannelSpec `json:"spec,omitempty"`
+}
+
+// PagerDutyNotificationChannelSpec defines the desired state of NotificationChannel
+type PagerDutyNotificationChannelSpec struct {
+	// The name of the notification channel created in New Relic
+	Name string `json:"name"`
+	// A reference to a secret key holding the integration key of the PagerDuty service. The secret must be in the namespace of the channel
+	ServiceKeySecretRef corev1.SecretKeySelector `json:"serviceKeySecretRef"`
+	// A label selector defining the alert policies covered by the notification channel
+	PolicySelector labels.Set `json:"policySelector,omitempty"`
+}
+
+func (channel PagerDutyNotificationChannel) NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel {
+	serviceKey, _ := secrets.Get(channel.Spec.ServiceKeySecretRef)
+	return &domain.NotificationChannel{
+		Channel: domain.Channel{
+			Id:   channel.Status.NewrelicId,
+			Name: channel.Spec.Name,
+			Type: "pagerduty",
+			Configuration: domain.Configuration{
+				ServiceKey: serviceKey,
+			},
+			Links: domain.Links{
+				PolicyIds: getPolicyIds(policies),
+			},
+		},
+	}
+}
+
+func (channel PagerDutyNotificationChannel) GetPolicySelector() labels.Selector {
+	return channel.Spec.PolicySelector.AsSelector()
+}
+
+func (channel PagerDutyNotificationChannel) GetSecretKeyRefs() []corev1.SecretKeySelector {
+	return []corev1.SecretKeySelector{channel.Spec.ServiceKeySecretRef}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// PagerDutyNotificationChannelList contains a list of PagerDutyNotificationChannel
+type PagerDutyNotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PagerDutyNotificationChannel `json:"items"`
+}
+
+func (list PagerDutyNotificationChannelList) Size() int {
+	return len(list.Items)
+}
+
+func (list PagerDutyNotificationChannelList) GetNamespacedNames() []types.NamespacedName {
+	result := make([]types.NamespacedName, len(list.Items))
+	for idx, item := range list.Items {
+		result[idx] = GetNamespacedName(&item)
+	}
+
+	return result
+}
+
+type pagerDutyNotificationChannelFactory struct{}
+
+func NewPagerDutyNotificationChannelFactory() ChannelFactory {
+	return pagerDutyNotificationChannelFactory{}
+}
+
+func (factory pagerDutyNotificationChannelFactory) NewChannel() NotificationChannel {
+	return &PagerDutyNotificationChannel{}
+}
+
+func (factory pagerDutyNotificationChannelFactory) NewList() NotificationChannelList {
+	return &PagerDutyNotificationChannelList{}
+}
+
+func init() {
+	SchemeBuilder.Register(&PagerDutyNotificationChannel{}, &PagerDutyNotificationChannelList{})
+}
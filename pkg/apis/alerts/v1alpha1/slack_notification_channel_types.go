@@ -5,13 +5,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"os"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NotificationChannel is the Schema for the slacknotificationchannels API
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=slacknotificationchannels,scope=Namespaced
 // +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
@@ -43,14 +43,14 @@ func (channel SlackNotificationChannel) GetPolicySelector() labels.Selector {
 	return channel.Spec.PolicySelector.AsSelector()
 }
 
-func (channel SlackNotificationChannel) NewChannel(policies AlertPolicyList) *domain.NotificationChannel {
+func (channel SlackNotificationChannel) NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel {
 	return &domain.NotificationChannel{
 		Channel: domain.Channel{
 			Id:   channel.Status.NewrelicId,
 			Name: channel.Spec.Name,
 			Type: "slack",
 			Configuration: domain.Configuration{
-				Url:     channel.getUrl(),
+				Url:     channel.getUrl(defaults),
 				Channel: channel.Spec.Channel,
 			},
 			Links: domain.Links{
@@ -60,12 +60,12 @@ func (channel SlackNotificationChannel) NewChannel(policies AlertPolicyList) *do
 	}
 }
 
-func (channel SlackNotificationChannel) getUrl() string {
+func (channel SlackNotificationChannel) getUrl(defaults ChannelDefaults) string {
 	if channel.Spec.Url != "" {
 		return channel.Spec.Url
 	}
 
-	return os.Getenv("DEFAULT_SLACK_WEBHOOK_URL")
+	return defaults.SlackWebhookUrl
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,28 @@
+package v1alpha1
+
+type SyntheticsCondition struct {
+	// The name of the Synthetics condition that will be created in New Relic
+	Name string `json:"name"`
+	// +optional
+	// +default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// A list of Synthetics monitor names to monitor. \
+	// Conditions without locationThresholds open a violation whenever the monitor fails, and must list exactly one monitor
+	Monitors []string `json:"monitors"`
+	// Turns the condition into a multi-location condition, which opens violations
+	// when the monitors fail in several locations at once
+	// +optional
+	LocationThresholds *LocationThresholds `json:"locationThresholds,omitempty"`
+	// +optional
+	RunbookUrl string `json:"runbookUrl,omitempty"`
+}
+
+type LocationThresholds struct {
+	// The number of locations which must fail for a critical violation to be opened
+	// +kubebuilder:validation:Minimum=1
+	Critical int `json:"critical"`
+	// The number of locations which must fail for a warning to be opened. Must be less than critical
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Warning *int `json:"warning,omitempty"`
+}
@@ -24,6 +24,7 @@ type EmailNotificationChannelSpec struct {
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // EmailNotificationChannel is the Schema for the EmailNotificationChannels API
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=emailnotificationchannels,scope=Namespaced
 // +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
@@ -41,7 +42,7 @@ func (channel EmailNotificationChannel) GetPolicySelector() labels.Selector {
 	return channel.Spec.PolicySelector.AsSelector()
 }
 
-func (channel EmailNotificationChannel) NewChannel(policies AlertPolicyList) *domain.NotificationChannel {
+func (channel EmailNotificationChannel) NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel {
 	return &domain.NotificationChannel{
 		Channel: domain.Channel{
 			Id:   channel.Status.NewrelicId,
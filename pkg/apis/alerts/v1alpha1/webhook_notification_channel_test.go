@@ -0,0 +1,92 @@
+package v1alpha1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"testing"
+)
+
+func TestWebhookNotificationChannel_NewChannel(t *testing.T) {
+	passwordRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+		Key:                  "password",
+	}
+	headerRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+		Key:                  "apiKey",
+	}
+	secrets := v1alpha1.SecretValues{}
+	secrets.Set(passwordRef, "secret-password")
+	secrets.Set(headerRef, "secret-key")
+
+	channel := v1alpha1.WebhookNotificationChannel{
+		Spec: v1alpha1.WebhookNotificationChannelSpec{
+			Name:                  "webhook",
+			BaseUrl:               "https://example.com",
+			AuthUsername:          "user",
+			AuthPasswordSecretRef: &passwordRef,
+			Headers: []v1alpha1.WebhookHeader{
+				{Name: "X-Source", Value: "newrelic"},
+				{Name: "X-Api-Key", Value: "inline-key", ValueSecretRef: &headerRef},
+			},
+			Payload: &runtime.RawExtension{Raw: []byte(`{"condition":"$CONDITION_NAME"}`)},
+		},
+	}
+
+	refs := channel.GetSecretKeyRefs()
+	if !reflect.DeepEqual(refs, []corev1.SecretKeySelector{passwordRef, headerRef}) {
+		t.Errorf("unexpected secret key refs %v", refs)
+	}
+
+	result := channel.NewChannel(v1alpha1.AlertPolicyList{}, v1alpha1.ChannelDefaults{}, secrets)
+	config := result.Channel.Configuration
+	if result.Channel.Type != "webhook" {
+		t.Errorf("expected type webhook, got %s", result.Channel.Type)
+	}
+	if config.BaseUrl != "https://example.com" || config.AuthUsername != "user" {
+		t.Errorf("unexpected base URL %s or username %s", config.BaseUrl, config.AuthUsername)
+	}
+	if config.AuthPassword != "secret-password" {
+		t.Errorf("expected password secret-password, got %s", config.AuthPassword)
+	}
+	if config.PayloadType != v1alpha1.WebhookPayloadTypeJson {
+		t.Errorf("expected payload type %s, got %s", v1alpha1.WebhookPayloadTypeJson, config.PayloadType)
+	}
+	if string(config.Payload) != `{"condition":"$CONDITION_NAME"}` {
+		t.Errorf("unexpected payload %s", config.Payload)
+	}
+	expectedHeaders := map[string]string{"X-Source": "newrelic", "X-Api-Key": "secret-key"}
+	if !reflect.DeepEqual(config.Headers, expectedHeaders) {
+		t.Errorf("expected headers %v, got %v", expectedHeaders, config.Headers)
+	}
+}
+
+func TestWebhookNotificationChannel_SecretChangesVersion(t *testing.T) {
+	ref := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+		Key:                  "password",
+	}
+	channel := v1alpha1.WebhookNotificationChannel{
+		Spec: v1alpha1.WebhookNotificationChannelSpec{
+			Name:                  "webhook",
+			BaseUrl:               "https://example.com",
+			AuthPasswordSecretRef: &ref,
+		},
+	}
+
+	first := v1alpha1.SecretValues{}
+	first.Set(ref, "password-1")
+	second := v1alpha1.SecretValues{}
+	second.Set(ref, "password-2")
+
+	firstConfig := channel.NewChannel(v1alpha1.AlertPolicyList{}, v1alpha1.ChannelDefaults{}, first).Channel.Configuration
+	secondConfig := channel.NewChannel(v1alpha1.AlertPolicyList{}, v1alpha1.ChannelDefaults{}, second).Channel.Configuration
+	if firstConfig.Version() == secondConfig.Version() {
+		t.Error("Version should change with the password")
+	}
+	if !firstConfig.Equals(secondConfig) {
+		t.Error("Configurations should only differ in their secrets")
+	}
+}
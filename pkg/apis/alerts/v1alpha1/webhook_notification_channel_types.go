@@ -0,0 +1,197 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"github.com/personio/newrelic-alert-manager/pkg/notification_channels/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	WebhookPayloadTypeJson = "application/json"
+	WebhookPayloadTypeForm = "application/x-www-form-urlencoded"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WebhookNotificationChannel is the Schema for the webhooknotificationchannels API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=webhooknotificationchannels,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
+// +kubebuilder:printcolumn:name="Newrelic ID",type="string",JSONPath=".status.newrelicId",description="The New Relic ID of this channel"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this channel"
+type WebhookNotificationChannel struct {
+	AbstractNotificationChannel `json:",inline"`
+	metav1.TypeMeta             `json:",inline"`
+	metav1.ObjectMeta           `json:"metadata,omitempty"`
+
+	Spec WebhookNotificationChannelSpec `json:"spec,omitempty"`
+}
+
+// WebhookNotificationChannelSpec defines the desired state of NotificationChannel
+type WebhookNotificationChannelSpec struct {
+	// The name of the notification channel created in New Relic
+	Name string `json:"name"`
+	// The URL New Relic sends the notifications to
+	BaseUrl string `json:"baseUrl"`
+	// The username of the basic authentication of the webhook
+	// +optional
+	AuthUsername string `json:"authUsername,omitempty"`
+	// A reference to a secret key holding the password of the basic authentication. The secret must be in the namespace of the channel
+	// +optional
+	AuthPasswordSecretRef *corev1.SecretKeySelector `json:"authPasswordSecretRef,omitempty"`
+	// Headers added to the requests of New Relic
+	// +optional
+	Headers []WebhookHeader `json:"headers,omitempty"`
+	// The content type of the payload.
+	// Available options are `application/json` and `application/x-www-form-urlencoded`. Defaults to `application/json`
+	// +kubebuilder:validation:Enum=application/json;application/x-www-form-urlencoded
+	// +optional
+	PayloadType *string `json:"payloadType,omitempty"`
+	// A custom payload which replaces the default payload of New Relic.
+	// Values can contain New Relic placeholders such as `$CONDITION_NAME` or `$INCIDENT_URL`
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Payload *runtime.RawExtension `json:"payload,omitempty"`
+	// A label selector defining the alert policies covered by the notification channel
+	PolicySelector labels.Set `json:"policySelector,omitempty"`
+}
+
+// WebhookHeader is a header added to the requests of New Relic
+type WebhookHeader struct {
+	// The name of the header
+	Name string `json:"name"`
+	// The value of the header
+	// +optional
+	Value string `json:"value,omitempty"`
+	// A reference to a secret key holding the value of the header. The secret must be in the namespace of the channel.
+	// Takes precedence over value
+	// +optional
+	ValueSecretRef *corev1.SecretKeySelector `json:"valueSecretRef,omitempty"`
+}
+
+func (channel WebhookNotificationChannel) GetPolicySelector() labels.Selector {
+	return channel.Spec.PolicySelector.AsSelector()
+}
+
+func (channel WebhookNotificationChannel) NewChannel(policies AlertPolicyList, defaults ChannelDefaults, secrets SecretValues) *domain.NotificationChannel {
+	return &domain.NotificationChannel{
+		Channel: domain.Channel{
+			Id:   channel.Status.NewrelicId,
+			Name: channel.Spec.Name,
+			Type: "webhook",
+			Configuration: domain.Configuration{
+				BaseUrl:      channel.Spec.BaseUrl,
+				AuthUsername: channel.Spec.AuthUsername,
+				AuthPassword: channel.getAuthPassword(secrets),
+				PayloadType:  channel.getPayloadType(),
+				Payload:      channel.getPayload(),
+				Headers:      channel.getHeaders(secrets),
+			},
+			Links: domain.Links{
+				PolicyIds: getPolicyIds(policies),
+			},
+		},
+	}
+}
+
+func (channel WebhookNotificationChannel) GetSecretKeyRefs() []corev1.SecretKeySelector {
+	var result []corev1.SecretKeySelector
+	if channel.Spec.AuthPasswordSecretRef != nil {
+		result = append(result, *channel.Spec.AuthPasswordSecretRef)
+	}
+	for _, header := range channel.Spec.Headers {
+		if header.ValueSecretRef != nil {
+			result = append(result, *header.ValueSecretRef)
+		}
+	}
+
+	return result
+}
+
+func (channel WebhookNotificationChannel) getAuthPassword(secrets SecretValues) string {
+	if channel.Spec.AuthPasswordSecretRef == nil {
+		return ""
+	}
+
+	value, _ := secrets.Get(*channel.Spec.AuthPasswordSecretRef)
+	return value
+}
+
+func (channel WebhookNotificationChannel) getHeaders(secrets SecretValues) map[string]string {
+	if len(channel.Spec.Headers) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(channel.Spec.Headers))
+	for _, header := range channel.Spec.Headers {
+		result[header.Name] = header.Value
+		if header.ValueSecretRef != nil {
+			if value, found := secrets.Get(*header.ValueSecretRef); found {
+				result[header.Name] = value
+			}
+		}
+	}
+
+	return result
+}
+
+func (channel WebhookNotificationChannel) getPayloadType() string {
+	if channel.Spec.PayloadType == nil {
+		return WebhookPayloadTypeJson
+	}
+
+	return *channel.Spec.PayloadType
+}
+
+func (channel WebhookNotificationChannel) getPayload() json.RawMessage {
+	if channel.Spec.Payload == nil || len(channel.Spec.Payload.Raw) == 0 {
+		return nil
+	}
+
+	return json.RawMessage(channel.Spec.Payload.Raw)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// WebhookNotificationChannelList contains a list of WebhookNotificationChannel
+type WebhookNotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WebhookNotificationChannel `json:"items"`
+}
+
+func (list WebhookNotificationChannelList) Size() int {
+	return len(list.Items)
+}
+
+func (list WebhookNotificationChannelList) GetNamespacedNames() []types.NamespacedName {
+	result := make([]types.NamespacedName, len(list.Items))
+	for idx, item := range list.Items {
+		result[idx] = GetNamespacedName(&item)
+	}
+
+	return result
+}
+
+type webhookNotificationChannelFactory struct{}
+
+func NewWebhookNotificationChannelFactory() ChannelFactory {
+	return webhookNotificationChannelFactory{}
+}
+
+func (factory webhookNotificationChannelFactory) NewChannel() NotificationChannel {
+	return &WebhookNotificationChannel{}
+}
+
+func (factory webhookNotificationChannelFactory) NewList() NotificationChannelList {
+	return &WebhookNotificationChannelList{}
+}
+
+func init() {
+	SchemeBuilder.Register(&WebhookNotificationChannel{}, &WebhookNotificationChannelList{})
+}
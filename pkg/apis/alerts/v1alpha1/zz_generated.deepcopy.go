@@ -5,6 +5,8 @@
 package v1alpha1
 
 import (
+	commonv1alpha1 "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -26,6 +28,155 @@ func (in *AbstractNotificationChannel) DeepCopy() *AbstractNotificationChannel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionStatus) DeepCopyInto(out *AlertConditionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionStatus.
+func (in *AlertConditionStatus) DeepCopy() *AlertConditionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionTemplate) DeepCopyInto(out *AlertConditionTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionTemplate.
+func (in *AlertConditionTemplate) DeepCopy() *AlertConditionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertConditionTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionTemplateList) DeepCopyInto(out *AlertConditionTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertConditionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionTemplateList.
+func (in *AlertConditionTemplateList) DeepCopy() *AlertConditionTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertConditionTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionTemplateReference) DeepCopyInto(out *AlertConditionTemplateReference) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionTemplateReference.
+func (in *AlertConditionTemplateReference) DeepCopy() *AlertConditionTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionTemplateSpec) DeepCopyInto(out *AlertConditionTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApmConditions != nil {
+		in, out := &in.ApmConditions, &out.ApmConditions
+		*out = make([]ApmCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NrqlConditions != nil {
+		in, out := &in.NrqlConditions, &out.NrqlConditions
+		*out = make([]NrqlCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InfraConditions != nil {
+		in, out := &in.InfraConditions, &out.InfraConditions
+		*out = make([]InfraCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionTemplateSpec.
+func (in *AlertConditionTemplateSpec) DeepCopy() *AlertConditionTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertPolicy) DeepCopyInto(out *AlertPolicy) {
 	*out = *in
@@ -111,6 +262,27 @@ func (in *AlertPolicySpec) DeepCopyInto(out *AlertPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SyntheticsConditions != nil {
+		in, out := &in.SyntheticsConditions, &out.SyntheticsConditions
+		*out = make([]SyntheticsCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalServiceConditions != nil {
+		in, out := &in.ExternalServiceConditions, &out.ExternalServiceConditions
+		*out = make([]ExternalServiceCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]AlertConditionTemplateReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -124,6 +296,114 @@ func (in *AlertPolicySpec) DeepCopy() *AlertPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertPolicyStatus) DeepCopyInto(out *AlertPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.UnresolvedEntities != nil {
+		in, out := &in.UnresolvedEntities, &out.UnresolvedEntities
+		*out = make([]UnresolvedEntity, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedEntities != nil {
+		in, out := &in.ResolvedEntities, &out.ResolvedEntities
+		*out = make([]ResolvedEntity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertPolicyStatus.
+func (in *AlertPolicyStatus) DeepCopy() *AlertPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApmAlertCondition) DeepCopyInto(out *ApmAlertCondition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApmAlertCondition.
+func (in *ApmAlertCondition) DeepCopy() *ApmAlertCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ApmAlertCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApmAlertCondition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApmAlertConditionList) DeepCopyInto(out *ApmAlertConditionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApmAlertCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApmAlertConditionList.
+func (in *ApmAlertConditionList) DeepCopy() *ApmAlertConditionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApmAlertConditionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApmAlertConditionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApmAlertConditionSpec) DeepCopyInto(out *ApmAlertConditionSpec) {
+	*out = *in
+	out.PolicyRef = in.PolicyRef
+	in.ApmCondition.DeepCopyInto(&out.ApmCondition)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApmAlertConditionSpec.
+func (in *ApmAlertConditionSpec) DeepCopy() *ApmAlertConditionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApmAlertConditionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApmCondition) DeepCopyInto(out *ApmCondition) {
 	*out = *in
@@ -142,6 +422,16 @@ func (in *ApmCondition) DeepCopyInto(out *ApmCondition) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EntitySelectors != nil {
+		in, out := &in.EntitySelectors, &out.EntitySelectors
+		*out = make([]commonv1alpha1.EntitySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingEntities != nil {
+		in, out := &in.MissingEntities, &out.MissingEntities
+		*out = new(string)
+		**out = **in
+	}
 	out.CriticalThreshold = in.CriticalThreshold
 	if in.WarningThreshold != nil {
 		in, out := &in.WarningThreshold, &out.WarningThreshold
@@ -167,27 +457,827 @@ func (in *ApmCondition) DeepCopy() *ApmCondition {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmailNotificationChannel) DeepCopyInto(out *EmailNotificationChannel) {
+func (in *ChannelDefaults) DeepCopyInto(out *ChannelDefaults) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelDefaults.
+func (in *ChannelDefaults) DeepCopy() *ChannelDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAlertConditionTemplate) DeepCopyInto(out *ClusterAlertConditionTemplate) {
 	*out = *in
-	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannel.
-func (in *EmailNotificationChannel) DeepCopy() *EmailNotificationChannel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAlertConditionTemplate.
+func (in *ClusterAlertConditionTemplate) DeepCopy() *ClusterAlertConditionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAlertConditionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAlertConditionTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAlertConditionTemplateList) DeepCopyInto(out *ClusterAlertConditionTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAlertConditionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAlertConditionTemplateList.
+func (in *ClusterAlertConditionTemplateList) DeepCopy() *ClusterAlertConditionTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAlertConditionTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAlertConditionTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannel) DeepCopyInto(out *EmailNotificationChannel) {
+	*out = *in
+	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannel.
+func (in *EmailNotificationChannel) DeepCopy() *EmailNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannelList) DeepCopyInto(out *EmailNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmailNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelList.
+func (in *EmailNotificationChannelList) DeepCopy() *EmailNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannelSpec) DeepCopyInto(out *EmailNotificationChannelSpec) {
+	*out = *in
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelSpec.
+func (in *EmailNotificationChannelSpec) DeepCopy() *EmailNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalServiceCondition) DeepCopyInto(out *ExternalServiceCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingEntities != nil {
+		in, out := &in.MissingEntities, &out.MissingEntities
+		*out = new(string)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalServiceCondition.
+func (in *ExternalServiceCondition) DeepCopy() *ExternalServiceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalServiceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraCondition) DeepCopyInto(out *InfraCondition) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(InfraThreshold)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraCondition.
+func (in *InfraCondition) DeepCopy() *InfraCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraThreshold) DeepCopyInto(out *InfraThreshold) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraThreshold.
+func (in *InfraThreshold) DeepCopy() *InfraThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationThresholds) DeepCopyInto(out *LocationThresholds) {
+	*out = *in
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationThresholds.
+func (in *LocationThresholds) DeepCopy() *LocationThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRule) DeepCopyInto(out *MutingRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRule.
+func (in *MutingRule) DeepCopy() *MutingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MutingRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleCondition) DeepCopyInto(out *MutingRuleCondition) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyRefs != nil {
+		in, out := &in.PolicyRefs, &out.PolicyRefs
+		*out = make([]PolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleCondition.
+func (in *MutingRuleCondition) DeepCopy() *MutingRuleCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleConditionGroup) DeepCopyInto(out *MutingRuleConditionGroup) {
+	*out = *in
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MutingRuleCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleConditionGroup.
+func (in *MutingRuleConditionGroup) DeepCopy() *MutingRuleConditionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleConditionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleList) DeepCopyInto(out *MutingRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MutingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleList.
+func (in *MutingRuleList) DeepCopy() *MutingRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MutingRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleSchedule) DeepCopyInto(out *MutingRuleSchedule) {
+	*out = *in
+	if in.Repeat != nil {
+		in, out := &in.Repeat, &out.Repeat
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeeklyRepeatDays != nil {
+		in, out := &in.WeeklyRepeatDays, &out.WeeklyRepeatDays
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndRepeat != nil {
+		in, out := &in.EndRepeat, &out.EndRepeat
+		*out = new(string)
+		**out = **in
+	}
+	if in.RepeatCount != nil {
+		in, out := &in.RepeatCount, &out.RepeatCount
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleSchedule.
+func (in *MutingRuleSchedule) DeepCopy() *MutingRuleSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleSpec) DeepCopyInto(out *MutingRuleSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.Condition.DeepCopyInto(&out.Condition)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(MutingRuleSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleSpec.
+func (in *MutingRuleSpec) DeepCopy() *MutingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutingRuleStatus) DeepCopyInto(out *MutingRuleStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutingRuleStatus.
+func (in *MutingRuleStatus) DeepCopy() *MutingRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MutingRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelStatus) DeepCopyInto(out *NotificationChannelStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelStatus.
+func (in *NotificationChannelStatus) DeepCopy() *NotificationChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlAlertCondition) DeepCopyInto(out *NrqlAlertCondition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlAlertCondition.
+func (in *NrqlAlertCondition) DeepCopy() *NrqlAlertCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlAlertCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NrqlAlertCondition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlAlertConditionList) DeepCopyInto(out *NrqlAlertConditionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NrqlAlertCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlAlertConditionList.
+func (in *NrqlAlertConditionList) DeepCopy() *NrqlAlertConditionList {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlAlertConditionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NrqlAlertConditionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlAlertConditionSpec) DeepCopyInto(out *NrqlAlertConditionSpec) {
+	*out = *in
+	out.PolicyRef = in.PolicyRef
+	in.NrqlCondition.DeepCopyInto(&out.NrqlCondition)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlAlertConditionSpec.
+func (in *NrqlAlertConditionSpec) DeepCopy() *NrqlAlertConditionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlAlertConditionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlCondition) DeepCopyInto(out *NrqlCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.BaselineDirection != nil {
+		in, out := &in.BaselineDirection, &out.BaselineDirection
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpectedGroups != nil {
+		in, out := &in.ExpectedGroups, &out.ExpectedGroups
+		*out = new(int)
+		**out = **in
+	}
+	if in.IgnoreOverlap != nil {
+		in, out := &in.IgnoreOverlap, &out.IgnoreOverlap
+		*out = new(bool)
+		**out = **in
+	}
+	out.AlertThreshold = in.AlertThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	if in.Signal != nil {
+		in, out := &in.Signal, &out.Signal
+		*out = new(NrqlSignal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(NrqlExpiration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlCondition.
+func (in *NrqlCondition) DeepCopy() *NrqlCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlExpiration) DeepCopyInto(out *NrqlExpiration) {
+	*out = *in
+	if in.ExpirationDuration != nil {
+		in, out := &in.ExpirationDuration, &out.ExpirationDuration
+		*out = new(int)
+		**out = **in
+	}
+	if in.OpenViolationOnExpiration != nil {
+		in, out := &in.OpenViolationOnExpiration, &out.OpenViolationOnExpiration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CloseViolationsOnExpiration != nil {
+		in, out := &in.CloseViolationsOnExpiration, &out.CloseViolationsOnExpiration
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlExpiration.
+func (in *NrqlExpiration) DeepCopy() *NrqlExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlSignal) DeepCopyInto(out *NrqlSignal) {
+	*out = *in
+	if in.AggregationWindow != nil {
+		in, out := &in.AggregationWindow, &out.AggregationWindow
+		*out = new(int)
+		**out = **in
+	}
+	if in.AggregationMethod != nil {
+		in, out := &in.AggregationMethod, &out.AggregationMethod
+		*out = new(string)
+		**out = **in
+	}
+	if in.AggregationDelay != nil {
+		in, out := &in.AggregationDelay, &out.AggregationDelay
+		*out = new(int)
+		**out = **in
+	}
+	if in.AggregationTimer != nil {
+		in, out := &in.AggregationTimer, &out.AggregationTimer
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvaluationOffset != nil {
+		in, out := &in.EvaluationOffset, &out.EvaluationOffset
+		*out = new(int)
+		**out = **in
+	}
+	if in.FillOption != nil {
+		in, out := &in.FillOption, &out.FillOption
+		*out = new(string)
+		**out = **in
+	}
+	if in.FillValue != nil {
+		in, out := &in.FillValue, &out.FillValue
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlSignal.
+func (in *NrqlSignal) DeepCopy() *NrqlSignal {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlSignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannel) DeepCopyInto(out *OpsgenieNotificationChannel) {
+	*out = *in
+	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannel.
+func (in *OpsgenieNotificationChannel) DeepCopy() *OpsgenieNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsgenieNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannelList) DeepCopyInto(out *OpsgenieNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsgenieNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelList.
+func (in *OpsgenieNotificationChannelList) DeepCopy() *OpsgenieNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsgenieNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannelSpec) DeepCopyInto(out *OpsgenieNotificationChannelSpec) {
+	*out = *in
+	if in.ApiKeySecretRef != nil {
+		in, out := &in.ApiKeySecretRef, &out.ApiKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelSpec.
+func (in *OpsgenieNotificationChannelSpec) DeepCopy() *OpsgenieNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyNotificationChannel) DeepCopyInto(out *PagerDutyNotificationChannel) {
+	*out = *in
+	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyNotificationChannel.
+func (in *PagerDutyNotificationChannel) DeepCopy() *PagerDutyNotificationChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(EmailNotificationChannel)
+	out := new(PagerDutyNotificationChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EmailNotificationChannel) DeepCopyObject() runtime.Object {
+func (in *PagerDutyNotificationChannel) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -195,13 +1285,13 @@ func (in *EmailNotificationChannel) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmailNotificationChannelList) DeepCopyInto(out *EmailNotificationChannelList) {
+func (in *PagerDutyNotificationChannelList) DeepCopyInto(out *PagerDutyNotificationChannelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]EmailNotificationChannel, len(*in))
+		*out = make([]PagerDutyNotificationChannel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -209,18 +1299,18 @@ func (in *EmailNotificationChannelList) DeepCopyInto(out *EmailNotificationChann
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelList.
-func (in *EmailNotificationChannelList) DeepCopy() *EmailNotificationChannelList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyNotificationChannelList.
+func (in *PagerDutyNotificationChannelList) DeepCopy() *PagerDutyNotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(EmailNotificationChannelList)
+	out := new(PagerDutyNotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EmailNotificationChannelList) DeepCopyObject() runtime.Object {
+func (in *PagerDutyNotificationChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -228,8 +1318,9 @@ func (in *EmailNotificationChannelList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmailNotificationChannelSpec) DeepCopyInto(out *EmailNotificationChannelSpec) {
+func (in *PagerDutyNotificationChannelSpec) DeepCopyInto(out *PagerDutyNotificationChannelSpec) {
 	*out = *in
+	in.ServiceKeySecretRef.DeepCopyInto(&out.ServiceKeySecretRef)
 	if in.PolicySelector != nil {
 		in, out := &in.PolicySelector, &out.PolicySelector
 		*out = make(labels.Set, len(*in))
@@ -240,105 +1331,93 @@ func (in *EmailNotificationChannelSpec) DeepCopyInto(out *EmailNotificationChann
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelSpec.
-func (in *EmailNotificationChannelSpec) DeepCopy() *EmailNotificationChannelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyNotificationChannelSpec.
+func (in *PagerDutyNotificationChannelSpec) DeepCopy() *PagerDutyNotificationChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EmailNotificationChannelSpec)
+	out := new(PagerDutyNotificationChannelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfraCondition) DeepCopyInto(out *InfraCondition) {
+func (in *PolicyDefaults) DeepCopyInto(out *PolicyDefaults) {
 	*out = *in
-	out.CriticalThreshold = in.CriticalThreshold
-	if in.WarningThreshold != nil {
-		in, out := &in.WarningThreshold, &out.WarningThreshold
-		*out = new(InfraThreshold)
-		**out = **in
-	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraCondition.
-func (in *InfraCondition) DeepCopy() *InfraCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyDefaults.
+func (in *PolicyDefaults) DeepCopy() *PolicyDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(InfraCondition)
+	out := new(PolicyDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfraThreshold) DeepCopyInto(out *InfraThreshold) {
+func (in *PolicyReference) DeepCopyInto(out *PolicyReference) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraThreshold.
-func (in *InfraThreshold) DeepCopy() *InfraThreshold {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReference.
+func (in *PolicyReference) DeepCopy() *PolicyReference {
 	if in == nil {
 		return nil
 	}
-	out := new(InfraThreshold)
+	out := new(PolicyReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationChannelStatus) DeepCopyInto(out *NotificationChannelStatus) {
+func (in *ResolvedEntity) DeepCopyInto(out *ResolvedEntity) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Ids != nil {
+		in, out := &in.Ids, &out.Ids
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelStatus.
-func (in *NotificationChannelStatus) DeepCopy() *NotificationChannelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedEntity.
+func (in *ResolvedEntity) DeepCopy() *ResolvedEntity {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationChannelStatus)
+	out := new(ResolvedEntity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NrqlCondition) DeepCopyInto(out *NrqlCondition) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	out.AlertThreshold = in.AlertThreshold
-	if in.WarningThreshold != nil {
-		in, out := &in.WarningThreshold, &out.WarningThreshold
-		*out = new(Threshold)
-		**out = **in
+func (in SecretValues) DeepCopyInto(out *SecretValues) {
+	{
+		in := &in
+		*out = make(SecretValues, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
 	}
-	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlCondition.
-func (in *NrqlCondition) DeepCopy() *NrqlCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretValues.
+func (in SecretValues) DeepCopy() SecretValues {
 	if in == nil {
 		return nil
 	}
-	out := new(NrqlCondition)
+	out := new(SecretValues)
 	in.DeepCopyInto(out)
-	return out
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsgenieNotificationChannel) DeepCopyInto(out *OpsgenieNotificationChannel) {
+func (in *SlackNotificationChannel) DeepCopyInto(out *SlackNotificationChannel) {
 	*out = *in
 	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
 	out.TypeMeta = in.TypeMeta
@@ -347,18 +1426,18 @@ func (in *OpsgenieNotificationChannel) DeepCopyInto(out *OpsgenieNotificationCha
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannel.
-func (in *OpsgenieNotificationChannel) DeepCopy() *OpsgenieNotificationChannel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannel.
+func (in *SlackNotificationChannel) DeepCopy() *SlackNotificationChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsgenieNotificationChannel)
+	out := new(SlackNotificationChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpsgenieNotificationChannel) DeepCopyObject() runtime.Object {
+func (in *SlackNotificationChannel) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -366,13 +1445,13 @@ func (in *OpsgenieNotificationChannel) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsgenieNotificationChannelList) DeepCopyInto(out *OpsgenieNotificationChannelList) {
+func (in *SlackNotificationChannelList) DeepCopyInto(out *SlackNotificationChannelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OpsgenieNotificationChannel, len(*in))
+		*out = make([]SlackNotificationChannel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -380,18 +1459,18 @@ func (in *OpsgenieNotificationChannelList) DeepCopyInto(out *OpsgenieNotificatio
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelList.
-func (in *OpsgenieNotificationChannelList) DeepCopy() *OpsgenieNotificationChannelList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelList.
+func (in *SlackNotificationChannelList) DeepCopy() *SlackNotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsgenieNotificationChannelList)
+	out := new(SlackNotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpsgenieNotificationChannelList) DeepCopyObject() runtime.Object {
+func (in *SlackNotificationChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -399,23 +1478,8 @@ func (in *OpsgenieNotificationChannelList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsgenieNotificationChannelSpec) DeepCopyInto(out *OpsgenieNotificationChannelSpec) {
+func (in *SlackNotificationChannelSpec) DeepCopyInto(out *SlackNotificationChannelSpec) {
 	*out = *in
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Recipients != nil {
-		in, out := &in.Recipients, &out.Recipients
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	if in.PolicySelector != nil {
 		in, out := &in.PolicySelector, &out.PolicySelector
 		*out = make(labels.Set, len(*in))
@@ -426,18 +1490,144 @@ func (in *OpsgenieNotificationChannelSpec) DeepCopyInto(out *OpsgenieNotificatio
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelSpec.
-func (in *OpsgenieNotificationChannelSpec) DeepCopy() *OpsgenieNotificationChannelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelSpec.
+func (in *SlackNotificationChannelSpec) DeepCopy() *SlackNotificationChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsgenieNotificationChannelSpec)
+	out := new(SlackNotificationChannelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SlackNotificationChannel) DeepCopyInto(out *SlackNotificationChannel) {
+func (in *SyntheticsCondition) DeepCopyInto(out *SyntheticsCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocationThresholds != nil {
+		in, out := &in.LocationThresholds, &out.LocationThresholds
+		*out = new(LocationThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticsCondition.
+func (in *SyntheticsCondition) DeepCopy() *SyntheticsCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticsCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Threshold) DeepCopyInto(out *Threshold) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Threshold.
+func (in *Threshold) DeepCopy() *Threshold {
+	if in == nil {
+		return nil
+	}
+	out := new(Threshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnresolvedEntity) DeepCopyInto(out *UnresolvedEntity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnresolvedEntity.
+func (in *UnresolvedEntity) DeepCopy() *UnresolvedEntity {
+	if in == nil {
+		return nil
+	}
+	out := new(UnresolvedEntity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDefined) DeepCopyInto(out *UserDefined) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDefined.
+func (in *UserDefined) DeepCopy() *UserDefined {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDefined)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookHeader) DeepCopyInto(out *WebhookHeader) {
+	*out = *in
+	if in.ValueSecretRef != nil {
+		in, out := &in.ValueSecretRef, &out.ValueSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookHeader.
+func (in *WebhookHeader) DeepCopy() *WebhookHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotificationChannel) DeepCopyInto(out *WebhookNotificationChannel) {
 	*out = *in
 	in.AbstractNotificationChannel.DeepCopyInto(&out.AbstractNotificationChannel)
 	out.TypeMeta = in.TypeMeta
@@ -446,18 +1636,18 @@ func (in *SlackNotificationChannel) DeepCopyInto(out *SlackNotificationChannel)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannel.
-func (in *SlackNotificationChannel) DeepCopy() *SlackNotificationChannel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationChannel.
+func (in *WebhookNotificationChannel) DeepCopy() *WebhookNotificationChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(SlackNotificationChannel)
+	out := new(WebhookNotificationChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SlackNotificationChannel) DeepCopyObject() runtime.Object {
+func (in *WebhookNotificationChannel) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -465,13 +1655,13 @@ func (in *SlackNotificationChannel) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SlackNotificationChannelList) DeepCopyInto(out *SlackNotificationChannelList) {
+func (in *WebhookNotificationChannelList) DeepCopyInto(out *WebhookNotificationChannelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SlackNotificationChannel, len(*in))
+		*out = make([]WebhookNotificationChannel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -479,18 +1669,18 @@ func (in *SlackNotificationChannelList) DeepCopyInto(out *SlackNotificationChann
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelList.
-func (in *SlackNotificationChannelList) DeepCopy() *SlackNotificationChannelList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationChannelList.
+func (in *WebhookNotificationChannelList) DeepCopy() *WebhookNotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(SlackNotificationChannelList)
+	out := new(WebhookNotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SlackNotificationChannelList) DeepCopyObject() runtime.Object {
+func (in *WebhookNotificationChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -498,8 +1688,30 @@ func (in *SlackNotificationChannelList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SlackNotificationChannelSpec) DeepCopyInto(out *SlackNotificationChannelSpec) {
+func (in *WebhookNotificationChannelSpec) DeepCopyInto(out *WebhookNotificationChannelSpec) {
 	*out = *in
+	if in.AuthPasswordSecretRef != nil {
+		in, out := &in.AuthPasswordSecretRef, &out.AuthPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]WebhookHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PayloadType != nil {
+		in, out := &in.PayloadType, &out.PayloadType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PolicySelector != nil {
 		in, out := &in.PolicySelector, &out.PolicySelector
 		*out = make(labels.Set, len(*in))
@@ -510,44 +1722,12 @@ func (in *SlackNotificationChannelSpec) DeepCopyInto(out *SlackNotificationChann
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelSpec.
-func (in *SlackNotificationChannelSpec) DeepCopy() *SlackNotificationChannelSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(SlackNotificationChannelSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Threshold) DeepCopyInto(out *Threshold) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Threshold.
-func (in *Threshold) DeepCopy() *Threshold {
-	if in == nil {
-		return nil
-	}
-	out := new(Threshold)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UserDefined) DeepCopyInto(out *UserDefined) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDefined.
-func (in *UserDefined) DeepCopy() *UserDefined {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationChannelSpec.
+func (in *WebhookNotificationChannelSpec) DeepCopy() *WebhookNotificationChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(UserDefined)
+	out := new(WebhookNotificationChannelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
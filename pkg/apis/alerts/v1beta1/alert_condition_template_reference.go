@@ -0,0 +1,17 @@
+package v1beta1
+
+// AlertConditionTemplateReference includes the conditions of a template in a policy
+type AlertConditionTemplateReference struct {
+	// The kind of the template. Available options are: \
+	// - `AlertConditionTemplate` - a template in the namespace of the policy \
+	// - `ClusterAlertConditionTemplate` \
+	// Defaults to `AlertConditionTemplate`
+	// +kubebuilder:validation:Enum=AlertConditionTemplate;ClusterAlertConditionTemplate
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+	// The name of the template
+	Name string `json:"name"`
+	// The values of the template parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
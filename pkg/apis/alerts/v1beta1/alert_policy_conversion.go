@@ -0,0 +1,399 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// v1alpha1 spells the infra comparison `bellow`
+const (
+	comparisonBelow       = "below"
+	comparisonBelowAlpha1 = "bellow"
+)
+
+// blank assignment to verify that AlertPolicy implements conversion.Convertible
+var _ conversion.Convertible = &AlertPolicy{}
+
+// ConvertTo converts the policy to the v1alpha1 storage version
+func (policy *AlertPolicy) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.AlertPolicy)
+	dst.ObjectMeta = policy.ObjectMeta
+	dst.Spec = v1alpha1.AlertPolicySpec{
+		Name:                      policy.Spec.Name,
+		IncidentPreference:        policy.Spec.IncidentPreference,
+		ApmConditions:             apmConditionsToHub(policy.Spec.ApmConditions),
+		NrqlConditions:            nrqlConditionsToHub(policy.Spec.NrqlConditions),
+		InfraConditions:           infraConditionsToHub(policy.Spec.InfraConditions),
+		SyntheticsConditions:      syntheticsConditionsToHub(policy.Spec.SyntheticsConditions),
+		ExternalServiceConditions: externalServiceConditionsToHub(policy.Spec.ExternalServiceConditions),
+		Templates:                 templatesToHub(policy.Spec.Templates),
+	}
+	dst.Status = v1alpha1.AlertPolicyStatus{
+		Status:             policy.Status.Status,
+		SaveOutcome:        policy.Status.SaveOutcome,
+		UnresolvedEntities: unresolvedEntitiesToHub(policy.Status.UnresolvedEntities),
+		ResolvedEntities:   resolvedEntitiesToHub(policy.Status.ResolvedEntities),
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the policy from the v1alpha1 storage version
+func (policy *AlertPolicy) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.AlertPolicy)
+	policy.ObjectMeta = src.ObjectMeta
+	policy.Spec = AlertPolicySpec{
+		Name:                      src.Spec.Name,
+		IncidentPreference:        src.Spec.IncidentPreference,
+		ApmConditions:             apmConditionsFromHub(src.Spec.ApmConditions),
+		NrqlConditions:            nrqlConditionsFromHub(src.Spec.NrqlConditions),
+		InfraConditions:           infraConditionsFromHub(src.Spec.InfraConditions),
+		SyntheticsConditions:      syntheticsConditionsFromHub(src.Spec.SyntheticsConditions),
+		ExternalServiceConditions: externalServiceConditionsFromHub(src.Spec.ExternalServiceConditions),
+		Templates:                 templatesFromHub(src.Spec.Templates),
+	}
+	policy.Status = AlertPolicyStatus{
+		Status:             src.Status.Status,
+		SaveOutcome:        src.Status.SaveOutcome,
+		UnresolvedEntities: unresolvedEntitiesFromHub(src.Status.UnresolvedEntities),
+		ResolvedEntities:   resolvedEntitiesFromHub(src.Status.ResolvedEntities),
+	}
+
+	return nil
+}
+
+func unresolvedEntitiesToHub(entities []UnresolvedEntity) []v1alpha1.UnresolvedEntity {
+	if entities == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.UnresolvedEntity, len(entities))
+	for i, entity := range entities {
+		result[i] = v1alpha1.UnresolvedEntity(entity)
+	}
+
+	return result
+}
+
+func unresolvedEntitiesFromHub(entities []v1alpha1.UnresolvedEntity) []UnresolvedEntity {
+	if entities == nil {
+		return nil
+	}
+
+	result := make([]UnresolvedEntity, len(entities))
+	for i, entity := range entities {
+		result[i] = UnresolvedEntity(entity)
+	}
+
+	return result
+}
+
+func resolvedEntitiesToHub(entities []ResolvedEntity) []v1alpha1.ResolvedEntity {
+	if entities == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.ResolvedEntity, len(entities))
+	for i, entity := range entities {
+		result[i] = v1alpha1.ResolvedEntity(entity)
+	}
+
+	return result
+}
+
+func resolvedEntitiesFromHub(entities []v1alpha1.ResolvedEntity) []ResolvedEntity {
+	if entities == nil {
+		return nil
+	}
+
+	result := make([]ResolvedEntity, len(entities))
+	for i, entity := range entities {
+		result[i] = ResolvedEntity(entity)
+	}
+
+	return result
+}
+
+func templatesToHub(templates []AlertConditionTemplateReference) []v1alpha1.AlertConditionTemplateReference {
+	if templates == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.AlertConditionTemplateReference, len(templates))
+	for i, template := range templates {
+		result[i] = v1alpha1.AlertConditionTemplateReference(template)
+	}
+
+	return result
+}
+
+func templatesFromHub(templates []v1alpha1.AlertConditionTemplateReference) []AlertConditionTemplateReference {
+	if templates == nil {
+		return nil
+	}
+
+	result := make([]AlertConditionTemplateReference, len(templates))
+	for i, template := range templates {
+		result[i] = AlertConditionTemplateReference(template)
+	}
+
+	return result
+}
+
+func apmConditionsToHub(conditions []ApmCondition) []v1alpha1.ApmCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.ApmCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = v1alpha1.ApmCondition{
+			Name:                condition.Name,
+			Type:                condition.Type,
+			Enabled:             condition.Enabled,
+			ConditionScope:      condition.ConditionScope,
+			Entities:            condition.Entities,
+			EntitySelectors:     condition.EntitySelectors,
+			MissingEntities:     condition.MissingEntities,
+			ViolationCloseTimer: condition.ViolationCloseTimer,
+			RunbookUrl:          condition.RunbookUrl,
+			Metric:              condition.Metric,
+			CriticalThreshold:   v1alpha1.Threshold(condition.CriticalThreshold),
+			WarningThreshold:    (*v1alpha1.Threshold)(condition.WarningThreshold),
+			UserDefined:         (*v1alpha1.UserDefined)(condition.UserDefined),
+		}
+	}
+
+	return result
+}
+
+func apmConditionsFromHub(conditions []v1alpha1.ApmCondition) []ApmCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]ApmCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = ApmCondition{
+			Name:                condition.Name,
+			Type:                condition.Type,
+			Enabled:             condition.Enabled,
+			ConditionScope:      condition.ConditionScope,
+			Entities:            condition.Entities,
+			EntitySelectors:     condition.EntitySelectors,
+			MissingEntities:     condition.MissingEntities,
+			ViolationCloseTimer: condition.ViolationCloseTimer,
+			RunbookUrl:          condition.RunbookUrl,
+			Metric:              condition.Metric,
+			CriticalThreshold:   Threshold(condition.CriticalThreshold),
+			WarningThreshold:    (*Threshold)(condition.WarningThreshold),
+			UserDefined:         (*UserDefined)(condition.UserDefined),
+		}
+	}
+
+	return result
+}
+
+func externalServiceConditionsToHub(conditions []ExternalServiceCondition) []v1alpha1.ExternalServiceCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.ExternalServiceCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = v1alpha1.ExternalServiceCondition{
+			Name:               condition.Name,
+			Enabled:            condition.Enabled,
+			Entities:           condition.Entities,
+			MissingEntities:    condition.MissingEntities,
+			ExternalServiceUrl: condition.ExternalServiceUrl,
+			Metric:             condition.Metric,
+			RunbookUrl:         condition.RunbookUrl,
+			CriticalThreshold:  v1alpha1.Threshold(condition.CriticalThreshold),
+			WarningThreshold:   (*v1alpha1.Threshold)(condition.WarningThreshold),
+		}
+	}
+
+	return result
+}
+
+func externalServiceConditionsFromHub(conditions []v1alpha1.ExternalServiceCondition) []ExternalServiceCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]ExternalServiceCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = ExternalServiceCondition{
+			Name:               condition.Name,
+			Enabled:            condition.Enabled,
+			Entities:           condition.Entities,
+			MissingEntities:    condition.MissingEntities,
+			ExternalServiceUrl: condition.ExternalServiceUrl,
+			Metric:             condition.Metric,
+			RunbookUrl:         condition.RunbookUrl,
+			CriticalThreshold:  Threshold(condition.CriticalThreshold),
+			WarningThreshold:   (*Threshold)(condition.WarningThreshold),
+		}
+	}
+
+	return result
+}
+
+func nrqlConditionsToHub(conditions []NrqlCondition) []v1alpha1.NrqlCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.NrqlCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = v1alpha1.NrqlCondition{
+			Name:              condition.Name,
+			Enabled:           condition.Enabled,
+			Type:              condition.Type,
+			BaselineDirection: condition.BaselineDirection,
+			ExpectedGroups:    condition.ExpectedGroups,
+			IgnoreOverlap:     condition.IgnoreOverlap,
+			Query:             condition.Query,
+			Since:             condition.Since,
+			ValueFunction:     condition.ValueFunction,
+			AlertThreshold:    v1alpha1.Threshold(condition.CriticalThreshold),
+			WarningThreshold:  (*v1alpha1.Threshold)(condition.WarningThreshold),
+			RunbookUrl:        condition.RunbookUrl,
+			Signal:            (*v1alpha1.NrqlSignal)(condition.Signal),
+			Expiration:        (*v1alpha1.NrqlExpiration)(condition.Expiration),
+		}
+	}
+
+	return result
+}
+
+func nrqlConditionsFromHub(conditions []v1alpha1.NrqlCondition) []NrqlCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]NrqlCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = NrqlCondition{
+			Name:              condition.Name,
+			Enabled:           condition.Enabled,
+			Type:              condition.Type,
+			BaselineDirection: condition.BaselineDirection,
+			ExpectedGroups:    condition.ExpectedGroups,
+			IgnoreOverlap:     condition.IgnoreOverlap,
+			Query:             condition.Query,
+			Since:             condition.Since,
+			ValueFunction:     condition.ValueFunction,
+			CriticalThreshold: Threshold(condition.AlertThreshold),
+			WarningThreshold:  (*Threshold)(condition.WarningThreshold),
+			RunbookUrl:        condition.RunbookUrl,
+			Signal:            (*NrqlSignal)(condition.Signal),
+			Expiration:        (*NrqlExpiration)(condition.Expiration),
+		}
+	}
+
+	return result
+}
+
+func infraConditionsToHub(conditions []InfraCondition) []v1alpha1.InfraCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.InfraCondition, len(conditions))
+	for i, condition := range conditions {
+		comparison := condition.Comparison
+		if comparison == comparisonBelow {
+			comparison = comparisonBelowAlpha1
+		}
+
+		result[i] = v1alpha1.InfraCondition{
+			Name:                condition.Name,
+			Type:                condition.Type,
+			Comparison:          comparison,
+			CriticalThreshold:   v1alpha1.InfraThreshold(condition.CriticalThreshold),
+			WarningThreshold:    (*v1alpha1.InfraThreshold)(condition.WarningThreshold),
+			Enabled:             condition.Enabled,
+			EventType:           condition.EventType,
+			IntegrationProvider: condition.IntegrationProvider,
+			RunbookUrl:          condition.RunbookUrl,
+			SelectValue:         condition.SelectValue,
+			ViolationCloseTimer: condition.ViolationCloseTimer,
+			WhereClause:         condition.WhereClause,
+			ProcessWhereClause:  condition.ProcessWhereClause,
+		}
+	}
+
+	return result
+}
+
+func infraConditionsFromHub(conditions []v1alpha1.InfraCondition) []InfraCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]InfraCondition, len(conditions))
+	for i, condition := range conditions {
+		comparison := condition.Comparison
+		if comparison == comparisonBelowAlpha1 {
+			comparison = comparisonBelow
+		}
+
+		result[i] = InfraCondition{
+			Name:                condition.Name,
+			Type:                condition.Type,
+			Comparison:          comparison,
+			CriticalThreshold:   InfraThreshold(condition.CriticalThreshold),
+			WarningThreshold:    (*InfraThreshold)(condition.WarningThreshold),
+			Enabled:             condition.Enabled,
+			EventType:           condition.EventType,
+			IntegrationProvider: condition.IntegrationProvider,
+			RunbookUrl:          condition.RunbookUrl,
+			SelectValue:         condition.SelectValue,
+			ViolationCloseTimer: condition.ViolationCloseTimer,
+			WhereClause:         condition.WhereClause,
+			ProcessWhereClause:  condition.ProcessWhereClause,
+		}
+	}
+
+	return result
+}
+
+func syntheticsConditionsToHub(conditions []SyntheticsCondition) []v1alpha1.SyntheticsCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.SyntheticsCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = v1alpha1.SyntheticsCondition{
+			Name:               condition.Name,
+			Enabled:            condition.Enabled,
+			Monitors:           condition.Monitors,
+			LocationThresholds: (*v1alpha1.LocationThresholds)(condition.LocationThresholds),
+			RunbookUrl:         condition.RunbookUrl,
+		}
+	}
+
+	return result
+}
+
+func syntheticsConditionsFromHub(conditions []v1alpha1.SyntheticsCondition) []SyntheticsCondition {
+	if conditions == nil {
+		return nil
+	}
+
+	result := make([]SyntheticsCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = SyntheticsCondition{
+			Name:               condition.Name,
+			Enabled:            condition.Enabled,
+			Monitors:           condition.Monitors,
+			LocationThresholds: (*LocationThresholds)(condition.LocationThresholds),
+			RunbookUrl:         condition.RunbookUrl,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+)
+
+type ApmCondition struct {
+	// The name of the alert condition that will be created in New Relic
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=apm_app_metric;apm_kt_metric;apm_jvm_metric;browser_metric;mobile_metric
+	// The type of the metric to monitor. Should be one of: \
+	// - `apm_app_metric` \
+	// - `apm_kt_metric` \
+	// - `apm_jvm_metric` \
+	// - `browser_metric` \
+	// - `mobile_metric` \
+	// Please refer to the Alerts conditions section in the [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#type) for more details
+	Type string `json:"type"`
+	// +optional
+	// +default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// +kubebuilder:validation:Enum=instance;application
+	// +optional
+	ConditionScope *string `json:"conditionScope,omitempty"`
+	// A list of application names from APM to monitor. \
+	// Either entities or entitySelectors, or both, should be set
+	// +optional
+	Entities []string `json:"entities,omitempty"`
+	// Selects the applications to monitor by name, by a pattern of their name or by label. \
+	// The selected applications are added to the entities and looked up again on every resync
+	// +optional
+	EntitySelectors []v1alpha1.EntitySelector `json:"entitySelectors,omitempty"`
+	// What to do when an application in entities does not exist in New Relic. \
+	// Can be one of: \
+	// - `fail` - the policy is not saved until the application exists \
+	// - `skip` - the condition is created for the remaining applications, or left unchanged when none of them exist \
+	// - `wait` - the condition is left unchanged until all applications exist \
+	// Missing applications are listed in the status of the policy, and are looked up again periodically. \
+	// Defaults to `fail`
+	// +kubebuilder:validation:Enum=fail;skip;wait
+	// +optional
+	MissingEntities *string `json:"missingEntities,omitempty"`
+	// +optional
+	ViolationCloseTimer int `json:"violationCloseTimer,omitempty"`
+	// +optional
+	RunbookUrl string `json:"runbookUrl,omitempty"`
+	// The APM metric to monitor. Different metrics can be applied depending on the condition type. \
+	// An example of a valid (type, metric) combination is (apm_app_metric, apdex). \
+	// Please refer to the Alerts conditions section in the [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#metric) for more details
+	Metric string `json:"metric"`
+	// Once the criticalThreshold is breached, a critical incident will be generated
+	CriticalThreshold Threshold `json:"criticalThreshold"`
+	// Once the warningThreshold is breached, a warning will be generated
+	// +optional
+	WarningThreshold *Threshold `json:"warningThreshold,omitempty"`
+	// Used for tracking a user defined custom metric \
+	// For more information, please refer to the [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#user_defined_metric)
+	// +optional
+	UserDefined *UserDefined `json:"userDefined,omitempty"`
+}
+
+type UserDefined struct {
+	// The name of the user defined custom metric
+	Metric string `json:"metric"`
+	// Available options are: \
+	// - `average` \
+	// - `min` \
+	// - `max` \
+	// - `total` \
+	// - `sample_size` \
+	// For more information, please refer to the official [New Relic documentation](https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#user_defined_value_function)
+	// +kubebuilder:validation:Enum=average;min;max;total;sample_size
+	ValueFunction string `json:"valueFunction"`
+}
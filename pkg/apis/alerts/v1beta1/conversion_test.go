@@ -0,0 +1,455 @@
+package v1beta1_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1beta1"
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+func TestAlertPolicy_RoundTripFromHub(t *testing.T) {
+	original := newHubPolicy()
+
+	var beta v1beta1.AlertPolicy
+	if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	var result v1alpha1.AlertPolicy
+	if err := beta.ConvertTo(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestAlertPolicy_RoundTripToHub(t *testing.T) {
+	original := newPolicy()
+
+	var alpha v1alpha1.AlertPolicy
+	if err := original.DeepCopy().ConvertTo(&alpha); err != nil {
+		t.Fatal(err)
+	}
+	var result v1beta1.AlertPolicy
+	if err := result.ConvertFrom(&alpha); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestAlertPolicy_ConvertFrom_RenamesFields(t *testing.T) {
+	var beta v1beta1.AlertPolicy
+	if err := beta.ConvertFrom(newHubPolicy()); err != nil {
+		t.Fatal(err)
+	}
+
+	if beta.Spec.InfraConditions[0].Comparison != "below" {
+		t.Errorf("Expected comparison below, got %s", beta.Spec.InfraConditions[0].Comparison)
+	}
+
+	content, err := yaml.Marshal(beta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"incidentPreference:", "criticalThreshold:", "valueFunction: average"} {
+		if !strings.Contains(string(content), field) {
+			t.Errorf("Expected %s in\n%s", field, content)
+		}
+	}
+	for _, field := range []string{"incident_preference:", "alertThreshold:", "value_function:"} {
+		if strings.Contains(string(content), field) {
+			t.Errorf("Expected no %s in\n%s", field, content)
+		}
+	}
+}
+
+func TestAlertPolicy_RoundTripExamples(t *testing.T) {
+	files, err := filepath.Glob("../../../../hack/examples/alertpolicy_*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("Expected example policies")
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var original v1alpha1.AlertPolicy
+		if err := yaml.Unmarshal(content, &original); err != nil {
+			t.Fatalf("Error parsing %s: %v", file, err)
+		}
+
+		var beta v1beta1.AlertPolicy
+		if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatal(err)
+		}
+		var result v1alpha1.AlertPolicy
+		if err := beta.ConvertTo(&result); err != nil {
+			t.Fatal(err)
+		}
+
+		result.TypeMeta = original.TypeMeta
+		assertEqual(t, &original, &result)
+	}
+}
+
+func TestSlackNotificationChannel_RoundTrip(t *testing.T) {
+	original := &v1alpha1.SlackNotificationChannel{
+		ObjectMeta: newObjectMeta(),
+		Spec: v1alpha1.SlackNotificationChannelSpec{
+			Name:           "my-channel",
+			Url:            "https://hooks.slack.com/services/T0/B0/X",
+			Channel:        "#alerts",
+			PolicySelector: map[string]string{"team": "platform"},
+		},
+	}
+	original.Status = v1alpha1.NewChannelReady(int64Ptr(10), "abc")
+
+	var beta v1beta1.SlackNotificationChannel
+	if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	var result v1alpha1.SlackNotificationChannel
+	if err := beta.ConvertTo(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestEmailNotificationChannel_RoundTrip(t *testing.T) {
+	original := &v1alpha1.EmailNotificationChannel{
+		ObjectMeta: newObjectMeta(),
+		Spec: v1alpha1.EmailNotificationChannelSpec{
+			Name:                   "my-channel",
+			Recipients:             "a@example.com,b@example.com",
+			IncludeJsonAttachments: true,
+			PolicySelector:         map[string]string{"team": "platform"},
+		},
+	}
+	original.Status = v1alpha1.NewChannelReady(int64Ptr(10), "abc")
+
+	var beta v1beta1.EmailNotificationChannel
+	if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	var result v1alpha1.EmailNotificationChannel
+	if err := beta.ConvertTo(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestOpsgenieNotificationChannel_RoundTrip(t *testing.T) {
+	original := &v1beta1.OpsgenieNotificationChannel{
+		ObjectMeta: newObjectMeta(),
+		Spec: v1beta1.OpsgenieNotificationChannelSpec{
+			Name: "my-channel",
+			ApiKeySecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "opsgenie"},
+				Key:                  "apiKey",
+			},
+			ApiKey:         "inline-key",
+			Teams:          []string{"team-1"},
+			Tags:           []string{"tag-1", "tag-2"},
+			Recipients:     []string{"a@example.com"},
+			PolicySelector: map[string]string{"team": "platform"},
+		},
+		Status: v1beta1.NotificationChannelStatus{
+			Status:                common.NewError(int64Ptr(10), errString("unauthorized")),
+			NewrelicConfigVersion: "abc",
+		},
+	}
+
+	var alpha v1alpha1.OpsgenieNotificationChannel
+	if err := original.DeepCopy().ConvertTo(&alpha); err != nil {
+		t.Fatal(err)
+	}
+	if alpha.Spec.ApiKey != "inline-key" || alpha.Spec.ApiKeySecretRef == nil {
+		t.Errorf("Expected the API key and the secret reference to be kept, got %+v", alpha.Spec)
+	}
+
+	var result v1beta1.OpsgenieNotificationChannel
+	if err := result.ConvertFrom(&alpha); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func assertEqual(t *testing.T, expected interface{}, actual interface{}) {
+	t.Helper()
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		expectedYaml, _ := yaml.Marshal(expected)
+		actualYaml, _ := yaml.Marshal(actual)
+		t.Errorf("Round trip changed the object.\nExpected:\n%s\nGot:\n%s", expectedYaml, actualYaml)
+	}
+}
+
+type errString string
+
+func (err errString) Error() string {
+	return string(err)
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func newObjectMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            "my-resource",
+		Namespace:       "default",
+		Labels:          map[string]string{"team": "platform"},
+		Annotations:     map[string]string{"owner": "platform"},
+		Finalizers:      []string{"newrelic"},
+		Generation:      3,
+		ResourceVersion: "42",
+	}
+}
+
+func newHubPolicy() *v1alpha1.AlertPolicy {
+	policy := &v1alpha1.AlertPolicy{
+		ObjectMeta: newObjectMeta(),
+		Spec: v1alpha1.AlertPolicySpec{
+			Name:               "my-policy",
+			IncidentPreference: "per_condition",
+			ApmConditions: []v1alpha1.ApmCondition{
+				{
+					Name:                "error-rate",
+					Type:                "apm_app_metric",
+					Enabled:             boolPtr(false),
+					ConditionScope:      stringPtr("instance"),
+					MissingEntities:     stringPtr("skip"),
+					Entities:            []string{"my-app"},
+					EntitySelectors:     []common.EntitySelector{{NamePattern: "payments-.*"}, {Label: "Team:Payments"}},
+					ViolationCloseTimer: 24,
+					RunbookUrl:          "https://example.com/runbook",
+					Metric:              "user_defined",
+					CriticalThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "80",
+						DurationMinutes: 5,
+					},
+					WarningThreshold: &v1alpha1.Threshold{
+						TimeFunction:    "any",
+						Operator:        "above",
+						Value:           "50",
+						DurationMinutes: 10,
+					},
+					UserDefined: &v1alpha1.UserDefined{
+						Metric:        "Custom/Metric",
+						ValueFunction: "average",
+					},
+				},
+			},
+			NrqlConditions: []v1alpha1.NrqlCondition{
+				{
+					Name:          "errors",
+					Enabled:       boolPtr(true),
+					Query:         "SELECT count(*) FROM TransactionError",
+					Since:         5,
+					ValueFunction: "sum",
+					AlertThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "below",
+						Value:           "10",
+						DurationMinutes: 5,
+					},
+					WarningThreshold: &v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "below",
+						Value:           "20",
+						DurationMinutes: 5,
+					},
+					RunbookUrl: "https://example.com/runbook",
+					Signal: &v1alpha1.NrqlSignal{
+						AggregationWindow: intPtr(120),
+						AggregationMethod: stringPtr("event_timer"),
+						AggregationTimer:  intPtr(60),
+						FillOption:        stringPtr("static"),
+						FillValue:         stringPtr("0"),
+					},
+					Expiration: &v1alpha1.NrqlExpiration{
+						ExpirationDuration:          intPtr(600),
+						CloseViolationsOnExpiration: boolPtr(true),
+					},
+				},
+				{
+					Name:              "throughput",
+					Type:              stringPtr("baseline"),
+					BaselineDirection: stringPtr("upper_and_lower"),
+					Query:             "SELECT count(*) FROM Transaction",
+					Since:             3,
+					ValueFunction:     "single_value",
+					AlertThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "3",
+						DurationMinutes: 5,
+					},
+				},
+				{
+					Name:           "pod-cpu",
+					Type:           stringPtr("outlier"),
+					ExpectedGroups: intPtr(3),
+					IgnoreOverlap:  boolPtr(true),
+					Query:          "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET podName",
+					Since:          5,
+					ValueFunction:  "single_value",
+					AlertThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "2",
+						DurationMinutes: 5,
+					},
+				},
+			},
+			InfraConditions: []v1alpha1.InfraCondition{
+				{
+					Name:       "queue-age",
+					Comparison: "bellow",
+					CriticalThreshold: v1alpha1.InfraThreshold{
+						TimeFunction:    "all",
+						Value:           10,
+						DurationMinutes: 5,
+					},
+					WarningThreshold: &v1alpha1.InfraThreshold{
+						TimeFunction:    "any",
+						Value:           20,
+						DurationMinutes: 5,
+					},
+					Enabled:             boolPtr(true),
+					EventType:           "QueueSample",
+					IntegrationProvider: "SqsQueue",
+					RunbookUrl:          "https://example.com/runbook",
+					SelectValue:         "provider.approximateAgeOfOldestMessage.Average",
+					ViolationCloseTimer: 24,
+					WhereClause:         "queueName = 'jobs'",
+				},
+				{
+					Name:       "nginx-down",
+					Type:       stringPtr("infra_process_running"),
+					Comparison: "equal",
+					CriticalThreshold: v1alpha1.InfraThreshold{
+						Value:           0,
+						DurationMinutes: 5,
+					},
+					WhereClause:        "hostname LIKE 'web-%'",
+					ProcessWhereClause: "commandName = 'nginx'",
+				},
+				{
+					Name: "host-down",
+					Type: stringPtr("infra_host_not_reporting"),
+					CriticalThreshold: v1alpha1.InfraThreshold{
+						DurationMinutes: 10,
+					},
+				},
+			},
+			SyntheticsConditions: []v1alpha1.SyntheticsCondition{
+				{
+					Name:       "homepage-down",
+					Enabled:    boolPtr(true),
+					Monitors:   []string{"homepage"},
+					RunbookUrl: "https://example.com/runbook",
+				},
+				{
+					Name:     "checkout-down",
+					Monitors: []string{"checkout", "checkout-eu"},
+					LocationThresholds: &v1alpha1.LocationThresholds{
+						Critical: 3,
+						Warning:  intPtr(1),
+					},
+				},
+			},
+			ExternalServiceConditions: []v1alpha1.ExternalServiceCondition{
+				{
+					Name:               "payments-slow",
+					Enabled:            boolPtr(true),
+					Entities:           []string{"checkout"},
+					MissingEntities:    stringPtr("skip"),
+					ExternalServiceUrl: "api.stripe.com",
+					Metric:             "response_time_average",
+					RunbookUrl:         "https://example.com/runbook",
+					CriticalThreshold: v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "2",
+						DurationMinutes: 5,
+					},
+					WarningThreshold: &v1alpha1.Threshold{
+						TimeFunction:    "all",
+						Operator:        "above",
+						Value:           "1",
+						DurationMinutes: 5,
+					},
+				},
+			},
+			Templates: []v1alpha1.AlertConditionTemplateReference{
+				{
+					Name:       "service-defaults",
+					Parameters: map[string]string{"appName": "checkout", "errorRate": "5"},
+				},
+				{
+					Kind: stringPtr("ClusterAlertConditionTemplate"),
+					Name: "kubernetes-defaults",
+				},
+			},
+		},
+	}
+	policy.Status = v1alpha1.NewPolicyError(
+		int64Ptr(10),
+		errString("timeout"),
+		v1alpha1.SaveOutcomeReverted,
+		[]v1alpha1.UnresolvedEntity{{Condition: "error-rate", Entity: "legacy-app"}},
+	).WithResolvedEntities(
+		[]v1alpha1.ResolvedEntity{{Condition: "error-rate", Entity: "namePattern=payments-.*", Kind: "application", Ids: []int{1, 2}}},
+	)
+
+	return policy
+}
+
+func newPolicy() *v1beta1.AlertPolicy {
+	var policy v1beta1.AlertPolicy
+	if err := policy.ConvertFrom(newHubPolicy()); err != nil {
+		panic(err)
+	}
+	policy.Spec.InfraConditions = append(policy.Spec.InfraConditions, v1beta1.InfraCondition{
+		Name:       "cpu",
+		Comparison: "above",
+		CriticalThreshold: v1beta1.InfraThreshold{
+			TimeFunction:    "all",
+			Value:           90,
+			DurationMinutes: 5,
+		},
+		IntegrationProvider: "Kubernetes",
+		SelectValue:         "cpuUsedCores",
+	})
+
+	return &policy
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,84 @@
+package v1beta1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/personio/newrelic-alert-manager/pkg/apis"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"testing"
+)
+
+func TestConversionWebhook_AlertPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	webhook := &conversion.Webhook{}
+	if err := webhook.InjectScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	review := []byte(`{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind": "ConversionReview",
+		"request": {
+			"uid": "123",
+			"desiredAPIVersion": "alerts.newrelic.io/v1beta1",
+			"objects": [{
+				"apiVersion": "alerts.newrelic.io/v1alpha1",
+				"kind": "AlertPolicy",
+				"metadata": {"name": "my-policy", "namespace": "default"},
+				"spec": {
+					"name": "my-policy",
+					"incident_preference": "per_policy",
+					"infraConditions": [{
+						"name": "cpu",
+						"comparison": "bellow",
+						"integrationProvider": "Kubernetes",
+						"selectValue": "cpuUsedCores",
+						"alertThreshold": {"timeFunction": "all", "value": 1, "durationMinutes": 5}
+					}]
+				}
+			}]
+		}
+	}`)
+
+	recorder := httptest.NewRecorder()
+	webhook.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(review)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var response struct {
+		Response struct {
+			Result           struct{ Status string }
+			ConvertedObjects []struct {
+				ApiVersion string `json:"apiVersion"`
+				Spec       struct {
+					IncidentPreference string `json:"incidentPreference"`
+					InfraConditions    []struct {
+						Comparison        string                 `json:"comparison"`
+						CriticalThreshold map[string]interface{} `json:"criticalThreshold"`
+					} `json:"infraConditions"`
+				} `json:"spec"`
+			} `json:"convertedObjects"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Response.Result.Status != "Success" || len(response.Response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected one converted object, got %s", recorder.Body)
+	}
+	converted := response.Response.ConvertedObjects[0]
+	if converted.ApiVersion != "alerts.newrelic.io/v1beta1" ||
+		converted.Spec.IncidentPreference != "per_policy" ||
+		converted.Spec.InfraConditions[0].Comparison != "below" ||
+		converted.Spec.InfraConditions[0].CriticalThreshold == nil {
+		t.Errorf("Unexpected conversion result %s", recorder.Body)
+	}
+}
@@ -0,0 +1,72 @@
+package v1beta1
+
+type InfraCondition struct {
+	// The name of the infra condition that will be created in New Relic
+	Name string `json:"name"`
+	// The type of the condition. Available options are: \
+	// - `infra_metric` - compares the selectValue of an event type or integration provider with the thresholds \
+	// - `infra_process_running` - compares the number of processes matching processWhereClause with the thresholds \
+	// - `infra_host_not_reporting` - opens a violation when a host stops reporting for the durationMinutes of the alertThreshold \
+	// Defaults to `infra_metric`
+	// +kubebuilder:validation:Enum=infra_metric;infra_process_running;infra_host_not_reporting
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// Required for `infra_metric` and `infra_process_running` conditions. Available options are: \
+	// - `above` \
+	// - `below` \
+	// - `equal` \
+	// +kubebuilder:validation:Enum=equal;above;below
+	// +optional
+	Comparison string `json:"comparison,omitempty"`
+	// Once the criticalThreshold is breached, a critical incident will be generated
+	CriticalThreshold InfraThreshold `json:"criticalThreshold"`
+	// Once the warningThreshold is breached, a warning will be generated
+	// +optional
+	WarningThreshold *InfraThreshold `json:"warningThreshold,omitempty"`
+	// +optional
+	// +default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// Leave this parameter empty when creating conditions based on data from an integration provider
+	// For more information, please refer to the `event_type` field in the official [New Relic documentation](https://docs.newrelic.com/docs/infrastructure/new-relic-infrastructure/infrastructure-alert-conditions/rest-api-calls-new-relic-infrastructure-alerts#definitions)
+	// +optional
+	EventType string `json:"eventType,omitempty"`
+	// When setting up alerts on integrations, specify the corresponding integration provider. \
+	// Examples can include SqsQueue, Kubernetes, RdsDbInstance etc. \
+	// For more information, please refer to the `integration_provider` field in the official [New Relic documentation](https://docs.newrelic.com/docs/infrastructure/new-relic-infrastructure/infrastructure-alert-conditions/rest-api-calls-new-relic-infrastructure-alerts#definitions)
+	// +optional
+	IntegrationProvider string `json:"integrationProvider,omitempty"`
+	// +optional
+	RunbookUrl string `json:"runbookUrl,omitempty"`
+	// The attribute name from the Event sample or Integration provider which identifies the metric to be tracked.
+	// Examples for Sqs include `provider.approximateAgeOfOldestMessage.Average` and `provider.numberOfEmptyReceives.Average`.
+	// For more information, please refer to the `select_value` field in the official [New Relic documentation](https://docs.newrelic.com/docs/infrastructure/new-relic-infrastructure/infrastructure-alert-conditions/rest-api-calls-new-relic-infrastructure-alerts#definitions)
+	// Required for `infra_metric` conditions
+	// +optional
+	SelectValue string `json:"selectValue,omitempty"`
+	// +optional
+	ViolationCloseTimer int `json:"violationCloseTimer,omitempty"`
+	// An expression used for filtering data from the IntegrationProvider, or the hosts of
+	// `infra_process_running` and `infra_host_not_reporting` conditions
+	WhereClause string `json:"whereClause,omitempty"`
+	// An expression selecting the processes to count in `infra_process_running` conditions,
+	// e.g. `commandName = 'nginx'`
+	// +optional
+	ProcessWhereClause string `json:"processWhereClause,omitempty"`
+}
+
+type InfraThreshold struct {
+	// Defines when the threshold should be considered as breached. \
+	// Available options are: \
+	// - `all` - all data points are in violation within the given period \
+	// - `any` - at least one data point is in violation within the given period \
+	// Required for `infra_metric` conditions
+	// +kubebuilder:validation:Enum=all;any
+	// +optional
+	TimeFunction string `json:"timeFunction,omitempty"`
+	// The value of the metric, or the number of processes, to compare with. \
+	// Not used by `infra_host_not_reporting` conditions
+	// +optional
+	Value int `json:"value"`
+	// For how long the violation should be active before an incident is triggered \
+	DurationMinutes int `json:"durationMinutes"`
+}
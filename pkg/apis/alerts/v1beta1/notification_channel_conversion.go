@@ -0,0 +1,89 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// blank assignments to verify that the channels implement conversion.Convertible
+var (
+	_ conversion.Convertible = &SlackNotificationChannel{}
+	_ conversion.Convertible = &EmailNotificationChannel{}
+	_ conversion.Convertible = &OpsgenieNotificationChannel{}
+)
+
+// ConvertTo converts the channel to the v1alpha1 storage version
+func (channel *SlackNotificationChannel) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.SlackNotificationChannel)
+	dst.ObjectMeta = channel.ObjectMeta
+	dst.Spec = v1alpha1.SlackNotificationChannelSpec(channel.Spec)
+	dst.Status = v1alpha1.NotificationChannelStatus(channel.Status)
+
+	return nil
+}
+
+// ConvertFrom converts the channel from the v1alpha1 storage version
+func (channel *SlackNotificationChannel) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.SlackNotificationChannel)
+	channel.ObjectMeta = src.ObjectMeta
+	channel.Spec = SlackNotificationChannelSpec(src.Spec)
+	channel.Status = NotificationChannelStatus(src.Status)
+
+	return nil
+}
+
+// ConvertTo converts the channel to the v1alpha1 storage version
+func (channel *EmailNotificationChannel) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.EmailNotificationChannel)
+	dst.ObjectMeta = channel.ObjectMeta
+	dst.Spec = v1alpha1.EmailNotificationChannelSpec(channel.Spec)
+	dst.Status = v1alpha1.NotificationChannelStatus(channel.Status)
+
+	return nil
+}
+
+// ConvertFrom converts the channel from the v1alpha1 storage version
+func (channel *EmailNotificationChannel) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.EmailNotificationChannel)
+	channel.ObjectMeta = src.ObjectMeta
+	channel.Spec = EmailNotificationChannelSpec(src.Spec)
+	channel.Status = NotificationChannelStatus(src.Status)
+
+	return nil
+}
+
+// ConvertTo converts the channel to the v1alpha1 storage version
+func (channel *OpsgenieNotificationChannel) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.OpsgenieNotificationChannel)
+	dst.ObjectMeta = channel.ObjectMeta
+	dst.Spec = v1alpha1.OpsgenieNotificationChannelSpec{
+		Name:            channel.Spec.Name,
+		ApiKey:          channel.Spec.ApiKey,
+		ApiKeySecretRef: channel.Spec.ApiKeySecretRef,
+		Teams:           channel.Spec.Teams,
+		Tags:            channel.Spec.Tags,
+		Recipients:      channel.Spec.Recipients,
+		PolicySelector:  channel.Spec.PolicySelector,
+	}
+	dst.Status = v1alpha1.NotificationChannelStatus(channel.Status)
+
+	return nil
+}
+
+// ConvertFrom converts the channel from the v1alpha1 storage version
+func (channel *OpsgenieNotificationChannel) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.OpsgenieNotificationChannel)
+	channel.ObjectMeta = src.ObjectMeta
+	channel.Spec = OpsgenieNotificationChannelSpec{
+		Name:            src.Spec.Name,
+		ApiKeySecretRef: src.Spec.ApiKeySecretRef,
+		ApiKey:          src.Spec.ApiKey,
+		Teams:           src.Spec.Teams,
+		Tags:            src.Spec.Tags,
+		Recipients:      src.Spec.Recipients,
+		PolicySelector:  src.Spec.PolicySelector,
+	}
+	channel.Status = NotificationChannelStatus(src.Status)
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+)
+
+// NotificationChannelStatus defines the observed state of NotificationChannel
+type NotificationChannelStatus struct {
+	v1alpha1.Status       `json:",inline"`
+	NewrelicConfigVersion string `json:"newrelicConfigVersion,omitempty"`
+}
@@ -0,0 +1,61 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OpsgenieNotificationChannel is the Schema for the OpsgenieNotificationChannels API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=opsgenienotificationchannels,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
+// +kubebuilder:printcolumn:name="Newrelic ID",type="string",JSONPath=".status.newrelicId",description="The New Relic ID of this channel"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this channel"
+type OpsgenieNotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsgenieNotificationChannelSpec `json:"spec,omitempty"`
+	Status NotificationChannelStatus       `json:"status,omitempty"`
+}
+
+// OpsgenieNotificationChannelSpec defines the desired state of OpsgenieNotificationChannel
+type OpsgenieNotificationChannelSpec struct {
+	// The name of the notification channel created in New Relic
+	Name string `json:"name"`
+	// A reference to a secret key holding the Opsgenie API Key. The secret must be in the namespace of the channel.
+	// If both apiKeySecretRef and apiKey are left empty, the default API key specified when deploying the operator will be used
+	// +optional
+	ApiKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+	// The Opsgenie API Key. Prefer apiKeySecretRef, so that the key is not stored in the resource
+	// +optional
+	ApiKey string `json:"apiKey,omitempty"`
+	// A list of teams
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+	// A list of tags
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// A comma-separated value of emails
+	// +optional
+	Recipients []string `json:"recipients,omitempty"`
+	// A label selector defining the alert policies covered by the notification channel
+	PolicySelector labels.Set `json:"policySelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OpsgenieNotificationChannelList contains a list of OpsgenieNotificationChannel
+type OpsgenieNotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsgenieNotificationChannel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsgenieNotificationChannel{}, &OpsgenieNotificationChannelList{})
+}
@@ -0,0 +1,50 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlackNotificationChannel is the Schema for the slacknotificationchannels API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=slacknotificationchannels,scope=Namespaced
+// +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.name",description="The New Relic name this channel"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this channel"
+// +kubebuilder:printcolumn:name="Newrelic ID",type="string",JSONPath=".status.newrelicId",description="The New Relic ID of this channel"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this channel"
+type SlackNotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlackNotificationChannelSpec `json:"spec,omitempty"`
+	Status NotificationChannelStatus    `json:"status,omitempty"`
+}
+
+// SlackNotificationChannelSpec defines the desired state of SlackNotificationChannel
+type SlackNotificationChannelSpec struct {
+	// The name of the notification channel created in New Relic
+	Name string `json:"name"`
+	// The Slack webhook URL.
+	// If left empty, the default URL specified when deploying the operator will be used
+	// +optional
+	Url string `json:"url,omitempty"`
+	// Name of the Slack channel. Should start with `#`
+	Channel string `json:"channel"`
+	// A label selector defining the alert policies covered by the notification channel
+	PolicySelector labels.Set `json:"policySelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SlackNotificationChannelList contains a list of SlackNotificationChannel
+type SlackNotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlackNotificationChannel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SlackNotificationChannel{}, &SlackNotificationChannelList{})
+}
@@ -0,0 +1,874 @@
+// +build !ignore_autogenerated
+
+// Code generated by operator-sdk. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1alpha1 "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConditionTemplateReference) DeepCopyInto(out *AlertConditionTemplateReference) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConditionTemplateReference.
+func (in *AlertConditionTemplateReference) DeepCopy() *AlertConditionTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConditionTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertPolicy) DeepCopyInto(out *AlertPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertPolicy.
+func (in *AlertPolicy) DeepCopy() *AlertPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertPolicyList) DeepCopyInto(out *AlertPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertPolicyList.
+func (in *AlertPolicyList) DeepCopy() *AlertPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertPolicySpec) DeepCopyInto(out *AlertPolicySpec) {
+	*out = *in
+	if in.ApmConditions != nil {
+		in, out := &in.ApmConditions, &out.ApmConditions
+		*out = make([]ApmCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NrqlConditions != nil {
+		in, out := &in.NrqlConditions, &out.NrqlConditions
+		*out = make([]NrqlCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InfraConditions != nil {
+		in, out := &in.InfraConditions, &out.InfraConditions
+		*out = make([]InfraCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyntheticsConditions != nil {
+		in, out := &in.SyntheticsConditions, &out.SyntheticsConditions
+		*out = make([]SyntheticsCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalServiceConditions != nil {
+		in, out := &in.ExternalServiceConditions, &out.ExternalServiceConditions
+		*out = make([]ExternalServiceCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]AlertConditionTemplateReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertPolicySpec.
+func (in *AlertPolicySpec) DeepCopy() *AlertPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertPolicyStatus) DeepCopyInto(out *AlertPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.UnresolvedEntities != nil {
+		in, out := &in.UnresolvedEntities, &out.UnresolvedEntities
+		*out = make([]UnresolvedEntity, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedEntities != nil {
+		in, out := &in.ResolvedEntities, &out.ResolvedEntities
+		*out = make([]ResolvedEntity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertPolicyStatus.
+func (in *AlertPolicyStatus) DeepCopy() *AlertPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApmCondition) DeepCopyInto(out *ApmCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConditionScope != nil {
+		in, out := &in.ConditionScope, &out.ConditionScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EntitySelectors != nil {
+		in, out := &in.EntitySelectors, &out.EntitySelectors
+		*out = make([]v1alpha1.EntitySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingEntities != nil {
+		in, out := &in.MissingEntities, &out.MissingEntities
+		*out = new(string)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	if in.UserDefined != nil {
+		in, out := &in.UserDefined, &out.UserDefined
+		*out = new(UserDefined)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApmCondition.
+func (in *ApmCondition) DeepCopy() *ApmCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ApmCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannel) DeepCopyInto(out *EmailNotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannel.
+func (in *EmailNotificationChannel) DeepCopy() *EmailNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannelList) DeepCopyInto(out *EmailNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmailNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelList.
+func (in *EmailNotificationChannelList) DeepCopy() *EmailNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationChannelSpec) DeepCopyInto(out *EmailNotificationChannelSpec) {
+	*out = *in
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationChannelSpec.
+func (in *EmailNotificationChannelSpec) DeepCopy() *EmailNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalServiceCondition) DeepCopyInto(out *ExternalServiceCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingEntities != nil {
+		in, out := &in.MissingEntities, &out.MissingEntities
+		*out = new(string)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalServiceCondition.
+func (in *ExternalServiceCondition) DeepCopy() *ExternalServiceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalServiceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraCondition) DeepCopyInto(out *InfraCondition) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(InfraThreshold)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraCondition.
+func (in *InfraCondition) DeepCopy() *InfraCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraThreshold) DeepCopyInto(out *InfraThreshold) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraThreshold.
+func (in *InfraThreshold) DeepCopy() *InfraThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationThresholds) DeepCopyInto(out *LocationThresholds) {
+	*out = *in
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationThresholds.
+func (in *LocationThresholds) DeepCopy() *LocationThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelStatus) DeepCopyInto(out *NotificationChannelStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelStatus.
+func (in *NotificationChannelStatus) DeepCopy() *NotificationChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlCondition) DeepCopyInto(out *NrqlCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.BaselineDirection != nil {
+		in, out := &in.BaselineDirection, &out.BaselineDirection
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpectedGroups != nil {
+		in, out := &in.ExpectedGroups, &out.ExpectedGroups
+		*out = new(int)
+		**out = **in
+	}
+	if in.IgnoreOverlap != nil {
+		in, out := &in.IgnoreOverlap, &out.IgnoreOverlap
+		*out = new(bool)
+		**out = **in
+	}
+	out.CriticalThreshold = in.CriticalThreshold
+	if in.WarningThreshold != nil {
+		in, out := &in.WarningThreshold, &out.WarningThreshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	if in.Signal != nil {
+		in, out := &in.Signal, &out.Signal
+		*out = new(NrqlSignal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(NrqlExpiration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlCondition.
+func (in *NrqlCondition) DeepCopy() *NrqlCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlExpiration) DeepCopyInto(out *NrqlExpiration) {
+	*out = *in
+	if in.ExpirationDuration != nil {
+		in, out := &in.ExpirationDuration, &out.ExpirationDuration
+		*out = new(int)
+		**out = **in
+	}
+	if in.OpenViolationOnExpiration != nil {
+		in, out := &in.OpenViolationOnExpiration, &out.OpenViolationOnExpiration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CloseViolationsOnExpiration != nil {
+		in, out := &in.CloseViolationsOnExpiration, &out.CloseViolationsOnExpiration
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlExpiration.
+func (in *NrqlExpiration) DeepCopy() *NrqlExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NrqlSignal) DeepCopyInto(out *NrqlSignal) {
+	*out = *in
+	if in.AggregationWindow != nil {
+		in, out := &in.AggregationWindow, &out.AggregationWindow
+		*out = new(int)
+		**out = **in
+	}
+	if in.AggregationMethod != nil {
+		in, out := &in.AggregationMethod, &out.AggregationMethod
+		*out = new(string)
+		**out = **in
+	}
+	if in.AggregationDelay != nil {
+		in, out := &in.AggregationDelay, &out.AggregationDelay
+		*out = new(int)
+		**out = **in
+	}
+	if in.AggregationTimer != nil {
+		in, out := &in.AggregationTimer, &out.AggregationTimer
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvaluationOffset != nil {
+		in, out := &in.EvaluationOffset, &out.EvaluationOffset
+		*out = new(int)
+		**out = **in
+	}
+	if in.FillOption != nil {
+		in, out := &in.FillOption, &out.FillOption
+		*out = new(string)
+		**out = **in
+	}
+	if in.FillValue != nil {
+		in, out := &in.FillValue, &out.FillValue
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NrqlSignal.
+func (in *NrqlSignal) DeepCopy() *NrqlSignal {
+	if in == nil {
+		return nil
+	}
+	out := new(NrqlSignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannel) DeepCopyInto(out *OpsgenieNotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannel.
+func (in *OpsgenieNotificationChannel) DeepCopy() *OpsgenieNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsgenieNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannelList) DeepCopyInto(out *OpsgenieNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsgenieNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelList.
+func (in *OpsgenieNotificationChannelList) DeepCopy() *OpsgenieNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsgenieNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsgenieNotificationChannelSpec) DeepCopyInto(out *OpsgenieNotificationChannelSpec) {
+	*out = *in
+	if in.ApiKeySecretRef != nil {
+		in, out := &in.ApiKeySecretRef, &out.ApiKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotificationChannelSpec.
+func (in *OpsgenieNotificationChannelSpec) DeepCopy() *OpsgenieNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedEntity) DeepCopyInto(out *ResolvedEntity) {
+	*out = *in
+	if in.Ids != nil {
+		in, out := &in.Ids, &out.Ids
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedEntity.
+func (in *ResolvedEntity) DeepCopy() *ResolvedEntity {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedEntity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotificationChannel) DeepCopyInto(out *SlackNotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannel.
+func (in *SlackNotificationChannel) DeepCopy() *SlackNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlackNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotificationChannelList) DeepCopyInto(out *SlackNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlackNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelList.
+func (in *SlackNotificationChannelList) DeepCopy() *SlackNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlackNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotificationChannelSpec) DeepCopyInto(out *SlackNotificationChannelSpec) {
+	*out = *in
+	if in.PolicySelector != nil {
+		in, out := &in.PolicySelector, &out.PolicySelector
+		*out = make(labels.Set, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationChannelSpec.
+func (in *SlackNotificationChannelSpec) DeepCopy() *SlackNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticsCondition) DeepCopyInto(out *SyntheticsCondition) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocationThresholds != nil {
+		in, out := &in.LocationThresholds, &out.LocationThresholds
+		*out = new(LocationThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticsCondition.
+func (in *SyntheticsCondition) DeepCopy() *SyntheticsCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticsCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Threshold) DeepCopyInto(out *Threshold) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Threshold.
+func (in *Threshold) DeepCopy() *Threshold {
+	if in == nil {
+		return nil
+	}
+	out := new(Threshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnresolvedEntity) DeepCopyInto(out *UnresolvedEntity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnresolvedEntity.
+func (in *UnresolvedEntity) DeepCopy() *UnresolvedEntity {
+	if in == nil {
+		return nil
+	}
+	out := new(UnresolvedEntity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDefined) DeepCopyInto(out *UserDefined) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDefined.
+func (in *UserDefined) DeepCopy() *UserDefined {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDefined)
+	in.DeepCopyInto(out)
+	return out
+}
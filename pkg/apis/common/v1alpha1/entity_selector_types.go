@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"regexp"
+	"strings"
+)
+
+// EntitySelector selects APM applications which report to New Relic. \
+// Exactly one of name, namePattern and label should be set. \
+// Selectors are resolved again on every resync, so that newly deployed applications are picked up
+type EntitySelector struct {
+	// The exact name of an application
+	// +optional
+	Name string `json:"name,omitempty"`
+	// A regular expression which has to match the complete name of an application, e.g. `payments-.*`
+	// +optional
+	NamePattern string `json:"namePattern,omitempty"`
+	// A New Relic application label in the form `category:name`, e.g. `Team:Payments`
+	// +optional
+	Label string `json:"label,omitempty"`
+}
+
+// Validate checks that exactly one way of selecting applications is used, and that it is well-formed
+func (selector EntitySelector) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	set := 0
+	for _, value := range []string{selector.Name, selector.NamePattern, selector.Label} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return append(errs, field.Invalid(path, selector.String(), "exactly one of name, namePattern and label must be set"))
+	}
+
+	if selector.NamePattern != "" {
+		if _, err := regexp.Compile(selector.NamePattern); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namePattern"), selector.NamePattern, err.Error()))
+		}
+	}
+	if selector.Label != "" {
+		parts := strings.SplitN(selector.Label, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errs = append(errs, field.Invalid(path.Child("label"), selector.Label, "must have the form category:name"))
+		}
+	}
+
+	return errs
+}
+
+// String describes the selector the same way in validation errors and in the unresolved entities of a status
+func (selector EntitySelector) String() string {
+	var parts []string
+	if selector.Name != "" {
+		parts = append(parts, selector.Name)
+	}
+	if selector.NamePattern != "" {
+		parts = append(parts, "namePattern="+selector.NamePattern)
+	}
+	if selector.Label != "" {
+		parts = append(parts, "label="+selector.Label)
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -4,6 +4,22 @@
 
 package v1alpha1
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntitySelector) DeepCopyInto(out *EntitySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntitySelector.
+func (in *EntitySelector) DeepCopy() *EntitySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(EntitySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
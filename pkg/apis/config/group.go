@@ -0,0 +1,6 @@
+// Package config contains config API versions.
+//
+// This file ensures Go source parsers acknowledge the config package
+// and any child packages. It can be removed if any other Go source files are
+// added to this package.
+package config
@@ -0,0 +1,4 @@
+// Package v1alpha1 contains API Schema definitions for the config v1alpha1 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=config.newrelic.io
+package v1alpha1
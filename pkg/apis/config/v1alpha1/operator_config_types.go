@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	statusApplied = "Applied"
+	statusInvalid = "Invalid"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfig is the Schema for the operatorconfigs API.
+// The operator only reads the OperatorConfig whose name is passed with the `--config-name` flag.
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=operatorconfigs,scope=Cluster
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this configuration"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of this configuration"
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigSpec   `json:"spec,omitempty"`
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+// OperatorConfigSpec defines the configuration of the operator.
+// Every field is optional and falls back to the default value when left empty.
+type OperatorConfigSpec struct {
+	// Reference to the secret key holding the New Relic admin API key.
+	// Defaults to the value of the `NEWRELIC_ADMIN_KEY` environment variable
+	// +optional
+	AdminKeySecretRef *SecretKeyReference `json:"adminKeySecretRef,omitempty"`
+	// The id of the New Relic account the admin key belongs to. It is required by the features
+	// which are only available through NerdGraph, like the signal settings of NRQL conditions.
+	// Defaults to the value of the `NEWRELIC_ACCOUNT_ID` environment variable
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AccountId int64 `json:"accountId,omitempty"`
+	// The New Relic API endpoints
+	// +optional
+	Endpoints Endpoints `json:"endpoints,omitempty"`
+	// The timeout for a single request to New Relic. Defaults to `3s`
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+	// The delay after which a resource which failed to reconcile is retried. Defaults to `5s`
+	// +optional
+	ErrorRequeueInterval *metav1.Duration `json:"errorRequeueInterval,omitempty"`
+	// The delay after which a successfully reconciled resource is reconciled again,
+	// reverting changes made to it in New Relic. Periodic resyncs are disabled by default
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+	// The delay after which an alert policy with unresolved APM entities is reconciled again,
+	// attaching its conditions to applications which started reporting in the meantime. Defaults to 5m
+	// +optional
+	EntityResolveInterval *metav1.Duration `json:"entityResolveInterval,omitempty"`
+	// What happens to the changes already applied in New Relic when saving an alert policy fails. \
+	// Can be one of: \
+	// - `Rollback` - all changes are reverted, so that New Relic keeps the previous state of the policy \
+	// - `Checkpoint` - only the changes of the failed step are reverted. The policy and the condition types saved before the failure are kept \
+	// Defaults to `Rollback`
+	// +kubebuilder:validation:Enum=Rollback;Checkpoint
+	// +optional
+	SaveFailureMode string `json:"saveFailureMode,omitempty"`
+	// Defaults applied to notification channels
+	// +optional
+	Defaults Defaults `json:"defaults,omitempty"`
+	// The ports on which metrics are served.
+	// Changes to the ports only take effect after the operator is restarted
+	// +optional
+	Metrics Metrics `json:"metrics,omitempty"`
+}
+
+// SecretKeyReference points to a key in a secret
+type SecretKeyReference struct {
+	// The namespace of the secret
+	Namespace string `json:"namespace"`
+	// The name of the secret
+	Name string `json:"name"`
+	// The key in the secret's data
+	Key string `json:"key"`
+}
+
+// Endpoints defines the base URLs of the New Relic APIs
+type Endpoints struct {
+	// The URL of the New Relic REST API. Defaults to `https://api.newrelic.com/v2`
+	// +optional
+	RestApiUrl string `json:"restApiUrl,omitempty"`
+	// The URL of the New Relic Infrastructure API. Defaults to `https://infra-api.newrelic.com/v2`
+	// +optional
+	InfraApiUrl string `json:"infraApiUrl,omitempty"`
+	// The URL of the New Relic Synthetics API. Defaults to `https://synthetics.newrelic.com/synthetics/api/v3`
+	// +optional
+	SyntheticsApiUrl string `json:"syntheticsApiUrl,omitempty"`
+	// The base URL of the New Relic NerdGraph API, whose /graphql path receives the requests.
+	// Defaults to `https://api.newrelic.com`
+	// +optional
+	NerdGraphUrl string `json:"nerdGraphUrl,omitempty"`
+}
+
+// Defaults defines the values used when a notification channel leaves a field empty
+type Defaults struct {
+	// Reference to the secret key holding the default Slack webhook URL.
+	// Defaults to the value of the `DEFAULT_SLACK_WEBHOOK_URL` environment variable
+	// +optional
+	SlackWebhookUrlSecretRef *SecretKeyReference `json:"slackWebhookUrlSecretRef,omitempty"`
+	// Reference to the secret key holding the default Opsgenie API key.
+	// Defaults to the value of the `DEFAULT_OPS_GENIE_API_KEY` environment variable
+	// +optional
+	OpsgenieApiKeySecretRef *SecretKeyReference `json:"opsgenieApiKeySecretRef,omitempty"`
+}
+
+// Metrics defines the ports on which metrics are served
+type Metrics struct {
+	// The port serving the operator metrics. Defaults to `8383`
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// The port serving the custom resource metrics. Defaults to `8686`
+	// +optional
+	OperatorPort int32 `json:"operatorPort,omitempty"`
+}
+
+// OperatorConfigStatus defines the observed state of an OperatorConfig
+type OperatorConfigStatus struct {
+	// The value will be set to `Applied` once the configuration has been validated and loaded,
+	// or to `Invalid` if the configuration was rejected. The operator keeps running with the last valid configuration
+	Status string `json:"status"`
+	// When the configuration is invalid, the value will be set to the validation errors
+	Reason string `json:"reason,omitempty"`
+	// The generation of the configuration which was last validated
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+func NewConfigApplied(generation int64) OperatorConfigStatus {
+	return OperatorConfigStatus{
+		Status:             statusApplied,
+		ObservedGeneration: generation,
+	}
+}
+
+func NewConfigInvalid(generation int64, err error) OperatorConfigStatus {
+	return OperatorConfigStatus{
+		Status:             statusInvalid,
+		Reason:             err.Error(),
+		ObservedGeneration: generation,
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfigList contains a list of OperatorConfig
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{}, &OperatorConfigList{})
+}
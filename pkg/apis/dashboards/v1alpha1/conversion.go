@@ -0,0 +1,6 @@
+package v1alpha1
+
+// v1alpha1 is the storage version of the dashboards group. All other versions
+// are converted to and from these types by the conversion webhook
+
+func (*Dashboard) Hub() {}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+const (
+	// DefaultVisibility is used when a dashboard does not set the visibility field
+	DefaultVisibility = "all"
+	// DefaultEditable is used when a dashboard does not set the editable field
+	DefaultEditable = "read_only"
+	// DefaultSinceSeconds is used when an APM metric widget does not set the sinceSeconds field
+	DefaultSinceSeconds = 1800
+)
+
+// DashboardDefaults holds the values written into a Dashboard
+// when the corresponding fields are left empty
+type DashboardDefaults struct {
+	Visibility   string
+	Editable     string
+	SinceSeconds int
+}
+
+func NewDashboardDefaults() DashboardDefaults {
+	return DashboardDefaults{
+		Visibility:   DefaultVisibility,
+		Editable:     DefaultEditable,
+		SinceSeconds: DefaultSinceSeconds,
+	}
+}
+
+// SetDefaults fills the empty fields of the dashboard and its widgets, so that the stored
+// resource shows exactly what is sent to New Relic
+func (dashboard *Dashboard) SetDefaults(defaults DashboardDefaults) {
+	if dashboard.Spec.Visibility == nil {
+		visibility := defaults.Visibility
+		dashboard.Spec.Visibility = &visibility
+	}
+	if dashboard.Spec.Editable == nil {
+		editable := defaults.Editable
+		dashboard.Spec.Editable = &editable
+	}
+
+	for i := range dashboard.Spec.Widgets {
+		apmMetric := dashboard.Spec.Widgets[i].Data.ApmMetric
+		if apmMetric != nil && apmMetric.SinceSeconds == 0 {
+			apmMetric.SinceSeconds = defaults.SinceSeconds
+		}
+	}
+}
@@ -9,6 +9,23 @@ import (
 type DashboardSpec struct {
 	// The name of the dashboard that will be created in New Relic
 	Title string `json:"title"`
+	// Who can see the dashboard in New Relic. \
+	// Can be one of: \
+	// - `owner` \
+	// - `all` \
+	// Defaults to `all`
+	// +kubebuilder:validation:Enum=owner;all
+	// +optional
+	Visibility *string `json:"visibility,omitempty"`
+	// Who can edit the dashboard in New Relic. \
+	// Can be one of: \
+	// - `read_only` \
+	// - `editable_by_owner` \
+	// - `editable_by_all` \
+	// Defaults to `read_only`, since changes made in New Relic are reverted by the operator
+	// +kubebuilder:validation:Enum=read_only;editable_by_owner;editable_by_all
+	// +optional
+	Editable *string `json:"editable,omitempty"`
 	// A list of widgets to add to the dashboard
 	Widgets []Widget `json:"widgets"`
 }
@@ -17,6 +34,7 @@ type DashboardSpec struct {
 
 // DashboardBody is the Schema for the dashboards API
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=dashboards,scope=Namespaced
 // +kubebuilder:printcolumn:name="NR Name",type="string",JSONPath=".spec.title",description="The New Relic name this dashboard"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="The status of this dashboard"
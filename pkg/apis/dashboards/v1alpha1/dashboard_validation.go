@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/nrql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// blank assignment to verify that Dashboard implements admission.Validator
+var _ admission.Validator = &Dashboard{}
+
+func (dashboard *Dashboard) ValidateCreate() error {
+	return dashboard.validate()
+}
+
+// ValidateUpdate only checks updates which change the spec, like the one of AlertPolicy,
+// so that the operator can always add and remove its finalizer
+func (dashboard *Dashboard) ValidateUpdate(old runtime.Object) error {
+	if dashboard.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldDashboard, ok := old.(*Dashboard); ok && reflect.DeepEqual(dashboard.Spec, oldDashboard.Spec) {
+		return nil
+	}
+
+	return dashboard.validate()
+}
+
+func (dashboard *Dashboard) ValidateDelete() error {
+	return nil
+}
+
+func (dashboard *Dashboard) validate() error {
+	errs := dashboard.Spec.Validate(field.NewPath("spec"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind("Dashboard").GroupKind(), dashboard.Name, errs)
+}
+
+// Validate checks that every widget plots either a query or APM metrics,
+// and that the queries can be plotted by the visualization of their widget
+func (spec DashboardSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec.Title == "" {
+		errs = append(errs, field.Required(path.Child("title"), ""))
+	}
+
+	for i, widget := range spec.Widgets {
+		errs = append(errs, widget.validate(path.Child("widgets").Index(i))...)
+	}
+
+	return errs
+}
+
+func (widget Widget) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	dataPath := path.Child("data")
+	if widget.Data.Nrql != "" && widget.Data.ApmMetric != nil {
+		errs = append(errs, field.Invalid(dataPath, "", "either nrql or apm can be set, but not both"))
+	}
+
+	if widget.Data.Nrql != "" {
+		for _, problem := range nrql.Errors(nrql.Lint(widget.Data.Nrql, nrql.WidgetRules(widget.Visualization))) {
+			errs = append(errs, field.Invalid(dataPath.Child("nrql"), widget.Data.Nrql, problem.String()))
+		}
+	}
+
+	if apm := widget.Data.ApmMetric; apm != nil {
+		apmPath := dataPath.Child("apm")
+		if len(apm.Entities) == 0 && len(apm.EntitySelectors) == 0 {
+			errs = append(errs, field.Required(apmPath.Child("entities"), "at least one application or entity selector is required"))
+		}
+		for i, selector := range apm.EntitySelectors {
+			errs = append(errs, selector.Validate(apmPath.Child("entitySelectors").Index(i))...)
+		}
+	}
+
+	return errs
+}
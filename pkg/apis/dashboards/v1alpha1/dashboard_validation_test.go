@@ -0,0 +1,162 @@
+package v1alpha1_test
+
+import (
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"io/ioutil"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+	"testing"
+)
+
+func TestValidate_ValidSpec(t *testing.T) {
+	spec := newValidSpec()
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_NrqlAndApmMetric(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Data.ApmMetric = &v1alpha1.Apm{Entities: []string{"my-app"}}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.widgets[0].data")
+}
+
+func TestValidate_ApmMetricWithEntitySelectors(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Visualization = "metric_line_chart"
+	spec.Widgets[0].Data = v1alpha1.Data{
+		ApmMetric: &v1alpha1.Apm{
+			EntitySelectors: []common.EntitySelector{
+				{NamePattern: "payments-.*"},
+				{Label: "Team:Payments"},
+			},
+		},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_ApmMetricWithoutEntities(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Visualization = "metric_line_chart"
+	spec.Widgets[0].Data = v1alpha1.Data{ApmMetric: &v1alpha1.Apm{}}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeRequired, "spec.widgets[0].data.apm.entities")
+}
+
+func TestValidate_EntitySelectorWithInvalidPattern(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Visualization = "metric_line_chart"
+	spec.Widgets[0].Data = v1alpha1.Data{
+		ApmMetric: &v1alpha1.Apm{
+			EntitySelectors: []common.EntitySelector{{NamePattern: "payments-("}},
+		},
+	}
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.widgets[0].data.apm.entitySelectors[0].namePattern")
+}
+
+func TestValidate_QueryNotMatchingVisualization(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Visualization = "facet_table"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	assertError(t, errs, field.ErrorTypeInvalid, "spec.widgets[0].data.nrql")
+}
+
+func TestValidate_IgnoresWarnings(t *testing.T) {
+	spec := newValidSpec()
+	spec.Widgets[0].Visualization = "billboard"
+
+	errs := spec.Validate(field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_NrqlAcceptedByNewRelic(t *testing.T) {
+	queries := []string{
+		"SELECT apdex(duration, t: 0.5) FROM Transaction TIMESERIES",
+		"SELECT count(*) FROM Log WHERE message RLIKE r'.*timeout \\d+.*' TIMESERIES",
+		"SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample) TIMESERIES",
+		"SELECT average(duration) FROM Transaction TIMESERIES 5 minutes SINCE 1 day ago SLIDE BY 1 minute",
+	}
+
+	for _, query := range queries {
+		spec := newValidSpec()
+		spec.Widgets[0].Data.Nrql = query
+
+		errs := spec.Validate(field.NewPath("spec"))
+		if len(errs) != 0 {
+			t.Errorf("Expected %q to be valid, got %v", query, errs)
+		}
+	}
+}
+
+func TestValidateUpdate_IgnoresUnchangedSpec(t *testing.T) {
+	old := &v1alpha1.Dashboard{Spec: newValidSpec()}
+	old.Spec.Widgets[0].Visualization = "facet_table"
+	dashboard := old.DeepCopy()
+	dashboard.Finalizers = []string{"newrelic"}
+
+	if err := dashboard.ValidateUpdate(old); err != nil {
+		t.Errorf("Expected a finalizer update of an invalid dashboard to be allowed, got %v", err)
+	}
+
+	dashboard.Spec.Title = "other title"
+	if err := dashboard.ValidateUpdate(old); err == nil {
+		t.Error("Expected a spec change of an invalid dashboard to be rejected")
+	}
+}
+
+func TestValidate_Example(t *testing.T) {
+	content, err := ioutil.ReadFile("../../../../hack/examples/dashboard_cr.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dashboard v1alpha1.Dashboard
+	if err := yaml.Unmarshal(content, &dashboard); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dashboard.ValidateCreate(); err != nil {
+		t.Errorf("Expected the example to be valid, got %v", err)
+	}
+}
+
+func assertError(t *testing.T, errs field.ErrorList, errorType field.ErrorType, path string) {
+	t.Helper()
+	for _, err := range errs {
+		if err.Type == errorType && err.Field == path {
+			return
+		}
+	}
+
+	t.Errorf("Expected error of type %s for %s, got %v", errorType, path, errs)
+}
+
+func newValidSpec() v1alpha1.DashboardSpec {
+	return v1alpha1.DashboardSpec{
+		Title: "my-dashboard",
+		Widgets: []v1alpha1.Widget{
+			{
+				Title:         "Response time",
+				Visualization: "line_chart",
+				Data: v1alpha1.Data{
+					Nrql: "SELECT average(duration) FROM Transaction TIMESERIES",
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,9 @@
 package v1alpha1
 
-import "github.com/personio/newrelic-alert-manager/pkg/dashboards/domain/widget"
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/dashboards/domain/widget"
+)
 
 // Widget defines the widget parameters \
 // For more details, refer to the official [New Relic documentation](https://docs.newrelic.com/docs/insights/insights-api/manage-dashboards/insights-dashboard-api#widget-data)
@@ -55,10 +58,16 @@ type Data struct {
 
 // Apm is the set of metric parameters used for defining the data to plot in the widget
 type Apm struct {
-	// The time frame in seconds
+	// The time frame in seconds. Defaults to `1800`
 	SinceSeconds int `json:"sinceSeconds,omitempty"`
-	// A list of application names for which to get the metric
-	Entities []string `json:"entities"`
+	// A list of application names for which to get the metric. \
+	// Either entities or entitySelectors, or both, should be set
+	// +optional
+	Entities []string `json:"entities,omitempty"`
+	// Selects the applications for which to get the metric by name, by a pattern of their name or by label. \
+	// The selected applications are added to the entities and looked up again on every resync
+	// +optional
+	EntitySelectors []v1alpha1.EntitySelector `json:"entitySelectors,omitempty"`
 	// A list of metrics to use
 	Metrics []Metric `json:"metrics,omitempty"`
 	// +optional
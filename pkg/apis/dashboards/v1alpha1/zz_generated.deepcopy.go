@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	commonv1alpha1 "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -16,6 +17,11 @@ func (in *Apm) DeepCopyInto(out *Apm) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EntitySelectors != nil {
+		in, out := &in.EntitySelectors, &out.EntitySelectors
+		*out = make([]commonv1alpha1.EntitySelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.Metrics != nil {
 		in, out := &in.Metrics, &out.Metrics
 		*out = make([]Metric, len(*in))
@@ -64,6 +70,22 @@ func (in *Dashboard) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardDefaults) DeepCopyInto(out *DashboardDefaults) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardDefaults.
+func (in *DashboardDefaults) DeepCopy() *DashboardDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardList) DeepCopyInto(out *DashboardList) {
 	*out = *in
@@ -100,6 +122,16 @@ func (in *DashboardList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
 	*out = *in
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(string)
+		**out = **in
+	}
+	if in.Editable != nil {
+		in, out := &in.Editable, &out.Editable
+		*out = new(string)
+		**out = **in
+	}
 	if in.Widgets != nil {
 		in, out := &in.Widgets, &out.Widgets
 		*out = make([]Widget, len(*in))
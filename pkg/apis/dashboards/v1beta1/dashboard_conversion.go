@@ -0,0 +1,128 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/dashboards/domain/widget"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// blank assignment to verify that Dashboard implements conversion.Convertible
+var _ conversion.Convertible = &Dashboard{}
+
+// ConvertTo converts the dashboard to the v1alpha1 storage version
+func (dashboard *Dashboard) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.Dashboard)
+	dst.ObjectMeta = dashboard.ObjectMeta
+	dst.Spec = v1alpha1.DashboardSpec{
+		Title:      dashboard.Spec.Title,
+		Visibility: dashboard.Spec.Visibility,
+		Editable:   dashboard.Spec.Editable,
+		Widgets:    widgetsToHub(dashboard.Spec.Widgets),
+	}
+	dst.Status = dashboard.Status
+
+	return nil
+}
+
+// ConvertFrom converts the dashboard from the v1alpha1 storage version
+func (dashboard *Dashboard) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.Dashboard)
+	dashboard.ObjectMeta = src.ObjectMeta
+	dashboard.Spec = DashboardSpec{
+		Title:      src.Spec.Title,
+		Visibility: src.Spec.Visibility,
+		Editable:   src.Spec.Editable,
+		Widgets:    widgetsFromHub(src.Spec.Widgets),
+	}
+	dashboard.Status = src.Status
+
+	return nil
+}
+
+func widgetsToHub(widgets []Widget) []v1alpha1.Widget {
+	if widgets == nil {
+		return nil
+	}
+
+	result := make([]v1alpha1.Widget, len(widgets))
+	for i, w := range widgets {
+		result[i] = v1alpha1.Widget{
+			Title:         w.Title,
+			Visualization: w.Visualization,
+			Data: v1alpha1.Data{
+				Nrql:      w.Data.Nrql,
+				ApmMetric: apmToHub(w.Data.ApmMetric),
+			},
+			Layout: widget.Layout(w.Layout),
+		}
+	}
+
+	return result
+}
+
+func widgetsFromHub(widgets []v1alpha1.Widget) []Widget {
+	if widgets == nil {
+		return nil
+	}
+
+	result := make([]Widget, len(widgets))
+	for i, w := range widgets {
+		result[i] = Widget{
+			Title:         w.Title,
+			Visualization: w.Visualization,
+			Data: Data{
+				Nrql:      w.Data.Nrql,
+				ApmMetric: apmFromHub(w.Data.ApmMetric),
+			},
+			Layout: Layout(w.Layout),
+		}
+	}
+
+	return result
+}
+
+func apmToHub(apm *Apm) *v1alpha1.Apm {
+	if apm == nil {
+		return nil
+	}
+
+	var metrics []v1alpha1.Metric
+	if apm.Metrics != nil {
+		metrics = make([]v1alpha1.Metric, len(apm.Metrics))
+		for i, metric := range apm.Metrics {
+			metrics[i] = v1alpha1.Metric(metric)
+		}
+	}
+
+	return &v1alpha1.Apm{
+		SinceSeconds:    apm.SinceSeconds,
+		Entities:        apm.Entities,
+		EntitySelectors: apm.EntitySelectors,
+		Metrics:         metrics,
+		Facet:           apm.Facet,
+		OrderBy:         apm.OrderBy,
+	}
+}
+
+func apmFromHub(apm *v1alpha1.Apm) *Apm {
+	if apm == nil {
+		return nil
+	}
+
+	var metrics []Metric
+	if apm.Metrics != nil {
+		metrics = make([]Metric, len(apm.Metrics))
+		for i, metric := range apm.Metrics {
+			metrics[i] = Metric(metric)
+		}
+	}
+
+	return &Apm{
+		SinceSeconds:    apm.SinceSeconds,
+		Entities:        apm.Entities,
+		EntitySelectors: apm.EntitySelectors,
+		Metrics:         metrics,
+		Facet:           apm.Facet,
+		OrderBy:         apm.OrderBy,
+	}
+}
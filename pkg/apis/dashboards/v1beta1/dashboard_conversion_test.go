@@ -0,0 +1,162 @@
+package v1beta1_test
+
+import (
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1beta1"
+	"github.com/personio/newrelic-alert-manager/pkg/dashboards/domain/widget"
+	"io/ioutil"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+func TestDashboard_RoundTripFromHub(t *testing.T) {
+	original := newHubDashboard()
+
+	var beta v1beta1.Dashboard
+	if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	var result v1alpha1.Dashboard
+	if err := beta.ConvertTo(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestDashboard_RoundTripToHub(t *testing.T) {
+	original := newDashboard()
+
+	var alpha v1alpha1.Dashboard
+	if err := original.DeepCopy().ConvertTo(&alpha); err != nil {
+		t.Fatal(err)
+	}
+	var result v1beta1.Dashboard
+	if err := result.ConvertFrom(&alpha); err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, original, &result)
+}
+
+func TestDashboard_ConvertFrom_RenamesFields(t *testing.T) {
+	var beta v1beta1.Dashboard
+	if err := beta.ConvertFrom(newHubDashboard()); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := yaml.Marshal(beta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "orderBy: name") || strings.Contains(string(content), "order_by:") {
+		t.Errorf("Expected orderBy instead of order_by in\n%s", content)
+	}
+}
+
+func TestDashboard_RoundTripExample(t *testing.T) {
+	content, err := ioutil.ReadFile("../../../../hack/examples/dashboard_cr.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var original v1alpha1.Dashboard
+	if err := yaml.Unmarshal(content, &original); err != nil {
+		t.Fatal(err)
+	}
+
+	var beta v1beta1.Dashboard
+	if err := beta.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	var result v1alpha1.Dashboard
+	if err := beta.ConvertTo(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	result.TypeMeta = original.TypeMeta
+	assertEqual(t, &original, &result)
+}
+
+func assertEqual(t *testing.T, expected interface{}, actual interface{}) {
+	t.Helper()
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		expectedYaml, _ := yaml.Marshal(expected)
+		actualYaml, _ := yaml.Marshal(actual)
+		t.Errorf("Round trip changed the object.\nExpected:\n%s\nGot:\n%s", expectedYaml, actualYaml)
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func newHubDashboard() *v1alpha1.Dashboard {
+	return &v1alpha1.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-dashboard",
+			Namespace:       "default",
+			Labels:          map[string]string{"team": "platform"},
+			Finalizers:      []string{"newrelic"},
+			Generation:      2,
+			ResourceVersion: "42",
+		},
+		Spec: v1alpha1.DashboardSpec{
+			Title:      "My dashboard",
+			Visibility: stringPtr("owner"),
+			Editable:   stringPtr("editable_by_owner"),
+			Widgets: []v1alpha1.Widget{
+				{
+					Title:         "Throughput",
+					Visualization: "line_chart",
+					Data: v1alpha1.Data{
+						Nrql: "SELECT count(*) FROM Transaction TIMESERIES",
+					},
+					Layout: widget.Layout{Width: 1, Height: 1, Row: 1, Column: 1},
+				},
+				{
+					Title:         "Response time",
+					Visualization: "metric_line_chart",
+					Data: v1alpha1.Data{
+						ApmMetric: &v1alpha1.Apm{
+							SinceSeconds:    3600,
+							Entities:        []string{"my-app"},
+							EntitySelectors: []common.EntitySelector{{Label: "Team:Payments"}},
+							Metrics: []v1alpha1.Metric{
+								{Name: "HttpDispatcher", Values: []string{"average_call_time", "call_count"}},
+							},
+							Facet:   "host",
+							OrderBy: "name",
+						},
+					},
+					Layout: widget.Layout{Width: 2, Height: 1, Row: 1, Column: 2},
+				},
+				{
+					Title:         "Breakdown",
+					Visualization: "application_breakdown",
+					Layout:        widget.Layout{Width: 3, Height: 1, Row: 2, Column: 1},
+				},
+			},
+		},
+		Status: common.NewReady(int64Ptr(10)),
+	}
+}
+
+func newDashboard() *v1beta1.Dashboard {
+	var dashboard v1beta1.Dashboard
+	if err := dashboard.ConvertFrom(newHubDashboard()); err != nil {
+		panic(err)
+	}
+	dashboard.Spec.Visibility = nil
+	dashboard.Spec.Widgets[1].Data.ApmMetric.Metrics = nil
+
+	return &dashboard
+}
@@ -0,0 +1,93 @@
+package v1beta1
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+)
+
+// Widget defines the widget parameters \
+// For more details, refer to the official [New Relic documentation](https://docs.newrelic.com/docs/insights/insights-api/manage-dashboards/insights-dashboard-api#widget-data)
+type Widget struct {
+	// The title of the widget created in New Relic
+	Title string `json:"title"`
+	// Visualization type to use for the widget. \
+	// Available options are: \
+	// - `application_breakdown` \
+	// - `attribute_sheet` \
+	// - `background_breakdown` \
+	// - `billboard` \
+	// - `billboard_comparison` \
+	// - `comparison_line_chart` \
+	// - `event_table` \
+	// - `facet_bar_chart` \
+	// - `facet_pie_chart` \
+	// - `facet_table` \
+	// - `faceted_area_chart` \
+	// - `faceted_line_chart` \
+	// - `funnel` \
+	// - `gauge` \
+	// - `heatmap` \
+	// - `histogram` \
+	// - `json` \
+	// - `line_chart` \
+	// - `list` \
+	// - `metric_line_chart` (used for apm metrics) \
+	// +kubebuilder:validation:Enum=application_breakdown;attribute_sheet;background_breakdown;billboard;billboard_comparison;comparison_line_chart;event_table;facet_bar_chart;facet_pie_chart;facet_table;faceted_area_chart;faceted_line_chart;funnel;gauge;heatmap;histogram;json;line_chart;list;metric_line_chart
+	Visualization string `json:"visualization"`
+	// The data to plot on the widget
+	Data Data `json:"data"`
+	// Defines the layout of the widget within the dashboard
+	Layout Layout `json:"layout"`
+}
+
+// Data represents the data to plot inside the widget. \
+// Either Nrql or ApmMetric should be specified, but not both. \
+// \
+// Leave both fields empty if you want to plot the application breakdown data, \
+// also present in the main widget that comes with the default application dashboard. \
+// For more information refer to the official [New Relic documentation](https://docs.newrelic.com/docs/insights/insights-api/manage-dashboards/insights-dashboard-api#dashboard-data)
+type Data struct {
+	// The NRQL query used which defines the data to plot in the widget
+	// +optional
+	Nrql string `json:"nrql,omitempty"`
+	// The APM metric parameters which defines the data to plot in the widget. \
+	// When using an APM metric for the data, visualization should be set to either `metric_line_chart` or `application_breakdown`. \
+	// +optional
+	ApmMetric *Apm `json:"apm,omitempty"`
+}
+
+// Apm is the set of metric parameters used for defining the data to plot in the widget
+type Apm struct {
+	// The time frame in seconds. Defaults to `1800`
+	SinceSeconds int `json:"sinceSeconds,omitempty"`
+	// A list of application names for which to get the metric. \
+	// Either entities or entitySelectors, or both, should be set
+	// +optional
+	Entities []string `json:"entities,omitempty"`
+	// Selects the applications for which to get the metric by name, by a pattern of their name or by label. \
+	// The selected applications are added to the entities and looked up again on every resync
+	// +optional
+	EntitySelectors []v1alpha1.EntitySelector `json:"entitySelectors,omitempty"`
+	// A list of metrics to use
+	Metrics []Metric `json:"metrics,omitempty"`
+	// +optional
+	Facet string `json:"facet,omitempty"`
+	// +optional
+	OrderBy string `json:"orderBy,omitempty"`
+}
+
+// Metric is the name of the metric as shown in Data Explorer
+type Metric struct {
+	// Name of the metric
+	Name string `json:"name"`
+	// List of metric values to plot. The available values will depend on the metric you choose. \
+	// Check the Data Explorer in New Relic to find out which values are available for which metrics.
+	Values []string `json:"values"`
+}
+
+// Layout is the position and size of the widget in the dashboard grid
+type Layout struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	Row    int `json:"row"`
+	Column int `json:"column"`
+}
@@ -0,0 +1,212 @@
+// Package apm lists the metrics and condition scopes New Relic accepts for each type of APM condition.
+// It has no dependencies on the other packages, so that the API types can validate conditions without importing the alert policy domain
+package apm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ConditionScopeApplication = "application"
+	ConditionScopeInstance    = "instance"
+)
+
+// apmConditionType lists the metrics and condition scopes New Relic accepts for a condition type
+type apmConditionType struct {
+	metrics         []string
+	conditionScopes []string
+}
+
+// apmConditionTypes is taken from the New Relic Alerts REST API reference:
+// https://docs.newrelic.com/docs/alerts/rest-api-alerts/new-relic-alerts-rest-api/alerts-conditions-api-field-names#metric
+var apmConditionTypes = map[string]apmConditionType{
+	"apm_app_metric": {
+		metrics: []string{
+			"apdex",
+			"error_percentage",
+			"response_time_background",
+			"response_time_web",
+			"throughput_background",
+			"throughput_web",
+			"user_defined",
+		},
+		conditionScopes: []string{ConditionScopeApplication, ConditionScopeInstance},
+	},
+	"apm_kt_metric": {
+		metrics: []string{
+			"apdex",
+			"error_count",
+			"error_percentage",
+			"response_time",
+			"throughput",
+		},
+		conditionScopes: []string{ConditionScopeApplication},
+	},
+	"apm_jvm_metric": {
+		metrics: []string{
+			"cpu_utilization_time",
+			"deadlocked_threads",
+			"gc_cpu_time",
+			"heap_memory_usage",
+		},
+		conditionScopes: []string{ConditionScopeApplication, ConditionScopeInstance},
+	},
+	"browser_metric": {
+		metrics: []string{
+			"ajax_response_time",
+			"ajax_throughput",
+			"dom_processing",
+			"end_user_apdex",
+			"network",
+			"page_rendering",
+			"page_view_throughput",
+			"page_views_with_js_errors",
+			"request_queuing",
+			"total_page_load",
+			"user_defined",
+			"web_application",
+		},
+		conditionScopes: []string{ConditionScopeApplication},
+	},
+	"mobile_metric": {
+		metrics: []string{
+			"database",
+			"images",
+			"json",
+			"mobile_crash_rate",
+			"network",
+			"network_error_percentage",
+			"status_error_percentage",
+			"user_defined",
+			"view_loading",
+		},
+		conditionScopes: []string{ConditionScopeApplication},
+	},
+}
+
+// ConditionTypes returns all known APM condition types
+func ConditionTypes() []string {
+	var result []string
+	for conditionType := range apmConditionTypes {
+		result = append(result, conditionType)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Metrics returns the metrics New Relic accepts for a condition type
+func Metrics(conditionType string) []string {
+	return apmConditionTypes[conditionType].metrics
+}
+
+// SupportsConditionScope returns whether New Relic accepts the condition scope for a condition type
+func SupportsConditionScope(conditionType string, conditionScope string) bool {
+	return contains(apmConditionTypes[conditionType].conditionScopes, conditionScope)
+}
+
+// ValidateMetric returns an error when New Relic does not accept the metric for the condition type.
+// The error suggests the closest valid metric
+func ValidateMetric(conditionType string, metric string) error {
+	catalogue, found := apmConditionTypes[conditionType]
+	if !found {
+		return fmt.Errorf("unknown condition type %s, must be one of %s", conditionType, strings.Join(ConditionTypes(), ", "))
+	}
+	if contains(catalogue.metrics, metric) {
+		return nil
+	}
+
+	message := fmt.Sprintf("metric %s is not supported by %s conditions, did you mean %s?", metric, conditionType, closest(catalogue.metrics, metric))
+	if otherTypes := typesWithMetric(metric); len(otherTypes) > 0 {
+		message += fmt.Sprintf(" The metric is supported by %s conditions", strings.Join(otherTypes, ", "))
+	}
+
+	return errors.New(message)
+}
+
+// ValidateConditionScope returns an error when New Relic does not accept the condition scope for the condition type
+func ValidateConditionScope(conditionType string, conditionScope string) error {
+	if SupportsConditionScope(conditionType, conditionScope) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"condition scope %s is not supported by %s conditions, must be one of %s",
+		conditionScope,
+		conditionType,
+		strings.Join(apmConditionTypes[conditionType].conditionScopes, ", "),
+	)
+}
+
+func typesWithMetric(metric string) []string {
+	var result []string
+	for _, conditionType := range ConditionTypes() {
+		if contains(apmConditionTypes[conditionType].metrics, metric) {
+			result = append(result, conditionType)
+		}
+	}
+
+	return result
+}
+
+// closest returns the candidate with the smallest edit distance to value
+func closest(candidates []string, value string) string {
+	result := candidates[0]
+	minDistance := editDistance(result, value)
+	for _, candidate := range candidates[1:] {
+		distance := editDistance(candidate, value)
+		if distance < minDistance {
+			result = candidate
+			minDistance = distance
+		}
+	}
+
+	return result
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package apm_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/apm"
+	"strings"
+	"testing"
+)
+
+func TestValidateMetric(t *testing.T) {
+	tests := []struct {
+		conditionType string
+		metric        string
+		expectedError string
+	}{
+		{conditionType: "apm_app_metric", metric: "apdex"},
+		{conditionType: "apm_jvm_metric", metric: "heap_memory_usage"},
+		{conditionType: "browser_metric", metric: "user_defined"},
+		{
+			conditionType: "apm_jvm_metric",
+			metric:        "apdex",
+			expectedError: "metric apdex is not supported by apm_jvm_metric conditions, did you mean ",
+		},
+		{
+			conditionType: "apm_app_metric",
+			metric:        "response_time",
+			expectedError: "did you mean response_time_web? The metric is supported by apm_kt_metric conditions",
+		},
+		{
+			conditionType: "browser_metric",
+			metric:        "error_percentage",
+			expectedError: "The metric is supported by apm_app_metric, apm_kt_metric conditions",
+		},
+		{
+			conditionType: "mobile_metric",
+			metric:        "mobile_crashrate",
+			expectedError: "did you mean mobile_crash_rate?",
+		},
+		{
+			conditionType: "apm_metric",
+			metric:        "apdex",
+			expectedError: "unknown condition type apm_metric",
+		},
+	}
+
+	for _, test := range tests {
+		err := apm.ValidateMetric(test.conditionType, test.metric)
+		if test.expectedError == "" && err != nil {
+			t.Errorf("%s/%s: expected no error, got %v", test.conditionType, test.metric, err)
+		}
+		if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+			t.Errorf("%s/%s: expected error containing %q, got %v", test.conditionType, test.metric, test.expectedError, err)
+		}
+	}
+}
+
+func TestValidateConditionScope(t *testing.T) {
+	if err := apm.ValidateConditionScope("apm_app_metric", "instance"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	err := apm.ValidateConditionScope("browser_metric", "instance")
+	if err == nil || err.Error() != "condition scope instance is not supported by browser_metric conditions, must be one of application" {
+		t.Errorf("Expected an unsupported scope error, got %v", err)
+	}
+}
@@ -1,5 +1,10 @@
 package applications
 
+import (
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"strings"
+)
+
 type ApplicationList struct {
 	Applications []Application `json:"applications"`
 }
@@ -7,3 +12,66 @@ type Application struct {
 	Id   int    `json:"id"`
 	Name string `json:"name"`
 }
+
+// Selector selects applications by exact name, by a regular expression matching the complete name or by label.
+// Exactly one of the fields is expected to be set
+type Selector struct {
+	Name        string
+	NamePattern string
+	Label       string
+}
+
+// LabelList is the response of the New Relic labels API
+type LabelList struct {
+	Labels []Label `json:"labels"`
+}
+
+type Label struct {
+	Key      string     `json:"key"`
+	Category string     `json:"category"`
+	Name     string     `json:"name"`
+	Links    LabelLinks `json:"links"`
+}
+
+type LabelLinks struct {
+	Applications []int `json:"applications"`
+}
+
+// Matches compares the label with one given as `category:name`, ignoring case like New Relic does
+func (label Label) Matches(key string) bool {
+	return strings.EqualFold(label.Category+":"+label.Name, key)
+}
+
+// String describes the selector the same way as the entity selectors of the custom resources
+func (selector Selector) String() string {
+	switch {
+	case selector.NamePattern != "":
+		return "namePattern=" + selector.NamePattern
+	case selector.Label != "":
+		return "label=" + selector.Label
+	default:
+		return selector.Name
+	}
+}
+
+// IsDynamic returns true for selectors whose matches change as applications are deployed or labelled
+func (selector Selector) IsDynamic() bool {
+	return selector.NamePattern != "" || selector.Label != ""
+}
+
+// NewSelectors combines the application names and the entity selectors of a condition or widget
+func NewSelectors(names []string, selectors []common.EntitySelector) []Selector {
+	result := make([]Selector, 0, len(names)+len(selectors))
+	for _, name := range names {
+		result = append(result, Selector{Name: name})
+	}
+	for _, selector := range selectors {
+		result = append(result, Selector{
+			Name:        selector.Name,
+			NamePattern: selector.NamePattern,
+			Label:       selector.Label,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,35 @@
+package applications_test
+
+import (
+	common "github.com/personio/newrelic-alert-manager/pkg/apis/common/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"testing"
+)
+
+func TestNewSelectors(t *testing.T) {
+	selectors := applications.NewSelectors(
+		[]string{"test-app"},
+		[]common.EntitySelector{{Name: "other-app"}, {NamePattern: "test-.*"}, {Label: "Team:payments"}},
+	)
+
+	expected := []struct {
+		description string
+		dynamic     bool
+	}{
+		{"test-app", false},
+		{"other-app", false},
+		{"namePattern=test-.*", true},
+		{"label=Team:payments", true},
+	}
+	if len(selectors) != len(expected) {
+		t.Fatalf("Expected %d selectors, got %v", len(expected), selectors)
+	}
+	for i, selector := range selectors {
+		if selector.String() != expected[i].description {
+			t.Errorf("Expected selector %s, got %s", expected[i].description, selector.String())
+		}
+		if selector.IsDynamic() != expected[i].dynamic {
+			t.Errorf("Selector %s should have IsDynamic %t", selector.String(), expected[i].dynamic)
+		}
+	}
+}
@@ -4,8 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/personio/newrelic-alert-manager/internal"
+	"regexp"
 )
 
+// pageSize is the number of applications and labels the REST API returns per page
+const pageSize = 200
+
+// Repository looks up APM applications. Applications can be looked up by name through a filter of the REST API,
+// while selecting them by pattern or label lists all applications of the account
 type Repository struct {
 	client internal.NewrelicClient
 }
@@ -31,11 +37,150 @@ func (repository Repository) GetApplicationByName(name string) (*Application, er
 
 	application := findApplicationByName(applications, name)
 	if application == nil {
-		return nil, fmt.Errorf("application with name %s does not exist", name)
+		return nil, NotFoundError{Name: name}
 	}
 	return application, nil
 }
 
+// Select returns the applications matched by the selector.
+// A selector which does not match any application results in a NotFoundError
+func (repository Repository) Select(selector Selector) ([]Application, error) {
+	if selector.Name != "" {
+		application, err := repository.GetApplicationByName(selector.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []Application{*application}, nil
+	}
+
+	var result []Application
+	var err error
+	if selector.NamePattern != "" {
+		result, err = repository.GetApplicationsByPattern(selector.NamePattern)
+	} else {
+		result, err = repository.GetApplicationsByLabel(selector.Label)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, NotFoundError{Name: selector.String()}
+	}
+	return result, nil
+}
+
+// GetApplicationsByPattern returns the applications whose complete name matches the regular expression
+func (repository Repository) GetApplicationsByPattern(pattern string) ([]Application, error) {
+	expression, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := repository.listApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Application
+	for _, application := range applications {
+		if expression.MatchString(application.Name) {
+			result = append(result, application)
+		}
+	}
+
+	return result, nil
+}
+
+// GetApplicationsByLabel returns the applications which carry the label, given as `category:name`
+func (repository Repository) GetApplicationsByLabel(key string) ([]Application, error) {
+	labels, err := repository.listLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int]bool)
+	for _, label := range labels {
+		if !label.Matches(key) {
+			continue
+		}
+		for _, id := range label.Links.Applications {
+			ids[id] = true
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	applications, err := repository.listApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Application
+	for _, application := range applications {
+		if ids[application.Id] {
+			result = append(result, application)
+		}
+	}
+
+	return result, nil
+}
+
+func (repository Repository) listApplications() ([]Application, error) {
+	var result []Application
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("/applications.json?page=%d", page)
+		response, err := repository.client.Get(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		var applications ApplicationList
+		err = json.NewDecoder(response.Body).Decode(&applications)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, applications.Applications...)
+		if len(applications.Applications) < pageSize {
+			return result, nil
+		}
+	}
+}
+
+func (repository Repository) listLabels() ([]Label, error) {
+	var result []Label
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("/labels.json?page=%d", page)
+		response, err := repository.client.Get(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		var labels LabelList
+		err = json.NewDecoder(response.Body).Decode(&labels)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, labels.Labels...)
+		if len(labels.Labels) < pageSize {
+			return result, nil
+		}
+	}
+}
+
+// NotFoundError is returned when no application reports to New Relic under the given name
+type NotFoundError struct {
+	Name string
+}
+
+func (err NotFoundError) Error() string {
+	return fmt.Sprintf("application with name %s does not exist", err.Name)
+}
+
 func findApplicationByName(applications ApplicationList, name string) *Application {
 	for _, application := range applications.Applications {
 		if application.Name == name {
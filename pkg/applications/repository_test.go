@@ -0,0 +1,113 @@
+package applications_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestRepository_Select_Name(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=app-1").Return(newApplicationPage(1, 1), nil)
+
+	repository := applications.NewRepository(client)
+	selected, err := repository.Select(applications.Selector{Name: "app-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertApplicationIds(t, selected, 1)
+}
+
+func TestRepository_Select_NamePattern(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?page=1").Return(newApplicationPage(1, 200), nil)
+	client.On("Get", "/applications.json?page=2").Return(newApplicationPage(201, 50), nil)
+
+	repository := applications.NewRepository(client)
+	selected, err := repository.Select(applications.Selector{NamePattern: "app-2[0-9]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertApplicationIds(t, selected, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29)
+	client.AssertNumberOfCalls(t, "Get", 2)
+}
+
+func TestRepository_Select_Label(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/labels.json?page=1").Return(newJsonResponse(applications.LabelList{
+		Labels: []applications.Label{
+			{Key: "Team:Payments", Category: "Team", Name: "Payments", Links: applications.LabelLinks{Applications: []int{2, 3}}},
+			{Key: "Team:Search", Category: "Team", Name: "Search", Links: applications.LabelLinks{Applications: []int{4}}},
+		},
+	}), nil)
+	client.On("Get", "/applications.json?page=1").Return(newApplicationPage(1, 5), nil)
+
+	repository := applications.NewRepository(client)
+	selected, err := repository.Select(applications.Selector{Label: "team:payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertApplicationIds(t, selected, 2, 3)
+}
+
+func TestRepository_Select_NoMatch(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?page=1").Return(newApplicationPage(1, 5), nil)
+
+	repository := applications.NewRepository(client)
+	_, err := repository.Select(applications.Selector{NamePattern: "unknown-.*"})
+	notFound, ok := err.(applications.NotFoundError)
+	if !ok {
+		t.Fatalf("Expected a NotFoundError, got %v", err)
+	}
+
+	if notFound.Name != "namePattern=unknown-.*" {
+		t.Errorf("Expected the selector in the error, got %s", notFound.Name)
+	}
+}
+
+func assertApplicationIds(t *testing.T, selected []applications.Application, ids ...int) {
+	t.Helper()
+	if len(selected) != len(ids) {
+		t.Fatalf("Expected applications %v, got %v", ids, selected)
+	}
+
+	for i, id := range ids {
+		if selected[i].Id != id {
+			t.Errorf("Expected applications %v, got %v", ids, selected)
+		}
+	}
+}
+
+// newApplicationPage returns a page of size applications named app-<id>, starting at the given id
+func newApplicationPage(firstId int, size int) *http.Response {
+	var list applications.ApplicationList
+	for id := firstId; id < firstId+size; id++ {
+		list.Applications = append(list.Applications, applications.Application{
+			Id:   id,
+			Name: fmt.Sprintf("app-%d", id),
+		})
+	}
+
+	return newJsonResponse(list)
+}
+
+func newJsonResponse(body interface{}) *http.Response {
+	content, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(content)),
+	}
+}
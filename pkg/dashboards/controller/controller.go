@@ -12,7 +12,6 @@ import (
 	"github.com/operator-framework/operator-sdk/pkg/predicate"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"os"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,16 +28,13 @@ type ReconcileDashboard struct {
 	newrelic         *newrelic.Repository
 	dashboardFactory *DashboardFactory
 	log              logr.Logger
+	options          internal.ControllerOptions
 }
 
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, options internal.ControllerOptions) error {
 	log.Info("Registering newrelic dashboard controller")
 
-	client := internal.NewNewrelicClient(
-		log,
-		"https://api.newrelic.com/v2",
-		os.Getenv("NEWRELIC_ADMIN_KEY"),
-	)
+	client := options.NewRestApiClient(log)
 
 	k8sClient := k8s.NewClient(log, mgr.GetClient())
 	repository := newrelic.NewRepository(log, client)
@@ -50,9 +46,10 @@ func Add(mgr manager.Manager) error {
 		newrelic:         repository,
 		dashboardFactory: dashboardFactory,
 		log:              log,
+		options:          options,
 	}
 
-	c, err := controller.New("newrelic-dashboard-controller", mgr, controller.Options{Reconciler: reconciler})
+	c, err := controller.New("newrelic-dashboard-controller", mgr, options.ForController("newrelic-dashboard-controller", reconciler))
 	if err != nil {
 		return err
 	}
@@ -80,7 +77,7 @@ func (r *ReconcileDashboard) Reconcile(request reconcile.Request) (reconcile.Res
 		}
 
 		reqLogger.Error(err, "Error talking to API server. Re-queueing request")
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	dashboard, err := r.dashboardFactory.NewDashboard(instance)
@@ -89,10 +86,10 @@ func (r *ReconcileDashboard) Reconcile(request reconcile.Request) (reconcile.Res
 		instance.Status = commonv1alpha1.NewError(dashboard.DashboardBody.Id, err)
 		statusErr := r.k8s.UpdateDashboardStatus(instance)
 		if statusErr != nil {
-			return internal.NewReconcileResult(statusErr)
+			return r.options.NewReconcileResult(statusErr)
 		}
 
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	if instance.DeletionTimestamp != nil {
@@ -102,7 +99,7 @@ func (r *ReconcileDashboard) Reconcile(request reconcile.Request) (reconcile.Res
 	err = r.k8s.SetFinalizer(*instance)
 	if err != nil {
 		reqLogger.Error(err, "Error setting finalizer on dashboard")
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	err = r.newrelic.Save(dashboard)
@@ -111,18 +108,22 @@ func (r *ReconcileDashboard) Reconcile(request reconcile.Request) (reconcile.Res
 		instance.Status = commonv1alpha1.NewError(dashboard.DashboardBody.Id, err)
 		err = r.k8s.UpdateDashboardStatus(instance)
 
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	instance.Status = commonv1alpha1.NewReady(dashboard.DashboardBody.Id)
 	err = r.k8s.UpdateDashboardStatus(instance)
 	if err != nil {
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	reqLogger.Info("Finished reconciling")
-	return internal.NewReconcileResult(nil)
+	if hasDynamicSelectors(instance) {
+		// Applications deployed later only match the entity selectors when the dashboard is reconciled again
+		return r.options.NewEntityResolveResult()
+	}
 
+	return r.options.NewReconcileResult(nil)
 }
 
 func (r *ReconcileDashboard) deleteDashboard(dashboard *domain.Dashboard, instance v1alpha1.Dashboard) (reconcile.Result, error) {
@@ -23,8 +23,8 @@ func (factory DashboardFactory) NewDashboard(cr *v1alpha1.Dashboard) (*domain.Da
 		DashboardBody: domain.DashboardBody{
 			Id:         cr.Status.NewrelicId,
 			Title:      cr.Spec.Title,
-			Visibility: "all",
-			Editable:   "read_only",
+			Visibility: stringWithDefault(cr.Spec.Visibility, v1alpha1.DefaultVisibility),
+			Editable:   stringWithDefault(cr.Spec.Editable, v1alpha1.DefaultEditable),
 			Metadata: domain.Metadata{
 				Version: 1,
 			},
@@ -77,14 +77,14 @@ func (factory DashboardFactory) newData(data v1alpha1.Data) (widget.DataList, er
 			Nrql: data.Nrql,
 		}
 	} else {
-		entities, err := factory.getApplicationIds(data.ApmMetric.Entities)
+		entities, err := factory.getApplicationIds(applications.NewSelectors(data.ApmMetric.Entities, data.ApmMetric.EntitySelectors))
 		if err != nil {
 			return result, err
 		}
 
 		result[0] = widget.Data{
 			ApmMetric: &widget.ApmMetric{
-				Duration:  factory.getInt64WithDefault(data.ApmMetric.SinceSeconds, 1800) * 1000,
+				Duration:  factory.getInt64WithDefault(data.ApmMetric.SinceSeconds, v1alpha1.DefaultSinceSeconds) * 1000,
 				EntityIds: entities,
 				Metrics:   newMetrics(data.ApmMetric.Metrics),
 				Facet:     data.ApmMetric.Facet,
@@ -105,23 +105,53 @@ func (factory DashboardFactory) getInt64WithDefault(value int, defaultValue int)
 	return int64(value)
 }
 
-func (factory DashboardFactory) getApplicationIds(entities []string) ([]int, error) {
+func stringWithDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
+
+// getApplicationIds returns the ids of the selected applications without duplicates
+func (factory DashboardFactory) getApplicationIds(selectors []applications.Selector) ([]int, error) {
 	var result []int
-	for _, item := range entities {
-		application, err := factory.appRepository.GetApplicationByName(item)
+	seen := make(map[int]bool)
+	for _, selector := range selectors {
+		selected, err := factory.appRepository.Select(selector)
 		if err != nil {
 			return nil, err
 		}
-		if application == nil {
-			continue
-		}
 
-		result = append(result, application.Id)
+		for _, application := range selected {
+			if seen[application.Id] {
+				continue
+			}
+			seen[application.Id] = true
+			result = append(result, application.Id)
+		}
 	}
 
 	return result, nil
 }
 
+
+// hasDynamicSelectors returns true when a widget selects applications by name pattern or label
+func hasDynamicSelectors(dashboard *v1alpha1.Dashboard) bool {
+	for _, w := range dashboard.Spec.Widgets {
+		if w.Data.ApmMetric == nil {
+			continue
+		}
+		for _, selector := range applications.NewSelectors(nil, w.Data.ApmMetric.EntitySelectors) {
+			if selector.IsDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func newMetrics(metrics []v1alpha1.Metric) widget.MetricList {
 	result := make([]widget.Metric, len(metrics))
 	for i, m := range metrics {
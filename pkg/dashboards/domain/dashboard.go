@@ -24,7 +24,10 @@ type DashboardBody struct {
 }
 
 func (d DashboardBody) Equals(other DashboardBody) bool {
-	return d.Title == other.Title && d.Widgets.Equals(other.Widgets)
+	return d.Title == other.Title &&
+		d.Editable == other.Editable &&
+		d.Visibility == other.Visibility &&
+		d.Widgets.Equals(other.Widgets)
 }
 
 type Metadata struct {
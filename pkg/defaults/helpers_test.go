@@ -0,0 +1,39 @@
+package defaults_test
+
+import (
+	"context"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceReader returns the namespaces it was created with
+type namespaceReader struct {
+	namespaces map[string]v1.Namespace
+}
+
+func newNamespaceReader(name string, annotations map[string]string) *namespaceReader {
+	namespace := v1.Namespace{}
+	namespace.Name = name
+	namespace.Annotations = annotations
+
+	return &namespaceReader{
+		namespaces: map[string]v1.Namespace{name: namespace},
+	}
+}
+
+func (r *namespaceReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	namespace, ok := r.namespaces[key.Name]
+	if !ok {
+		return errors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, key.Name)
+	}
+
+	*obj.(*v1.Namespace) = namespace
+	return nil
+}
+
+func (r *namespaceReader) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	return nil
+}
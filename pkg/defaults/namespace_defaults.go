@@ -0,0 +1,124 @@
+package defaults
+
+import (
+	"context"
+	"fmt"
+	alerts "github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	dashboards "github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+)
+
+// The annotations below can be set on a namespace to change the defaults
+// applied to the resources created in it
+const (
+	annotationPrefix = "defaults.newrelic.io/"
+
+	EnabledAnnotation        = annotationPrefix + "enabled"
+	ConditionScopeAnnotation = annotationPrefix + "condition-scope"
+	VisibilityAnnotation     = annotationPrefix + "visibility"
+	EditableAnnotation       = annotationPrefix + "editable"
+	SinceSecondsAnnotation   = annotationPrefix + "since-seconds"
+)
+
+var (
+	conditionScopes = []string{"application", "instance"}
+	visibilities    = []string{"owner", "all"}
+	editables       = []string{"read_only", "editable_by_owner", "editable_by_all"}
+)
+
+// NamespaceDefaults reads the defaults configured on a namespace
+type NamespaceDefaults struct {
+	reader client.Reader
+}
+
+func NewNamespaceDefaults(reader client.Reader) *NamespaceDefaults {
+	return &NamespaceDefaults{
+		reader: reader,
+	}
+}
+
+// PolicyDefaults returns the built-in policy defaults, overridden by the annotations of the namespace
+func (d NamespaceDefaults) PolicyDefaults(ctx context.Context, namespace string) (alerts.PolicyDefaults, error) {
+	defaults := alerts.NewPolicyDefaults()
+	annotations, err := d.annotations(ctx, namespace)
+	if err != nil {
+		return defaults, err
+	}
+
+	if value, ok := annotations[EnabledAnnotation]; ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return defaults, invalidAnnotation(namespace, EnabledAnnotation, value, "must be true or false")
+		}
+		defaults.Enabled = enabled
+	}
+
+	if value, ok := annotations[ConditionScopeAnnotation]; ok {
+		if !contains(conditionScopes, value) {
+			return defaults, invalidAnnotation(namespace, ConditionScopeAnnotation, value, fmt.Sprintf("must be one of %v", conditionScopes))
+		}
+		defaults.ConditionScope = value
+	}
+
+	return defaults, nil
+}
+
+// DashboardDefaults returns the built-in dashboard defaults, overridden by the annotations of the namespace
+func (d NamespaceDefaults) DashboardDefaults(ctx context.Context, namespace string) (dashboards.DashboardDefaults, error) {
+	defaults := dashboards.NewDashboardDefaults()
+	annotations, err := d.annotations(ctx, namespace)
+	if err != nil {
+		return defaults, err
+	}
+
+	if value, ok := annotations[VisibilityAnnotation]; ok {
+		if !contains(visibilities, value) {
+			return defaults, invalidAnnotation(namespace, VisibilityAnnotation, value, fmt.Sprintf("must be one of %v", visibilities))
+		}
+		defaults.Visibility = value
+	}
+
+	if value, ok := annotations[EditableAnnotation]; ok {
+		if !contains(editables, value) {
+			return defaults, invalidAnnotation(namespace, EditableAnnotation, value, fmt.Sprintf("must be one of %v", editables))
+		}
+		defaults.Editable = value
+	}
+
+	if value, ok := annotations[SinceSecondsAnnotation]; ok {
+		sinceSeconds, err := strconv.Atoi(value)
+		if err != nil || sinceSeconds <= 0 {
+			return defaults, invalidAnnotation(namespace, SinceSecondsAnnotation, value, "must be a positive number")
+		}
+		defaults.SinceSeconds = sinceSeconds
+	}
+
+	return defaults, nil
+}
+
+func (d NamespaceDefaults) annotations(ctx context.Context, name string) (map[string]string, error) {
+	var namespace v1.Namespace
+	err := d.reader.Get(ctx, types.NamespacedName{Name: name}, &namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespace.Annotations, nil
+}
+
+func invalidAnnotation(namespace string, annotation string, value string, reason string) error {
+	return fmt.Errorf("invalid annotation %s=%q on namespace %s: %s", annotation, value, namespace, reason)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	alerts "github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	dashboards "github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// PolicyDefaulter writes the effective defaults into the conditions of an AlertPolicy
+type PolicyDefaulter struct {
+	defaults *NamespaceDefaults
+}
+
+// blank assignment to verify that PolicyDefaulter implements admission.Handler
+var _ admission.Handler = &PolicyDefaulter{}
+
+func NewPolicyDefaulter(defaults *NamespaceDefaults) *PolicyDefaulter {
+	return &PolicyDefaulter{
+		defaults: defaults,
+	}
+}
+
+func (d *PolicyDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var policy alerts.AlertPolicy
+	if err := json.Unmarshal(req.Object.Raw, &policy); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	defaults, err := d.defaults.PolicyDefaults(ctx, req.Namespace)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	policy.SetDefaults(defaults)
+	return patchResponse(req, policy)
+}
+
+// DashboardDefaulter writes the effective defaults into a Dashboard and its widgets
+type DashboardDefaulter struct {
+	defaults *NamespaceDefaults
+}
+
+// blank assignment to verify that DashboardDefaulter implements admission.Handler
+var _ admission.Handler = &DashboardDefaulter{}
+
+func NewDashboardDefaulter(defaults *NamespaceDefaults) *DashboardDefaulter {
+	return &DashboardDefaulter{
+		defaults: defaults,
+	}
+}
+
+func (d *DashboardDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var dashboard dashboards.Dashboard
+	if err := json.Unmarshal(req.Object.Raw, &dashboard); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	defaults, err := d.defaults.DashboardDefaults(ctx, req.Namespace)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	dashboard.SetDefaults(defaults)
+	return patchResponse(req, dashboard)
+}
+
+func patchResponse(req admission.Request, object interface{}) admission.Response {
+	marshalled, err := json.Marshal(object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
@@ -0,0 +1,235 @@
+package defaults_test
+
+import (
+	"context"
+	"encoding/json"
+	alerts "github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	dashboards "github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/defaults"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+func TestPolicyDefaulter_SetsBuiltInDefaults(t *testing.T) {
+	policy := newPolicy()
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(newNamespaceReader("default", nil)))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "default", policy))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/apmConditions/0/enabled", true)
+	assertPatch(t, response, "/spec/apmConditions/0/conditionScope", "application")
+	assertPatch(t, response, "/spec/apmConditions/0/missingEntities", "fail")
+	assertPatch(t, response, "/spec/nrqlConditions/0/enabled", true)
+	assertPatch(t, response, "/spec/nrqlConditions/0/type", "static")
+	assertPatch(t, response, "/spec/infraConditions/0/type", "infra_metric")
+}
+
+func TestPolicyDefaulter_KeepsExplicitValues(t *testing.T) {
+	policy := newPolicy()
+	enabled := false
+	policy.Spec.ApmConditions[0].Enabled = &enabled
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(newNamespaceReader("default", nil)))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "default", policy))
+
+	assertAllowed(t, response)
+	assertNoPatch(t, response, "/spec/apmConditions/0/enabled")
+}
+
+func TestPolicyDefaulter_SetsOutlierDefaults(t *testing.T) {
+	policy := newPolicy()
+	outlier := "outlier"
+	policy.Spec.NrqlConditions[0].Type = &outlier
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(newNamespaceReader("default", nil)))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "default", policy))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/nrqlConditions/0/ignoreOverlap", false)
+	assertNoPatch(t, response, "/spec/nrqlConditions/0/type")
+}
+
+func TestPolicyDefaulter_SetsExternalServiceDefaults(t *testing.T) {
+	policy := newPolicy()
+	policy.Spec.ExternalServiceConditions = []alerts.ExternalServiceCondition{
+		{
+			Name:               "external",
+			Entities:           []string{"app"},
+			ExternalServiceUrl: "api.stripe.com",
+			Metric:             "throughput",
+		},
+	}
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(newNamespaceReader("default", nil)))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "default", policy))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/externalServiceConditions/0/enabled", true)
+	assertPatch(t, response, "/spec/externalServiceConditions/0/missingEntities", "fail")
+}
+
+func TestPolicyDefaulter_UsesNamespaceDefaults(t *testing.T) {
+	reader := newNamespaceReader("team", map[string]string{
+		defaults.EnabledAnnotation:        "false",
+		defaults.ConditionScopeAnnotation: "instance",
+	})
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(reader))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "team", newPolicy()))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/apmConditions/0/enabled", false)
+	assertPatch(t, response, "/spec/apmConditions/0/conditionScope", "instance")
+}
+
+func TestPolicyDefaulter_SkipsUnsupportedNamespaceConditionScope(t *testing.T) {
+	reader := newNamespaceReader("team", map[string]string{
+		defaults.ConditionScopeAnnotation: "instance",
+	})
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(reader))
+	policy := newPolicy()
+	policy.Spec.ApmConditions[0].Type = "browser_metric"
+	policy.Spec.ApmConditions[0].Metric = "end_user_apdex"
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "team", policy))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/apmConditions/0/conditionScope", "application")
+}
+
+func TestPolicyDefaulter_DeniesInvalidNamespaceDefaults(t *testing.T) {
+	reader := newNamespaceReader("team", map[string]string{
+		defaults.ConditionScopeAnnotation: "cluster",
+	})
+	defaulter := defaults.NewPolicyDefaulter(defaults.NewNamespaceDefaults(reader))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "team", newPolicy()))
+
+	if response.Allowed {
+		t.Error("Expected the request to be denied")
+	}
+}
+
+func TestDashboardDefaulter_SetsDefaults(t *testing.T) {
+	reader := newNamespaceReader("team", map[string]string{
+		defaults.VisibilityAnnotation:   "owner",
+		defaults.SinceSecondsAnnotation: "3600",
+	})
+	defaulter := defaults.NewDashboardDefaulter(defaults.NewNamespaceDefaults(reader))
+
+	response := defaulter.Handle(context.TODO(), newRequest(t, "team", newDashboard()))
+
+	assertAllowed(t, response)
+	assertPatch(t, response, "/spec/visibility", "owner")
+	assertPatch(t, response, "/spec/editable", "read_only")
+	assertPatch(t, response, "/spec/widgets/0/data/apm/sinceSeconds", float64(3600))
+}
+
+func newPolicy() *alerts.AlertPolicy {
+	policy := &alerts.AlertPolicy{
+		Spec: alerts.AlertPolicySpec{
+			Name:               "policy",
+			IncidentPreference: "per_policy",
+			ApmConditions: []alerts.ApmCondition{
+				{
+					Name:     "apm",
+					Type:     "apm_app_metric",
+					Entities: []string{"app"},
+					Metric:   "apdex",
+				},
+			},
+			NrqlConditions: []alerts.NrqlCondition{
+				{
+					Name:  "nrql",
+					Query: "SELECT count(*) FROM Transaction",
+				},
+			},
+			InfraConditions: []alerts.InfraCondition{
+				{
+					Name:        "infra",
+					Comparison:  "above",
+					EventType:   "SystemSample",
+					SelectValue: "cpuPercent",
+				},
+			},
+		},
+	}
+	policy.Name = "policy"
+
+	return policy
+}
+
+func newDashboard() *dashboards.Dashboard {
+	dashboard := &dashboards.Dashboard{
+		Spec: dashboards.DashboardSpec{
+			Title: "dashboard",
+			Widgets: []dashboards.Widget{
+				{
+					Title:         "apdex",
+					Visualization: "metric_line_chart",
+					Data: dashboards.Data{
+						ApmMetric: &dashboards.Apm{
+							Entities: []string{"app"},
+						},
+					},
+				},
+			},
+		},
+	}
+	dashboard.Name = "dashboard"
+
+	return dashboard
+}
+
+func newRequest(t *testing.T, namespace string, object interface{}) admission.Request {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Namespace: namespace,
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func assertAllowed(t *testing.T, response admission.Response) {
+	t.Helper()
+	if !response.Allowed {
+		t.Fatalf("Expected the request to be allowed, got %v", response.Result)
+	}
+}
+
+func assertPatch(t *testing.T, response admission.Response, path string, value interface{}) {
+	t.Helper()
+	patched, found := findPatch(response, path)
+	if !found {
+		t.Fatalf("Expected a patch for %s, got %v", path, response.Patches)
+	}
+	if patched != value {
+		t.Errorf("Expected %s to be set to %v, got %v", path, value, patched)
+	}
+}
+
+func assertNoPatch(t *testing.T, response admission.Response, path string) {
+	t.Helper()
+	if patched, found := findPatch(response, path); found {
+		t.Errorf("Expected no patch for %s, got %v", path, patched)
+	}
+}
+
+func findPatch(response admission.Response, path string) (interface{}, bool) {
+	for _, patch := range response.Patches {
+		if patch.Path == path {
+			return patch.Value, true
+		}
+	}
+
+	return nil, false
+}
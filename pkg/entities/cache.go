@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"sync"
+	"time"
+)
+
+type cacheKey struct {
+	kind     Kind
+	selector applications.Selector
+}
+
+type cacheEntry struct {
+	entities []Entity
+	expires  time.Time
+}
+
+// cache keeps the entities a selector resolved to, so that policies reconciled shortly after each other
+// do not list the same entities again. Selectors which did not match any entity are never cached.
+// A nil cache keeps nothing.
+type cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+func (cache *cache) get(kind Kind, selector applications.Selector) ([]Entity, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	key := cacheKey{kind: kind, selector: selector}
+	entry, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if cache.now().After(entry.expires) {
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	return entry.entities, true
+}
+
+func (cache *cache) put(kind Kind, selector applications.Selector, entities []Entity) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries[cacheKey{kind: kind, selector: selector}] = cacheEntry{
+		entities: entities,
+		expires:  cache.now().Add(cache.ttl),
+	}
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"fmt"
+)
+
+// Kind is the kind of New Relic entity an alert condition is applied to
+type Kind string
+
+const (
+	KindApplication        Kind = "application"
+	KindBrowserApplication Kind = "browser_application"
+	KindMobileApplication  Kind = "mobile_application"
+	KindKeyTransaction     Kind = "key_transaction"
+)
+
+type Entity struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// NotFoundError is returned when no entity of the kind matches a selector
+type NotFoundError struct {
+	Kind Kind
+	Name string
+}
+
+func (err NotFoundError) Error() string {
+	return fmt.Sprintf("%s with name %s does not exist", err.Kind, err.Name)
+}
@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"regexp"
+	"time"
+)
+
+// pageSize is the number of entities the REST API returns per page
+const pageSize = 200
+
+// lookup describes how the entities of a kind are listed by the REST API
+type lookup struct {
+	path string
+	// listKey is the field of the response which holds the entities
+	listKey string
+	// nameFilter is true when the endpoint supports filtering by name
+	nameFilter bool
+}
+
+// APM applications are looked up through the applications repository, which also supports labels
+var lookups = map[Kind]lookup{
+	KindBrowserApplication: {path: "/browser_applications.json", listKey: "browser_applications", nameFilter: true},
+	KindMobileApplication:  {path: "/mobile_applications.json", listKey: "applications"},
+	KindKeyTransaction:     {path: "/key_transactions.json", listKey: "key_transactions", nameFilter: true},
+}
+
+// Repository resolves the entities alert conditions are applied to,
+// with a separate lookup for APM applications, browser applications, mobile applications and key transactions
+type Repository struct {
+	client       internal.NewrelicClient
+	applications *applications.Repository
+	cache        *cache
+}
+
+func NewRepository(client internal.NewrelicClient) *Repository {
+	return &Repository{
+		client:       client,
+		applications: applications.NewRepository(client),
+	}
+}
+
+// WithCache returns a repository which reuses the entities a selector resolved to for the given duration
+func (repository *Repository) WithCache(ttl time.Duration) *Repository {
+	return &Repository{
+		client:       repository.client,
+		applications: repository.applications,
+		cache:        newCache(ttl),
+	}
+}
+
+// Select returns the entities of the kind which are matched by the selector.
+// A selector which does not match any entity results in a NotFoundError
+func (repository *Repository) Select(kind Kind, selector applications.Selector) ([]Entity, error) {
+	if cached, ok := repository.cache.get(kind, selector); ok {
+		return cached, nil
+	}
+
+	var result []Entity
+	var err error
+	if kind == KindApplication {
+		result, err = repository.selectApplications(selector)
+	} else {
+		result, err = repository.selectEntities(kind, selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	repository.cache.put(kind, selector, result)
+	return result, nil
+}
+
+func (repository *Repository) selectApplications(selector applications.Selector) ([]Entity, error) {
+	selected, err := repository.applications.Select(selector)
+	if err != nil {
+		var notFound applications.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, NotFoundError{Kind: KindApplication, Name: notFound.Name}
+		}
+		return nil, err
+	}
+
+	result := make([]Entity, len(selected))
+	for i, application := range selected {
+		result[i] = Entity{Id: application.Id, Name: application.Name}
+	}
+
+	return result, nil
+}
+
+func (repository *Repository) selectEntities(kind Kind, selector applications.Selector) ([]Entity, error) {
+	lookup, ok := lookups[kind]
+	if !ok {
+		return nil, fmt.Errorf("entities of kind %s cannot be looked up", kind)
+	}
+	if selector.Label != "" {
+		return nil, internal.NewClientError(fmt.Sprintf("label selectors are only supported for APM applications, not for %s entities", kind))
+	}
+
+	var candidates []Entity
+	var err error
+	if selector.Name != "" && lookup.nameFilter {
+		candidates, err = repository.getEntities(lookup, fmt.Sprintf("%s?filter[name]=%s", lookup.path, selector.Name))
+	} else {
+		candidates, err = repository.listEntities(lookup)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := newMatcher(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Entity
+	for _, entity := range candidates {
+		if matches(entity.Name) {
+			result = append(result, entity)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, NotFoundError{Kind: kind, Name: selector.String()}
+	}
+	return result, nil
+}
+
+func (repository *Repository) listEntities(lookup lookup) ([]Entity, error) {
+	var result []Entity
+	for page := 1; ; page++ {
+		entities, err := repository.getEntities(lookup, fmt.Sprintf("%s?page=%d", lookup.path, page))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, entities...)
+		if len(entities) < pageSize {
+			return result, nil
+		}
+	}
+}
+
+func (repository *Repository) getEntities(lookup lookup, endpoint string) ([]Entity, error) {
+	response, err := repository.client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var list map[string][]Entity
+	err = json.NewDecoder(response.Body).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list[lookup.listKey], nil
+}
+
+// newMatcher matches entity names against the exact name or the pattern of the selector
+func newMatcher(selector applications.Selector) (func(string) bool, error) {
+	if selector.NamePattern == "" {
+		return func(name string) bool {
+			return name == selector.Name
+		}, nil
+	}
+
+	expression, err := regexp.Compile("^(?:" + selector.NamePattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.MatchString, nil
+}
@@ -0,0 +1,105 @@
+package entities_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/applications"
+	"github.com/personio/newrelic-alert-manager/pkg/entities"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepository_Select_BrowserApplicationByName(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/browser_applications.json?filter[name]=checkout").Return(newStringResponse(`
+		{"browser_applications": [{"id": 7, "name": "checkout-legacy"}, {"id": 8, "name": "checkout"}]}
+	`), nil)
+
+	repository := entities.NewRepository(client)
+	selected, err := repository.Select(entities.KindBrowserApplication, applications.Selector{Name: "checkout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selected) != 1 || selected[0].Id != 8 {
+		t.Errorf("Expected browser application 8, got %v", selected)
+	}
+}
+
+func TestRepository_Select_MobileApplicationsByPattern(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/mobile_applications.json?page=1").Return(newStringResponse(`
+		{"applications": [{"id": 1, "name": "shop-ios"}, {"id": 2, "name": "shop-android"}, {"id": 3, "name": "admin-ios"}]}
+	`), nil)
+
+	repository := entities.NewRepository(client)
+	selected, err := repository.Select(entities.KindMobileApplication, applications.Selector{NamePattern: "shop-.*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selected) != 2 || selected[0].Id != 1 || selected[1].Id != 2 {
+		t.Errorf("Expected mobile applications 1 and 2, got %v", selected)
+	}
+}
+
+func TestRepository_Select_KeyTransactionNotFound(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/key_transactions.json?filter[name]=login").Return(newStringResponse(`{"key_transactions": []}`), nil)
+
+	repository := entities.NewRepository(client)
+	_, err := repository.Select(entities.KindKeyTransaction, applications.Selector{Name: "login"})
+	if _, ok := err.(entities.NotFoundError); !ok {
+		t.Fatalf("Expected a NotFoundError, got %v", err)
+	}
+
+	if err.Error() != "key_transaction with name login does not exist" {
+		t.Errorf("Unexpected error message %s", err.Error())
+	}
+}
+
+func TestRepository_Select_LabelOfBrowserApplication(t *testing.T) {
+	repository := entities.NewRepository(new(mocks.NewrelicClient))
+	_, err := repository.Select(entities.KindBrowserApplication, applications.Selector{Label: "Team:Payments"})
+	if !internal.IsClientError(err) {
+		t.Errorf("Expected a client error, got %v", err)
+	}
+}
+
+func TestRepository_Select_Cached(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "/applications.json?filter[name]=checkout").Return(func(string) *http.Response {
+		return newStringResponse(`{"applications": [{"id": 5, "name": "checkout"}]}`)
+	}, nil)
+	client.On("Get", "/applications.json?filter[name]=removed").Return(func(string) *http.Response {
+		return newStringResponse(`{"applications": []}`)
+	}, nil)
+
+	repository := entities.NewRepository(client).WithCache(time.Hour)
+	for i := 0; i < 2; i++ {
+		selected, err := repository.Select(entities.KindApplication, applications.Selector{Name: "checkout"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(selected) != 1 || selected[0].Id != 5 {
+			t.Errorf("Expected application 5, got %v", selected)
+		}
+
+		_, err = repository.Select(entities.KindApplication, applications.Selector{Name: "removed"})
+		if _, ok := err.(entities.NotFoundError); !ok {
+			t.Errorf("Expected a NotFoundError, got %v", err)
+		}
+	}
+
+	client.AssertNumberOfCalls(t, "Get", 3)
+}
+
+func newStringResponse(response string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(strings.NewReader(response)),
+	}
+}
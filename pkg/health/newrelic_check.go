@@ -0,0 +1,83 @@
+package health
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// newrelicCheckTtl defines how long the result of a connectivity check is reused.
+// Probes are called every few seconds, and each check consumes a request from the account's budget.
+const newrelicCheckTtl = time.Minute
+
+type checkResult struct {
+	err       error
+	checkedAt time.Time
+}
+
+// NewrelicCheck verifies that New Relic can be reached with the configured admin key.
+// Results are cached per account, so a changed admin key is checked right away.
+type NewrelicCheck struct {
+	client   internal.NewrelicClient
+	settings func() internal.ClientSettings
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	results map[string]checkResult
+}
+
+func NewNewrelicCheck(client internal.NewrelicClient, settings func() internal.ClientSettings) *NewrelicCheck {
+	return newNewrelicCheck(client, settings, time.Now)
+}
+
+func newNewrelicCheck(client internal.NewrelicClient, settings func() internal.ClientSettings, now func() time.Time) *NewrelicCheck {
+	return &NewrelicCheck{
+		client:   client,
+		settings: settings,
+		now:      now,
+		results:  make(map[string]checkResult),
+	}
+}
+
+// Check implements healthz.Checker
+func (check *NewrelicCheck) Check(_ *http.Request) error {
+	account := check.settings().AdminKey
+
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	result, ok := check.results[account]
+	if ok && check.now().Sub(result.checkedAt) < newrelicCheckTtl {
+		return result.err
+	}
+
+	result = checkResult{
+		err:       check.execute(),
+		checkedAt: check.now(),
+	}
+	check.results = map[string]checkResult{account: result}
+
+	return result.err
+}
+
+func (check *NewrelicCheck) execute() error {
+	response, err := check.client.Get("alerts_policies.json")
+	if err != nil {
+		return fmt.Errorf("could not reach New Relic: %s", err.Error())
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("the admin key was rejected by New Relic with status %d", response.StatusCode)
+	}
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("New Relic responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
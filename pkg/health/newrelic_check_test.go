@@ -0,0 +1,79 @@
+package health
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newResponse(statusCode int) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader("{}")),
+	}
+}
+
+func newSettings(adminKey *string) func() internal.ClientSettings {
+	return func() internal.ClientSettings {
+		return internal.ClientSettings{AdminKey: *adminKey}
+	}
+}
+
+func TestNewrelicCheck_CachesResult(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "alerts_policies.json").Return(newResponse(200), nil).Once()
+
+	now := time.Now()
+	adminKey := "admin-key"
+	check := newNewrelicCheck(client, newSettings(&adminKey), func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		if err := check.Check(nil); err != nil {
+			t.Error(err)
+		}
+	}
+
+	client.AssertNumberOfCalls(t, "Get", 1)
+}
+
+func TestNewrelicCheck_RechecksAfterTtl(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "alerts_policies.json").Return(newResponse(200), nil).Once()
+	client.On("Get", "alerts_policies.json").Return(newResponse(401), nil).Once()
+
+	now := time.Now()
+	adminKey := "admin-key"
+	check := newNewrelicCheck(client, newSettings(&adminKey), func() time.Time { return now })
+
+	if err := check.Check(nil); err != nil {
+		t.Error(err)
+	}
+
+	now = now.Add(2 * newrelicCheckTtl)
+	if err := check.Check(nil); err == nil {
+		t.Error("A rejected admin key should fail the check")
+	}
+}
+
+func TestNewrelicCheck_RechecksWhenAdminKeyChanges(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "alerts_policies.json").Return(newResponse(401), nil).Once()
+	client.On("Get", "alerts_policies.json").Return(newResponse(200), nil).Once()
+
+	now := time.Now()
+	adminKey := "invalid-admin-key"
+	check := newNewrelicCheck(client, newSettings(&adminKey), func() time.Time { return now })
+
+	if err := check.Check(nil); err == nil {
+		t.Error("A rejected admin key should fail the check")
+	}
+
+	adminKey = "valid-admin-key"
+	if err := check.Check(nil); err != nil {
+		t.Error(err)
+	}
+}
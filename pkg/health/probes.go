@@ -0,0 +1,96 @@
+package health
+
+import (
+	"errors"
+	"github.com/go-logr/logr"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sync/atomic"
+)
+
+// Probes serves the liveness and readiness endpoints of the operator.
+// The endpoints are served before the operator becomes the leader,
+// so that a pod waiting for the leader lock is alive but not ready.
+type Probes struct {
+	log         logr.Logger
+	leader      int32
+	cacheSynced int32
+	newrelic    *NewrelicCheck
+	progress    *Progress
+}
+
+func NewProbes(log logr.Logger, newrelic *NewrelicCheck, progress *Progress) *Probes {
+	return &Probes{
+		log:      log,
+		newrelic: newrelic,
+		progress: progress,
+	}
+}
+
+// SetLeader marks the operator as the leader
+func (probes *Probes) SetLeader() {
+	atomic.StoreInt32(&probes.leader, 1)
+}
+
+// WaitForCacheSync returns a runnable which marks the cache as synced once all informers have synced
+func (probes *Probes) WaitForCacheSync(informers cache.Cache) manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		if informers.WaitForCacheSync(stop) {
+			atomic.StoreInt32(&probes.cacheSynced, 1)
+		}
+		return nil
+	})
+}
+
+// Handler returns the handler serving `/healthz` and `/readyz`.
+// Individual checks can be queried with `/healthz/<check>` and `/readyz/<check>`, and `?verbose` lists the result of every check.
+func (probes *Probes) Handler() http.Handler {
+	liveness := &healthz.Handler{
+		Checks: map[string]healthz.Checker{
+			"ping":      healthz.Ping,
+			"reconcile": probes.progress.Check,
+		},
+	}
+	readiness := &healthz.Handler{
+		Checks: map[string]healthz.Checker{
+			"leader":     probes.checkLeader,
+			"cache-sync": probes.checkCacheSynced,
+			"newrelic":   probes.newrelic.Check,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/healthz/", http.StripPrefix("/healthz", liveness))
+	mux.Handle("/readyz", http.StripPrefix("/readyz", readiness))
+	mux.Handle("/readyz/", http.StripPrefix("/readyz", readiness))
+
+	return mux
+}
+
+// Serve starts serving the probes in the background
+func (probes *Probes) Serve(address string) {
+	go func() {
+		probes.log.Info("Serving health probes", "Address", address)
+		err := http.ListenAndServe(address, probes.Handler())
+		if err != nil {
+			probes.log.Error(err, "Health probe server stopped")
+		}
+	}()
+}
+
+func (probes *Probes) checkLeader(_ *http.Request) error {
+	if atomic.LoadInt32(&probes.leader) == 0 {
+		return errors.New("waiting to become the leader")
+	}
+	return nil
+}
+
+func (probes *Probes) checkCacheSynced(_ *http.Request) error {
+	if atomic.LoadInt32(&probes.cacheSynced) == 0 {
+		return errors.New("waiting for the caches to sync")
+	}
+	return nil
+}
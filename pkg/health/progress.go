@@ -0,0 +1,85 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sync"
+	"time"
+)
+
+// Progress tracks the running reconciles of the controllers, so that the liveness probe fails
+// when a reconcile does not return within the stall timeout, e.g. because a worker is deadlocked.
+// An idle operator stays alive, since only running reconciles are checked
+type Progress struct {
+	stallTimeout time.Duration
+	now          func() time.Time
+	mutex        sync.Mutex
+	nextId       uint64
+	running      map[uint64]runningReconcile
+}
+
+type runningReconcile struct {
+	controllerName string
+	request        reconcile.Request
+	started        time.Time
+}
+
+func NewProgress(stallTimeout time.Duration) *Progress {
+	return newProgress(stallTimeout, time.Now)
+}
+
+func newProgress(stallTimeout time.Duration, now func() time.Time) *Progress {
+	return &Progress{
+		stallTimeout: stallTimeout,
+		now:          now,
+		running:      make(map[uint64]runningReconcile),
+	}
+}
+
+// Track wraps the reconciler of a controller to record its running reconciles
+func (progress *Progress) Track(controllerName string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(request reconcile.Request) (reconcile.Result, error) {
+		id := progress.start(controllerName, request)
+		defer progress.finish(id)
+
+		return reconciler.Reconcile(request)
+	})
+}
+
+// Check fails when a reconcile has been running for longer than the stall timeout
+func (progress *Progress) Check(_ *http.Request) error {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	now := progress.now()
+	for _, running := range progress.running {
+		duration := now.Sub(running.started)
+		if duration > progress.stallTimeout {
+			return fmt.Errorf("%s has been reconciling %s for %s", running.controllerName, running.request.NamespacedName, duration.Round(time.Second))
+		}
+	}
+
+	return nil
+}
+
+func (progress *Progress) start(controllerName string, request reconcile.Request) uint64 {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	progress.nextId++
+	progress.running[progress.nextId] = runningReconcile{
+		controllerName: controllerName,
+		request:        request,
+		started:        progress.now(),
+	}
+
+	return progress.nextId
+}
+
+func (progress *Progress) finish(id uint64) {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	delete(progress.running, id)
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"net/http/httptest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgress_IdleIsAlive(t *testing.T) {
+	progress := NewProgress(time.Minute)
+
+	if err := progress.Check(nil); err != nil {
+		t.Errorf("Expected an idle operator to be alive, got %v", err)
+	}
+}
+
+func TestProgress_FailsForStalledReconcile(t *testing.T) {
+	now := time.Now()
+	progress := newProgress(time.Minute, func() time.Time { return now })
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	reconciler := progress.Track("test-controller", reconcile.Func(func(request reconcile.Request) (reconcile.Result, error) {
+		close(started)
+		<-release
+		return reconcile.Result{}, nil
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "policy"}})
+		close(done)
+	}()
+	<-started
+
+	if err := progress.Check(nil); err != nil {
+		t.Errorf("Expected a running reconcile within the stall timeout to be alive, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	err := progress.Check(nil)
+	if err == nil || !strings.Contains(err.Error(), "test-controller has been reconciling default/policy for 2m0s") {
+		t.Errorf("Expected the stalled reconcile to fail the check, got %v", err)
+	}
+
+	close(release)
+	<-done
+	if err := progress.Check(nil); err != nil {
+		t.Errorf("Expected the check to recover once the reconcile returned, got %v", err)
+	}
+}
+
+func TestProbes_LivenessReportsStalledReconcile(t *testing.T) {
+	now := time.Now()
+	progress := newProgress(time.Minute, func() time.Time { return now })
+	progress.start("test-controller", reconcile.Request{NamespacedName: types.NamespacedName{Name: "policy"}})
+	now = now.Add(2 * time.Minute)
+
+	probes := NewProbes(log.Log, nil, progress)
+	recorder := httptest.NewRecorder()
+	probes.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/healthz", nil))
+
+	if recorder.Code != 500 {
+		t.Errorf("Expected the liveness probe to fail, got status %d", recorder.Code)
+	}
+}
@@ -0,0 +1,11 @@
+package monitors
+
+type MonitorList struct {
+	Monitors []Monitor `json:"monitors"`
+	Count    int       `json:"count"`
+}
+
+type Monitor struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,75 @@
+package monitors
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+)
+
+// pageSize is the largest page the Synthetics API returns
+const pageSize = 100
+
+// Repository looks up Synthetics monitors. The Synthetics API cannot filter monitors by name,
+// so the monitors are listed page by page until one with the given name is found
+type Repository struct {
+	client internal.NewrelicClient
+}
+
+func NewRepository(client internal.NewrelicClient) *Repository {
+	return &Repository{
+		client: client,
+	}
+}
+
+func (repository Repository) GetMonitorByName(name string) (*Monitor, error) {
+	for offset := 0; ; offset += pageSize {
+		monitors, err := repository.getMonitors(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		monitor := findMonitorByName(monitors, name)
+		if monitor != nil {
+			return monitor, nil
+		}
+
+		if len(monitors.Monitors) < pageSize || offset+len(monitors.Monitors) >= monitors.Count {
+			return nil, NotFoundError{Name: name}
+		}
+	}
+}
+
+func (repository Repository) getMonitors(offset int) (*MonitorList, error) {
+	endpoint := fmt.Sprintf("monitors?offset=%d&limit=%d", offset, pageSize)
+	response, err := repository.client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var monitors MonitorList
+	err = json.NewDecoder(response.Body).Decode(&monitors)
+	if err != nil {
+		return nil, err
+	}
+
+	return &monitors, nil
+}
+
+// NotFoundError is returned when no Synthetics monitor has the given name
+type NotFoundError struct {
+	Name string
+}
+
+func (err NotFoundError) Error() string {
+	return fmt.Sprintf("monitor with name %s does not exist", err.Name)
+}
+
+func findMonitorByName(monitors *MonitorList, name string) *Monitor {
+	for _, monitor := range monitors.Monitors {
+		if monitor.Name == name {
+			return &monitor
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package monitors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/monitors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestRepository_GetMonitorByName(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "monitors?offset=0&limit=100").Return(newMonitorPage(0, 2, 2), nil)
+
+	repository := monitors.NewRepository(client)
+	monitor, err := repository.GetMonitorByName("monitor-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if monitor.Id != "id-1" {
+		t.Errorf("Expected monitor id id-1, got %s", monitor.Id)
+	}
+}
+
+func TestRepository_GetMonitorByName_NextPage(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "monitors?offset=0&limit=100").Return(newMonitorPage(0, 100, 150), nil)
+	client.On("Get", "monitors?offset=100&limit=100").Return(newMonitorPage(100, 50, 150), nil)
+
+	repository := monitors.NewRepository(client)
+	monitor, err := repository.GetMonitorByName("monitor-120")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if monitor.Id != "id-120" {
+		t.Errorf("Expected monitor id id-120, got %s", monitor.Id)
+	}
+}
+
+func TestRepository_GetMonitorByName_NotFound(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("Get", "monitors?offset=0&limit=100").Return(newMonitorPage(0, 100, 150), nil)
+	client.On("Get", "monitors?offset=100&limit=100").Return(newMonitorPage(100, 50, 150), nil)
+
+	repository := monitors.NewRepository(client)
+	_, err := repository.GetMonitorByName("unknown")
+	if _, ok := err.(monitors.NotFoundError); !ok {
+		t.Errorf("Expected a NotFoundError, got %v", err)
+	}
+
+	client.AssertNumberOfCalls(t, "Get", 2)
+}
+
+// newMonitorPage returns a page of size monitors named monitor-<n>, starting at offset
+func newMonitorPage(offset int, size int, count int) *http.Response {
+	list := monitors.MonitorList{Count: count}
+	for i := offset; i < offset+size; i++ {
+		list.Monitors = append(list.Monitors, monitors.Monitor{
+			Id:   fmt.Sprintf("id-%d", i),
+			Name: fmt.Sprintf("monitor-%d", i),
+		})
+	}
+
+	content, err := json.Marshal(list)
+	if err != nil {
+		panic(err)
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(content)),
+		Close:      false,
+	}
+}
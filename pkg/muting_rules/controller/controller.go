@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/infrastructure/k8s"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/infrastructure/newrelic"
+	"github.com/go-logr/logr"
+	"github.com/operator-framework/operator-sdk/pkg/predicate"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
+)
+
+var log = logf.Log.WithName("controller_muting_rule")
+
+// stateUpdateDelay gives New Relic time to update the state of a rule after its muting window started or ended
+const stateUpdateDelay = 30 * time.Second
+
+type ReconcileMutingRule struct {
+	k8s         *k8s.Client
+	scheme      *runtime.Scheme
+	newrelic    *newrelic.Repository
+	ruleFactory *MutingRuleFactory
+	log         logr.Logger
+	options     internal.ControllerOptions
+}
+
+func Add(mgr manager.Manager, options internal.ControllerOptions) error {
+	log.Info("Registering newrelic muting rule controller")
+
+	reconciler := &ReconcileMutingRule{
+		k8s:         k8s.NewClient(log, mgr.GetClient()),
+		scheme:      mgr.GetScheme(),
+		newrelic:    newrelic.NewRepository(log, options.NewNerdGraphClient(log)),
+		ruleFactory: NewMutingRuleFactory(mgr.GetClient()),
+		log:         log,
+		options:     options,
+	}
+
+	c, err := controller.New("newrelic-muting-rule-controller", mgr, options.ForController("newrelic-muting-rule-controller", reconciler))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource MutingRule
+	err = c.Watch(&source.Kind{Type: &v1alpha1.MutingRule{}}, &handler.EnqueueRequestForObject{}, predicate.GenerationChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileMutingRule implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileMutingRule{}
+
+// Reconcile saves the muting rule in New Relic. Rules with a schedule are reconciled again
+// when their next muting window starts or ends, so that their state is refreshed
+func (r *ReconcileMutingRule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling MutingRule")
+
+	instance, err := r.k8s.GetMutingRule(request.NamespacedName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return internal.NewReconcileResult(nil)
+		}
+
+		reqLogger.Error(err, "Error talking to API server. Re-queueing request")
+		return r.options.NewReconcileResult(err)
+	}
+
+	rule, err := r.ruleFactory.NewMutingRule(instance)
+	if instance.DeletionTimestamp != nil {
+		return r.deleteMutingRule(rule, *instance)
+	}
+
+	if err != nil {
+		reqLogger.Error(err, "Error creating muting rule")
+		instance.Status = v1alpha1.NewMutingRuleError(rule.Id, err)
+		statusErr := r.k8s.UpdateMutingRuleStatus(instance)
+		if statusErr != nil {
+			return r.options.NewReconcileResult(statusErr)
+		}
+
+		return r.options.NewReconcileResult(err)
+	}
+
+	err = r.k8s.SetFinalizer(*instance)
+	if err != nil {
+		reqLogger.Error(err, "Error setting finalizer on muting rule")
+		return r.options.NewReconcileResult(err)
+	}
+
+	state, err := r.newrelic.Save(rule)
+	if err != nil {
+		reqLogger.Error(err, "Error saving muting rule")
+		instance.Status = v1alpha1.NewMutingRuleError(rule.Id, err)
+		statusErr := r.k8s.UpdateMutingRuleStatus(instance)
+		if statusErr != nil {
+			return r.options.NewReconcileResult(statusErr)
+		}
+
+		return r.options.NewReconcileResult(err)
+	}
+
+	instance.Status = v1alpha1.NewMutingRuleReady(rule.Id, newState(state))
+	err = r.k8s.UpdateMutingRuleStatus(instance)
+	if err != nil {
+		return r.options.NewReconcileResult(err)
+	}
+
+	reqLogger.Info("Finished reconciling")
+	if next, ok := rule.NextTransition(time.Now()); ok {
+		return r.options.NewScheduledResult(time.Until(next) + stateUpdateDelay)
+	}
+
+	return r.options.NewReconcileResult(nil)
+}
+
+// deleteMutingRule only needs the id of the rule, so rules are deleted even when their policy references no longer resolve
+func (r *ReconcileMutingRule) deleteMutingRule(rule *domain.MutingRule, instance v1alpha1.MutingRule) (reconcile.Result, error) {
+	err := r.newrelic.Delete(*rule)
+	if err != nil {
+		r.log.Error(err, "Error deleting muting rule")
+		return reconcile.Result{}, err
+	}
+
+	err = r.k8s.DeleteMutingRule(instance)
+	if err != nil {
+		r.log.Error(err, "Error deleting muting rule in k8s")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// newState converts the status NerdGraph reports for a muting rule, such as ACTIVE, to the state of the custom resource, such as Active
+func newState(status string) string {
+	if status == "" {
+		return ""
+	}
+
+	return strings.ToUpper(status[:1]) + strings.ToLower(status[1:])
+}
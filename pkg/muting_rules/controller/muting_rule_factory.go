@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/domain"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+	"strings"
+)
+
+type MutingRuleFactory struct {
+	policyReader client.Reader
+}
+
+// NewMutingRuleFactory creates a factory which reads the AlertPolicy resources referenced by muting rules through the given reader
+func NewMutingRuleFactory(policyReader client.Reader) *MutingRuleFactory {
+	return &MutingRuleFactory{
+		policyReader: policyReader,
+	}
+}
+
+func (factory MutingRuleFactory) NewMutingRule(cr *v1alpha1.MutingRule) (*domain.MutingRule, error) {
+	rule := &domain.MutingRule{
+		Id:          cr.Status.NewrelicId,
+		Name:        cr.Spec.Name,
+		Description: cr.Spec.Description,
+		Enabled:     boolWithDefault(cr.Spec.Enabled, v1alpha1.DefaultMutingRuleEnabled),
+		Condition: domain.MutingRuleConditionGroup{
+			Operator: strings.ToUpper(stringWithDefault(cr.Spec.Condition.Operator, v1alpha1.DefaultMutingRuleOperator)),
+		},
+		Schedule: newSchedule(cr.Spec.Schedule),
+	}
+
+	for _, condition := range cr.Spec.Condition.Conditions {
+		values, err := factory.newValues(cr.Namespace, condition)
+		if err != nil {
+			return rule, err
+		}
+
+		rule.Condition.Conditions = append(rule.Condition.Conditions, domain.MutingRuleCondition{
+			Attribute: condition.Attribute,
+			Operator:  strings.ToUpper(condition.Operator),
+			Values:    values,
+		})
+	}
+
+	return rule, nil
+}
+
+// newValues adds the New Relic ids of the referenced policies to the values of the condition.
+// Policies which do not exist or were not created in New Relic yet return an error, so that the rule is reconciled again
+func (factory MutingRuleFactory) newValues(namespace string, condition v1alpha1.MutingRuleCondition) ([]string, error) {
+	values := append([]string{}, condition.Values...)
+	for _, reference := range condition.PolicyRefs {
+		key := reference.NamespacedName(namespace)
+
+		var policy v1alpha1.AlertPolicy
+		err := factory.policyReader.Get(context.TODO(), key, &policy)
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("AlertPolicy %s does not exist", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if policy.Status.NewrelicId == nil {
+			return nil, fmt.Errorf("AlertPolicy %s has not been created in New Relic yet", key)
+		}
+
+		values = append(values, strconv.FormatInt(*policy.Status.NewrelicId, 10))
+	}
+
+	return values, nil
+}
+
+func newSchedule(schedule *v1alpha1.MutingRuleSchedule) *domain.MutingRuleSchedule {
+	if schedule == nil {
+		return nil
+	}
+
+	result := &domain.MutingRuleSchedule{
+		StartTime:   schedule.StartTime,
+		EndTime:     schedule.EndTime,
+		TimeZone:    schedule.TimeZone,
+		Repeat:      upperCase(schedule.Repeat),
+		EndRepeat:   schedule.EndRepeat,
+		RepeatCount: schedule.RepeatCount,
+	}
+	for _, day := range schedule.WeeklyRepeatDays {
+		result.WeeklyRepeatDays = append(result.WeeklyRepeatDays, strings.ToUpper(day))
+	}
+
+	return result
+}
+
+// upperCase converts the lower case enum values of the custom resource to the enum values of NerdGraph
+func upperCase(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := strings.ToUpper(*value)
+	return &result
+}
+
+func stringWithDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
+
+func boolWithDefault(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,147 @@
+package controller_test
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"testing"
+)
+
+func TestMutingRuleFactory_NewMutingRule(t *testing.T) {
+	factory := controller.NewMutingRuleFactory(newPolicyReader())
+	cr := newMutingRule(v1alpha1.MutingRuleCondition{
+		Attribute: "conditionName",
+		Operator:  "starts_with",
+		Values:    []string{"Database"},
+	})
+	cr.Spec.Schedule = &v1alpha1.MutingRuleSchedule{
+		StartTime:        "2020-06-06T02:00:00",
+		EndTime:          "2020-06-06T04:00:00",
+		TimeZone:         "Europe/Berlin",
+		Repeat:           stringPtr("weekly"),
+		WeeklyRepeatDays: []string{"saturday", "sunday"},
+	}
+
+	rule, err := factory.NewMutingRule(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !rule.Enabled || rule.Condition.Operator != "AND" || rule.Condition.Conditions[0].Operator != "STARTS_WITH" {
+		t.Errorf("Expected an enabled rule with upper case operators, got %+v", rule)
+	}
+	if *rule.Schedule.Repeat != "WEEKLY" || !reflect.DeepEqual(rule.Schedule.WeeklyRepeatDays, []string{"SATURDAY", "SUNDAY"}) {
+		t.Errorf("Expected a weekly schedule on the weekend, got %+v", rule.Schedule)
+	}
+}
+
+func TestMutingRuleFactory_NewMutingRule_PolicyRefs(t *testing.T) {
+	factory := controller.NewMutingRuleFactory(newPolicyReader(
+		newAlertPolicy("default", "checkout", 10),
+		newAlertPolicy("payments", "ledger", 20),
+	))
+	cr := newMutingRule(v1alpha1.MutingRuleCondition{
+		Attribute: v1alpha1.MutingRuleAttributePolicyId,
+		Operator:  "in",
+		Values:    []string{"5"},
+		PolicyRefs: []v1alpha1.PolicyReference{
+			{Name: "checkout"},
+			{Name: "ledger", Namespace: "payments"},
+		},
+	})
+
+	rule, err := factory.NewMutingRule(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"5", "10", "20"}
+	if !reflect.DeepEqual(rule.Condition.Conditions[0].Values, expected) {
+		t.Errorf("Expected values %v, got %v", expected, rule.Condition.Conditions[0].Values)
+	}
+}
+
+func TestMutingRuleFactory_NewMutingRule_UnresolvedPolicyRefs(t *testing.T) {
+	pending := newAlertPolicy("default", "pending", 0)
+	pending.Status.NewrelicId = nil
+	factory := controller.NewMutingRuleFactory(newPolicyReader(pending))
+
+	tests := map[string]string{
+		"pending": "AlertPolicy default/pending has not been created in New Relic yet",
+		"missing": "AlertPolicy default/missing does not exist",
+	}
+	for name, expectedErr := range tests {
+		cr := newMutingRule(v1alpha1.MutingRuleCondition{
+			Attribute:  v1alpha1.MutingRuleAttributePolicyId,
+			Operator:   "equals",
+			PolicyRefs: []v1alpha1.PolicyReference{{Name: name}},
+		})
+
+		_, err := factory.NewMutingRule(cr)
+		if err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Errorf("Expected error %q, got %v", expectedErr, err)
+		}
+	}
+}
+
+func newMutingRule(condition v1alpha1.MutingRuleCondition) *v1alpha1.MutingRule {
+	return &v1alpha1.MutingRule{
+		ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "maintenance"},
+		Spec: v1alpha1.MutingRuleSpec{
+			Name: "Database maintenance",
+			Condition: v1alpha1.MutingRuleConditionGroup{
+				Conditions: []v1alpha1.MutingRuleCondition{condition},
+			},
+		},
+	}
+}
+
+func newAlertPolicy(namespace string, name string, newrelicId int64) *v1alpha1.AlertPolicy {
+	policy := &v1alpha1.AlertPolicy{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+	policy.Status.NewrelicId = &newrelicId
+
+	return policy
+}
+
+// policyReader returns the policies it was created with
+type policyReader struct {
+	policies map[client.ObjectKey]v1alpha1.AlertPolicy
+}
+
+func newPolicyReader(policies ...*v1alpha1.AlertPolicy) *policyReader {
+	reader := &policyReader{
+		policies: make(map[client.ObjectKey]v1alpha1.AlertPolicy),
+	}
+	for _, policy := range policies {
+		reader.policies[client.ObjectKey{Namespace: policy.Namespace, Name: policy.Name}] = *policy
+	}
+
+	return reader
+}
+
+func (r *policyReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	policy, ok := r.policies[key]
+	if !ok {
+		return errors.NewNotFound(schema.GroupResource{Group: "alerts.newrelic.io", Resource: "alertpolicies"}, key.Name)
+	}
+
+	*obj.(*v1alpha1.AlertPolicy) = policy
+	return nil
+}
+
+func (r *policyReader) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	return nil
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
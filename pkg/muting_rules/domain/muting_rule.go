@@ -0,0 +1,118 @@
+package domain
+
+import "time"
+
+// MutingRule is the NerdGraph input of a muting rule. The field names follow the NerdGraph schema.
+type MutingRule struct {
+	Id          *int64                   `json:"-"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Enabled     bool                     `json:"enabled"`
+	Condition   MutingRuleConditionGroup `json:"condition"`
+	// Rules without a schedule are saved with a null schedule, which removes the schedule of an existing rule
+	Schedule *MutingRuleSchedule `json:"schedule"`
+}
+
+type MutingRuleConditionGroup struct {
+	Operator   string                `json:"operator"`
+	Conditions []MutingRuleCondition `json:"conditions"`
+}
+
+type MutingRuleCondition struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+type MutingRuleSchedule struct {
+	StartTime        string   `json:"startTime"`
+	EndTime          string   `json:"endTime"`
+	TimeZone         string   `json:"timeZone"`
+	Repeat           *string  `json:"repeat"`
+	EndRepeat        *string  `json:"endRepeat"`
+	RepeatCount      *int     `json:"repeatCount"`
+	WeeklyRepeatDays []string `json:"weeklyRepeatDays"`
+}
+
+// NextTransition returns the next time after now at which the rule starts or stops muting violations.
+// It returns false for disabled rules and rules without a schedule, whose state only changes when they are updated
+func (rule MutingRule) NextTransition(now time.Time) (time.Time, bool) {
+	if !rule.Enabled || rule.Schedule == nil {
+		return time.Time{}, false
+	}
+
+	return rule.Schedule.NextTransition(now)
+}
+
+func (rule MutingRule) Equals(other MutingRule) bool {
+	return rule.Name == other.Name &&
+		rule.Description == other.Description &&
+		rule.Enabled == other.Enabled &&
+		rule.Condition.equals(other.Condition) &&
+		schedulesEqual(rule.Schedule, other.Schedule)
+}
+
+func (group MutingRuleConditionGroup) equals(other MutingRuleConditionGroup) bool {
+	if group.Operator != other.Operator || len(group.Conditions) != len(other.Conditions) {
+		return false
+	}
+
+	for i, condition := range group.Conditions {
+		if !condition.equals(other.Conditions[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (condition MutingRuleCondition) equals(other MutingRuleCondition) bool {
+	return condition.Attribute == other.Attribute &&
+		condition.Operator == other.Operator &&
+		stringsEqual(condition.Values, other.Values)
+}
+
+func schedulesEqual(schedule *MutingRuleSchedule, other *MutingRuleSchedule) bool {
+	if schedule == nil || other == nil {
+		return schedule == other
+	}
+
+	return schedule.StartTime == other.StartTime &&
+		schedule.EndTime == other.EndTime &&
+		schedule.TimeZone == other.TimeZone &&
+		stringPtrsEqual(schedule.Repeat, other.Repeat) &&
+		stringPtrsEqual(schedule.EndRepeat, other.EndRepeat) &&
+		intPtrsEqual(schedule.RepeatCount, other.RepeatCount) &&
+		stringsEqual(schedule.WeeklyRepeatDays, other.WeeklyRepeatDays)
+}
+
+// stringsEqual treats nil and empty lists as equal, since NerdGraph returns both for rules without values
+func stringsEqual(values []string, other []string) bool {
+	if len(values) != len(other) {
+		return false
+	}
+
+	for i, value := range values {
+		if value != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringPtrsEqual(value *string, other *string) bool {
+	if value == nil || other == nil {
+		return value == other
+	}
+
+	return *value == *other
+}
+
+func intPtrsEqual(value *int, other *int) bool {
+	if value == nil || other == nil {
+		return value == other
+	}
+
+	return *value == *other
+}
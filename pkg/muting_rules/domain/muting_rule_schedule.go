@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// scheduleTimeLayout is the layout of the local times NerdGraph expects for a schedule
+const scheduleTimeLayout = "2006-01-02T15:04:05"
+
+// NextTransition returns the next time after now at which a muting window of the schedule starts or ends.
+// It returns false when the schedule has ended or cannot be parsed
+func (schedule MutingRuleSchedule) NextTransition(now time.Time) (time.Time, bool) {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, err := time.ParseInLocation(scheduleTimeLayout, schedule.StartTime, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.ParseInLocation(scheduleTimeLayout, schedule.EndTime, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var endRepeat *time.Time
+	if schedule.EndRepeat != nil {
+		parsed, err := time.ParseInLocation(scheduleTimeLayout, *schedule.EndRepeat, location)
+		if err != nil {
+			return time.Time{}, false
+		}
+		endRepeat = &parsed
+	}
+
+	duration := end.Sub(start)
+	windows := 0
+	for i := 0; ; i++ {
+		windowStart, ok := schedule.windowStart(start, i)
+		if !ok {
+			return time.Time{}, false
+		}
+		if windowStart.IsZero() {
+			if i >= 7 && windows == 0 {
+				// None of the weekly repeat days is a valid weekday
+				return time.Time{}, false
+			}
+			continue
+		}
+		if endRepeat != nil && windowStart.After(*endRepeat) {
+			return time.Time{}, false
+		}
+
+		windows++
+		if schedule.RepeatCount != nil && windows > *schedule.RepeatCount {
+			return time.Time{}, false
+		}
+
+		if windowStart.After(now) {
+			return windowStart, true
+		}
+		if windowEnd := windowStart.Add(duration); windowEnd.After(now) {
+			return windowEnd, true
+		}
+	}
+}
+
+// windowStart returns the start of a candidate window, counted in the steps of the repeat interval.
+// Weekly schedules step through single days and return a zero time for the days which are not repeated.
+// It returns false when the schedule has no further candidates
+func (schedule MutingRuleSchedule) windowStart(start time.Time, step int) (time.Time, bool) {
+	if schedule.Repeat == nil {
+		return start, step == 0
+	}
+
+	switch *schedule.Repeat {
+	case "DAILY":
+		return start.AddDate(0, 0, step), true
+	case "MONTHLY":
+		return start.AddDate(0, step, 0), true
+	case "WEEKLY":
+		day := start.AddDate(0, 0, step)
+		if schedule.repeatsOn(day.Weekday(), start.Weekday()) {
+			return day, true
+		}
+		return time.Time{}, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// repeatsOn returns whether a weekly window starts on the weekday, which defaults to the weekday of the start time
+func (schedule MutingRuleSchedule) repeatsOn(weekday time.Weekday, startWeekday time.Weekday) bool {
+	if len(schedule.WeeklyRepeatDays) == 0 {
+		return weekday == startWeekday
+	}
+
+	for _, day := range schedule.WeeklyRepeatDays {
+		if strings.EqualFold(day, weekday.String()) {
+			return true
+		}
+	}
+
+	return false
+}
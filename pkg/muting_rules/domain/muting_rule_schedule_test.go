@@ -0,0 +1,129 @@
+package domain_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/domain"
+	"testing"
+	"time"
+)
+
+func TestMutingRule_NextTransition(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		schedule *domain.MutingRuleSchedule
+		enabled  bool
+		now      string
+		expected string
+	}{
+		{
+			name:     "one-time window which has not started",
+			schedule: newSchedule(nil, nil, nil),
+			enabled:  true,
+			now:      "2020-01-01T08:00:00",
+			expected: "2020-01-01T10:00:00",
+		},
+		{
+			name:     "one-time window which is active",
+			schedule: newSchedule(nil, nil, nil),
+			enabled:  true,
+			now:      "2020-01-01T10:30:00",
+			expected: "2020-01-01T11:00:00",
+		},
+		{
+			name:     "one-time window which has ended",
+			schedule: newSchedule(nil, nil, nil),
+			enabled:  true,
+			now:      "2020-01-01T12:00:00",
+		},
+		{
+			name:     "daily window",
+			schedule: newSchedule(stringPtr("DAILY"), nil, nil),
+			enabled:  true,
+			now:      "2020-01-05T12:00:00",
+			expected: "2020-01-06T10:00:00",
+		},
+		{
+			name:     "daily window after its repeat count",
+			schedule: newSchedule(stringPtr("DAILY"), nil, intPtr(2)),
+			enabled:  true,
+			now:      "2020-01-05T12:00:00",
+		},
+		{
+			name:     "daily window after its end repeat",
+			schedule: newSchedule(stringPtr("DAILY"), stringPtr("2020-01-03T00:00:00"), nil),
+			enabled:  true,
+			now:      "2020-01-05T12:00:00",
+		},
+		{
+			name:     "weekly window on the next repeat day",
+			schedule: newSchedule(stringPtr("WEEKLY"), nil, nil, "MONDAY", "WEDNESDAY"),
+			enabled:  true,
+			now:      "2020-01-07T12:00:00",
+			expected: "2020-01-08T10:00:00",
+		},
+		{
+			name:     "monthly window",
+			schedule: newSchedule(stringPtr("MONTHLY"), nil, nil),
+			enabled:  true,
+			now:      "2020-01-01T11:30:00",
+			expected: "2020-02-01T10:00:00",
+		},
+		{
+			name:     "disabled rule",
+			schedule: newSchedule(stringPtr("DAILY"), nil, nil),
+			enabled:  false,
+			now:      "2020-01-05T12:00:00",
+		},
+		{
+			name:    "rule without schedule",
+			enabled: true,
+			now:     "2020-01-05T12:00:00",
+		},
+	}
+
+	for _, testCase := range testCases {
+		rule := domain.MutingRule{
+			Enabled:  testCase.enabled,
+			Schedule: testCase.schedule,
+		}
+		now, _ := time.ParseInLocation("2006-01-02T15:04:05", testCase.now, berlin)
+
+		next, ok := rule.NextTransition(now)
+		if testCase.expected == "" {
+			if ok {
+				t.Errorf("%s: expected no transition, got %s", testCase.name, next)
+			}
+			continue
+		}
+
+		expected, _ := time.ParseInLocation("2006-01-02T15:04:05", testCase.expected, berlin)
+		if !ok || !next.Equal(expected) {
+			t.Errorf("%s: expected the next transition at %s, got %s", testCase.name, expected, next)
+		}
+	}
+}
+
+// newSchedule returns a schedule whose first window lasts from 10:00 to 11:00 on Wednesday, 2020-01-01 in Berlin
+func newSchedule(repeat *string, endRepeat *string, repeatCount *int, weeklyRepeatDays ...string) *domain.MutingRuleSchedule {
+	return &domain.MutingRuleSchedule{
+		StartTime:        "2020-01-01T10:00:00",
+		EndTime:          "2020-01-01T11:00:00",
+		TimeZone:         "Europe/Berlin",
+		Repeat:           repeat,
+		EndRepeat:        endRepeat,
+		RepeatCount:      repeatCount,
+		WeeklyRepeatDays: weeklyRepeatDays,
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
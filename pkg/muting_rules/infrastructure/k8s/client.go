@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Client struct {
+	logr   logr.Logger
+	client client_go.Client
+}
+
+func NewClient(logr logr.Logger, client client_go.Client) *Client {
+	return &Client{
+		logr:   logr,
+		client: client,
+	}
+}
+
+func (c *Client) GetMutingRule(name types.NamespacedName) (*v1alpha1.MutingRule, error) {
+	var instance v1alpha1.MutingRule
+	err := c.client.Get(context.TODO(), name, &instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (c *Client) DeleteMutingRule(rule v1alpha1.MutingRule) error {
+	rule.ObjectMeta.Finalizers = []string{}
+	err := c.client.Update(context.TODO(), &rule)
+	if err != nil {
+		c.logr.Error(err, "Error deleting muting rule")
+		return err
+	}
+	return nil
+}
+
+func (c *Client) UpdateMutingRuleStatus(rule *v1alpha1.MutingRule) error {
+	key := types.NamespacedName{
+		Namespace: rule.Namespace,
+		Name:      rule.Name,
+	}
+
+	return c.updateWithRetries(key, rule)
+}
+
+func (c *Client) updateWithRetries(key types.NamespacedName, rule *v1alpha1.MutingRule) error {
+	err := c.client.Status().Update(context.TODO(), rule)
+
+	if err != nil && errors.IsConflict(err) {
+		c.logr.Info("Conflict updating muting rule status, retrying")
+		serverRule, err := c.GetMutingRule(key)
+		if err != nil {
+			c.logr.Error(err, "Error updating muting rule status")
+			return err
+		}
+
+		serverRule.Status = rule.Status
+		return c.updateWithRetries(key, serverRule)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) SetFinalizer(rule v1alpha1.MutingRule) error {
+	rule.ObjectMeta.Finalizers = []string{"newrelic"}
+	err := c.client.Update(context.TODO(), &rule)
+	if err != nil {
+		if errors.IsConflict(err) {
+			c.logr.Info("Conflict adding muting rule finalizer, retrying")
+		} else {
+			c.logr.Error(err, "Error setting muting rule finalizer")
+		}
+		return err
+
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/domain"
+	"github.com/go-logr/logr"
+	"strconv"
+	"time"
+)
+
+const mutingRuleFields = `id
+status
+name
+description
+enabled
+condition {
+  operator
+  conditions {
+    attribute
+    operator
+    values
+  }
+}
+schedule {
+  startTime
+  endTime
+  timeZone
+  repeat
+  endRepeat
+  repeatCount
+  weeklyRepeatDays
+}`
+
+const getMutingRuleQuery = `query($accountId: Int!, $id: ID!) {
+  actor {
+    account(id: $accountId) {
+      alerts {
+        mutingRule(id: $id) {
+          ` + mutingRuleFields + `
+        }
+      }
+    }
+  }
+}`
+
+const createMutingRuleMutation = `mutation($accountId: Int!, $rule: AlertsMutingRuleInput!) {
+  alertsMutingRuleCreate(accountId: $accountId, rule: $rule) {
+    id
+    status
+  }
+}`
+
+const updateMutingRuleMutation = `mutation($accountId: Int!, $id: ID!, $rule: AlertsMutingRuleUpdateInput!) {
+  alertsMutingRuleUpdate(accountId: $accountId, id: $id, rule: $rule) {
+    id
+    status
+  }
+}`
+
+const deleteMutingRuleMutation = `mutation($accountId: Int!, $id: ID!) {
+  alertsMutingRuleDelete(accountId: $accountId, id: $id) {
+    id
+  }
+}`
+
+// naiveTimeLayout is the layout of the local times NerdGraph expects in the schedule of a muting rule
+const naiveTimeLayout = "2006-01-02T15:04:05"
+
+// mutingRule is a muting rule as returned by NerdGraph
+type mutingRule struct {
+	domain.MutingRule
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type getMutingRuleResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				MutingRule *mutingRule `json:"mutingRule"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+type createMutingRuleResponse struct {
+	Rule mutingRule `json:"alertsMutingRuleCreate"`
+}
+
+type updateMutingRuleResponse struct {
+	Rule mutingRule `json:"alertsMutingRuleUpdate"`
+}
+
+// Repository saves muting rules through NerdGraph
+type Repository struct {
+	logr   logr.Logger
+	client internal.NerdGraphClient
+}
+
+func NewRepository(logr logr.Logger, client internal.NerdGraphClient) *Repository {
+	return &Repository{
+		logr:   logr,
+		client: client,
+	}
+}
+
+// Save creates or updates the muting rule, sets its id and returns its state in New Relic.
+// A rule which no longer exists in New Relic is created again
+func (repository Repository) Save(rule *domain.MutingRule) (string, error) {
+	if repository.client.AccountId() == 0 {
+		return "", errors.New("muting rules can only be saved when the accountId is set in the operator configuration")
+	}
+
+	if rule.Id == nil {
+		return repository.create(rule)
+	}
+
+	existingRule, err := repository.get(*rule.Id)
+	if err != nil {
+		return "", err
+	}
+
+	if existingRule == nil {
+		return repository.create(rule)
+	}
+
+	if existingRule.Equals(*rule) {
+		return existingRule.Status, nil
+	}
+
+	return repository.update(rule)
+}
+
+func (repository Repository) create(rule *domain.MutingRule) (string, error) {
+	repository.logr.Info("Creating muting rule", "MutingRule", rule)
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"rule":      rule,
+	}
+
+	var response createMutingRuleResponse
+	err := repository.client.Query(createMutingRuleMutation, variables, &response)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := strconv.ParseInt(response.Rule.Id, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unexpected muting rule id %q: %v", response.Rule.Id, err)
+	}
+
+	rule.Id = &id
+	return response.Rule.Status, nil
+}
+
+func (repository Repository) update(rule *domain.MutingRule) (string, error) {
+	repository.logr.Info("Updating muting rule", "MutingRule", rule)
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"id":        strconv.FormatInt(*rule.Id, 10),
+		"rule":      rule,
+	}
+
+	var response updateMutingRuleResponse
+	err := repository.client.Query(updateMutingRuleMutation, variables, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Rule.Status, nil
+}
+
+func (repository Repository) get(ruleId int64) (*mutingRule, error) {
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"id":        strconv.FormatInt(ruleId, 10),
+	}
+
+	var response getMutingRuleResponse
+	err := repository.client.Query(getMutingRuleQuery, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := response.Actor.Account.Alerts.MutingRule
+	if rule == nil {
+		return nil, nil
+	}
+
+	if rule.Schedule != nil {
+		rule.Schedule.StartTime = toNaiveTime(rule.Schedule.StartTime)
+		rule.Schedule.EndTime = toNaiveTime(rule.Schedule.EndTime)
+		if rule.Schedule.EndRepeat != nil {
+			endRepeat := toNaiveTime(*rule.Schedule.EndRepeat)
+			rule.Schedule.EndRepeat = &endRepeat
+		}
+	}
+
+	return rule, nil
+}
+
+func (repository Repository) Delete(rule domain.MutingRule) error {
+	if rule.Id == nil || repository.client.AccountId() == 0 {
+		return nil
+	}
+
+	existingRule, err := repository.get(*rule.Id)
+	if err != nil {
+		return err
+	}
+
+	if existingRule == nil {
+		return nil
+	}
+
+	repository.logr.Info("Deleting muting rule", "MutingRule", rule)
+	variables := map[string]interface{}{
+		"accountId": repository.client.AccountId(),
+		"id":        strconv.FormatInt(*rule.Id, 10),
+	}
+
+	return repository.client.Query(deleteMutingRuleMutation, variables, nil)
+}
+
+// toNaiveTime converts the times NerdGraph returns, which include the offset of the time zone of the schedule,
+// to the local times it expects in mutations
+func toNaiveTime(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+
+	return parsed.Format(naiveTimeLayout)
+}
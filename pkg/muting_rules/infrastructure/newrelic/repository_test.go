@@ -0,0 +1,206 @@
+package newrelic_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/internal/mocks"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/muting_rules/infrastructure/newrelic"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"net/http"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+)
+
+var logr = logf.Log.WithName("test")
+
+const existingRuleResponse = `
+	{
+		"data": {
+			"actor": {
+				"account": {
+					"alerts": {
+						"mutingRule": {
+							"id": "7",
+							"status": "SCHEDULED",
+							"name": "Database maintenance",
+							"description": "",
+							"enabled": true,
+							"condition": {
+								"operator": "AND",
+								"conditions": [{"attribute": "policyId", "operator": "EQUALS", "values": ["10"]}]
+							},
+							"schedule": {
+								"startTime": "2020-06-06T02:00:00+02:00",
+								"endTime": "2020-06-06T04:00:00+02:00",
+								"timeZone": "Europe/Berlin",
+								"repeat": null,
+								"endRepeat": null,
+								"repeatCount": null,
+								"weeklyRepeatDays": null
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+func TestRepository_Save_CreatesRule(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("PostJson", "graphql", mock.MatchedBy(isMutation("alertsMutingRuleCreate"))).Return(
+		newResponse(`{"data": {"alertsMutingRuleCreate": {"id": "7", "status": "ACTIVE"}}}`),
+		nil,
+	)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 1))
+	rule := newRule(nil)
+	state, err := repository.Save(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule.Id == nil || *rule.Id != 7 || state != "ACTIVE" {
+		t.Errorf("Expected active rule 7, got id %v and state %s", rule.Id, state)
+	}
+	client.AssertCalled(t, "PostJson", "graphql", mock.MatchedBy(func(payload []byte) bool {
+		return strings.Contains(string(payload), `"schedule":{"startTime":"2020-06-06T02:00:00","endTime":"2020-06-06T04:00:00"`)
+	}))
+}
+
+func TestRepository_Save_KeepsUnchangedRule(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("PostJson", "graphql", mock.MatchedBy(isQuery)).Return(newResponse(existingRuleResponse), nil)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 1))
+	state, err := repository.Save(newRule(int64Ptr(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state != "SCHEDULED" {
+		t.Errorf("Expected the state of the existing rule, got %s", state)
+	}
+	client.AssertNumberOfCalls(t, "PostJson", 1)
+}
+
+func TestRepository_Save_UpdatesChangedRule(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("PostJson", "graphql", mock.MatchedBy(isQuery)).Return(newResponse(existingRuleResponse), nil)
+	client.On("PostJson", "graphql", mock.MatchedBy(isMutation("alertsMutingRuleUpdate"))).Return(
+		newResponse(`{"data": {"alertsMutingRuleUpdate": {"id": "7", "status": "INACTIVE"}}}`),
+		nil,
+	)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 1))
+	rule := newRule(int64Ptr(7))
+	rule.Enabled = false
+	state, err := repository.Save(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state != "INACTIVE" {
+		t.Errorf("Expected the state of the updated rule, got %s", state)
+	}
+	client.AssertCalled(t, "PostJson", "graphql", mock.MatchedBy(func(payload []byte) bool {
+		return isMutation("alertsMutingRuleUpdate")(payload) && strings.Contains(string(payload), `"id":"7"`)
+	}))
+}
+
+func TestRepository_Save_RecreatesDeletedRule(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("PostJson", "graphql", mock.MatchedBy(isQuery)).Return(
+		newResponse(`{"data": {"actor": {"account": {"alerts": {"mutingRule": null}}}}}`),
+		nil,
+	)
+	client.On("PostJson", "graphql", mock.MatchedBy(isMutation("alertsMutingRuleCreate"))).Return(
+		newResponse(`{"data": {"alertsMutingRuleCreate": {"id": "8", "status": "ACTIVE"}}}`),
+		nil,
+	)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 1))
+	rule := newRule(int64Ptr(7))
+	_, err := repository.Save(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *rule.Id != 8 {
+		t.Errorf("Expected the id of the new rule, got %d", *rule.Id)
+	}
+}
+
+func TestRepository_Save_RequiresAccountId(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 0))
+	_, err := repository.Save(newRule(nil))
+	if err == nil || !strings.Contains(err.Error(), "accountId") {
+		t.Errorf("Expected a missing accountId error, got %v", err)
+	}
+	client.AssertNotCalled(t, "PostJson", mock.Anything, mock.Anything)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	client := new(mocks.NewrelicClient)
+	client.On("PostJson", "graphql", mock.MatchedBy(isQuery)).Return(newResponse(existingRuleResponse), nil)
+	client.On("PostJson", "graphql", mock.MatchedBy(isMutation("alertsMutingRuleDelete"))).Return(
+		newResponse(`{"data": {"alertsMutingRuleDelete": {"id": "7"}}}`),
+		nil,
+	)
+
+	repository := newrelic.NewRepository(logr, newNerdGraphClient(client, 1))
+	err := repository.Delete(*newRule(int64Ptr(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.AssertCalled(t, "PostJson", "graphql", mock.MatchedBy(isMutation("alertsMutingRuleDelete")))
+}
+
+func newRule(id *int64) *domain.MutingRule {
+	return &domain.MutingRule{
+		Id:      id,
+		Name:    "Database maintenance",
+		Enabled: true,
+		Condition: domain.MutingRuleConditionGroup{
+			Operator: "AND",
+			Conditions: []domain.MutingRuleCondition{
+				{Attribute: "policyId", Operator: "EQUALS", Values: []string{"10"}},
+			},
+		},
+		Schedule: &domain.MutingRuleSchedule{
+			StartTime: "2020-06-06T02:00:00",
+			EndTime:   "2020-06-06T04:00:00",
+			TimeZone:  "Europe/Berlin",
+		},
+	}
+}
+
+// newNerdGraphClient sends the NerdGraph requests of the account through the mocked client to the "graphql" path
+func newNerdGraphClient(client *mocks.NewrelicClient, accountId int64) internal.NerdGraphClient {
+	return internal.NewNerdGraphClient(client, func() int64 { return accountId })
+}
+
+func isQuery(payload []byte) bool {
+	return strings.HasPrefix(string(payload), `{"query":"query`)
+}
+
+func isMutation(name string) func(payload []byte) bool {
+	return func(payload []byte) bool {
+		return strings.HasPrefix(string(payload), `{"query":"mutation`) && strings.Contains(string(payload), name)
+	}
+}
+
+func newResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
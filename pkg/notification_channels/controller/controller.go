@@ -10,31 +10,37 @@ import (
 	"github.com/operator-framework/operator-sdk/pkg/predicate"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"os"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 var log = logf.Log.WithName("controller-notification-channel")
 
+// secretRefreshInterval defines how often channels referencing secrets are reconciled again
+// so that rotated secrets are picked up without changing the channel itself
+const secretRefreshInterval = 5 * time.Minute
+
 // Reconcile reconciles a NotificationChannel object
 type Reconcile struct {
 	k8s      *k8s.Client
 	logr     logr.Logger
 	scheme   *runtime.Scheme
 	newrelic *newrelic.ChannelRepository
+	options  internal.ControllerOptions
 }
 
-func Add(mgr manager.Manager, controllerName string, channelType iov1alpha1.NotificationChannel, channelFactory iov1alpha1.ChannelFactory) error {
-	k8sClient := k8s.NewClient(log, mgr.GetClient(), channelFactory)
-	reconciler := newReconciler(mgr, k8sClient)
+func Add(mgr manager.Manager, options internal.ControllerOptions, controllerName string, channelType iov1alpha1.NotificationChannel, channelFactory iov1alpha1.ChannelFactory) error {
+	k8sClient := k8s.NewClient(log, mgr.GetClient(), mgr.GetAPIReader(), channelFactory)
+	reconciler := newReconciler(mgr, options, k8sClient)
 
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: reconciler})
+	c, err := controller.New(controllerName, mgr, options.ForController(controllerName, reconciler))
 	if err != nil {
 		return err
 	}
@@ -69,18 +75,15 @@ func Add(mgr manager.Manager, controllerName string, channelType iov1alpha1.Noti
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, k8sClient *k8s.Client) reconcile.Reconciler {
-	newrelicClient := internal.NewNewrelicClient(
-		log,
-		"https://api.newrelic.com/v2",
-		os.Getenv("NEWRELIC_ADMIN_KEY"),
-	)
+func newReconciler(mgr manager.Manager, options internal.ControllerOptions, k8sClient *k8s.Client) reconcile.Reconciler {
+	newrelicClient := options.NewRestApiClient(log)
 	repository := newrelic.NewChannelRepository(log, newrelicClient)
 	return &Reconcile{
 		logr:     log,
 		k8s:      k8sClient,
 		scheme:   mgr.GetScheme(),
 		newrelic: repository,
+		options:  options,
 	}
 }
 
@@ -98,16 +101,28 @@ func (r *Reconcile) Reconcile(request reconcile.Request) (reconcile.Result, erro
 			return internal.NewReconcileResult(nil)
 		}
 		r.logr.Error(err, "Error reading object, requeueing request")
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
 	}
 
 	policies, err := r.k8s.GetPolicies(instance)
 	if err != nil {
 		r.logr.Error(err, "Error getting policies for channel, requeueing request")
-		return internal.NewReconcileResult(err)
+		return r.options.NewReconcileResult(err)
+	}
+
+	secrets, err := r.k8s.GetSecretValues(instance)
+	if err != nil && !iov1alpha1.IsDeleted(instance) {
+		instance.SetStatus(iov1alpha1.NewChannelError(instance.GetStatus().NewrelicId, err))
+		statusErr := r.k8s.UpdateChannelStatus(instance)
+		if statusErr != nil {
+			return r.options.NewReconcileResult(statusErr)
+		}
+
+		r.logr.Error(err, "Error reading secrets for channel, requeueing request")
+		return r.options.NewReconcileResult(err)
 	}
 
-	channel := instance.NewChannel(policies)
+	channel := instance.NewChannel(policies, r.channelDefaults(), secrets)
 	channel.Channel.Configuration.PreviousVersion = instance.GetStatus().NewrelicConfigVersion
 
 	if iov1alpha1.IsDeleted(instance) {
@@ -116,14 +131,17 @@ func (r *Reconcile) Reconcile(request reconcile.Request) (reconcile.Result, erro
 		err = r.k8s.SetFinalizer(instance)
 		if err != nil {
 			reqLogger.Error(err, "Error setting finalizer on channel")
-			return internal.NewReconcileResult(err)
+			return r.options.NewReconcileResult(err)
 		}
 
+		// Channels referencing secrets are reconciled periodically, so the status is only written when it changes
 		configVersion := channel.Channel.Configuration.Version()
-		instance.SetStatus(iov1alpha1.NewChannelPending(channel.Channel.Id, configVersion))
-		err := r.k8s.UpdateChannelStatus(instance)
-		if err != nil {
-			return internal.NewReconcileResult(err)
+		if status := instance.GetStatus(); !status.IsReady() || status.NewrelicConfigVersion != configVersion {
+			instance.SetStatus(iov1alpha1.NewChannelPending(channel.Channel.Id, configVersion))
+			err := r.k8s.UpdateChannelStatus(instance)
+			if err != nil {
+				return r.options.NewReconcileResult(err)
+			}
 		}
 
 		err = r.newrelic.Save(channel)
@@ -131,21 +149,28 @@ func (r *Reconcile) Reconcile(request reconcile.Request) (reconcile.Result, erro
 			instance.SetStatus(iov1alpha1.NewChannelError(channel.Channel.Id, err))
 			statusErr := r.k8s.UpdateChannelStatus(instance)
 			if statusErr != nil {
-				return internal.NewReconcileResult(statusErr)
+				return r.options.NewReconcileResult(statusErr)
 			}
 
 			reqLogger.Error(err, "Error saving notification channel")
-			return internal.NewReconcileResult(err)
+			return r.options.NewReconcileResult(err)
 		}
 
-		instance.SetStatus(iov1alpha1.NewChannelReady(channel.Channel.Id, configVersion))
-		err = r.k8s.UpdateChannelStatus(instance)
-		if err != nil {
-			return internal.NewReconcileResult(err)
+		status := iov1alpha1.NewChannelReady(channel.Channel.Id, configVersion)
+		if !reflect.DeepEqual(instance.GetStatus(), status) {
+			instance.SetStatus(status)
+			err = r.k8s.UpdateChannelStatus(instance)
+			if err != nil {
+				return r.options.NewReconcileResult(err)
+			}
 		}
 
 		reqLogger.Info("Finished reconciling")
-		return internal.NewReconcileResult(nil)
+		if _, ok := instance.(iov1alpha1.SecretReferencingChannel); ok {
+			return r.newSecretRefreshResult()
+		}
+
+		return r.options.NewReconcileResult(nil)
 	}
 }
 
@@ -164,3 +189,21 @@ func (r *Reconcile) deleteChannel(channel domain.NotificationChannel, instance i
 
 	return reconcile.Result{}, nil
 }
+
+// newSecretRefreshResult requeues a channel referencing secrets, so that rotated secrets are picked up.
+// Secrets are read from the API server rather than watched, so that the operator does not cache every secret of the cluster
+func (r *Reconcile) newSecretRefreshResult() (reconcile.Result, error) {
+	requeueAfter := secretRefreshInterval
+	if resync := r.options.Settings.ResyncInterval(); resync > 0 && resync < requeueAfter {
+		requeueAfter = resync
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *Reconcile) channelDefaults() iov1alpha1.ChannelDefaults {
+	return iov1alpha1.ChannelDefaults{
+		SlackWebhookUrl: r.options.Settings.DefaultSlackWebhookUrl(),
+		OpsgenieApiKey:  r.options.Settings.DefaultOpsgenieApiKey(),
+	}
+}
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"bytes"
+	"encoding/json"
 	"github.com/cnf/structhash"
+	"reflect"
 	"sort"
 )
 
@@ -42,6 +45,15 @@ type Configuration struct {
 	ApiKey string `json:"api_key,omitempty"`
 	Teams  string `json:"teams,omitempty"`
 	Tags   string `json:"tags,omitempty"`
+	// PagerDuty
+	ServiceKey string `json:"service_key,omitempty" hash:"version:2"`
+	// Webhook
+	BaseUrl      string            `json:"base_url,omitempty" hash:"version:2"`
+	AuthUsername string            `json:"auth_username,omitempty" hash:"version:2"`
+	AuthPassword string            `json:"auth_password,omitempty" hash:"version:2"`
+	PayloadType  string            `json:"payload_type,omitempty" hash:"version:2"`
+	Payload      json.RawMessage   `json:"payload,omitempty" hash:"version:2"`
+	Headers      map[string]string `json:"headers,omitempty" hash:"version:2"`
 	// NewRelic does not return API keys, so we need to keep a hash
 	// of the config to know if it has been modified
 	PreviousVersion string `json:"-" hash:"-"`
@@ -51,17 +63,66 @@ func (c Configuration) IsModified() bool {
 	return c.PreviousVersion != c.Version()
 }
 
+// Version hashes the PagerDuty and webhook fields only when one of them is set,
+// so that the version of Slack, Email and OpsGenie channels stays the same
 func (c Configuration) Version() string {
-	version, _ := structhash.Hash(c, 1)
+	version, _ := structhash.Hash(c, c.hashVersion())
 	return version
 }
 
+func (c Configuration) hashVersion() int {
+	if c.ServiceKey == "" &&
+		c.BaseUrl == "" &&
+		c.AuthUsername == "" &&
+		c.AuthPassword == "" &&
+		c.PayloadType == "" &&
+		len(c.Payload) == 0 &&
+		len(c.Headers) == 0 {
+		return 1
+	}
+
+	return 2
+}
+
 func (c Configuration) Equals(other Configuration) bool {
 	return c.Channel == other.Channel &&
 		c.Recipients == other.Recipients &&
 		c.IncludeJsonAttachments == other.IncludeJsonAttachments &&
 		c.Teams == other.Teams &&
-		c.Tags == other.Tags
+		c.Tags == other.Tags &&
+		c.BaseUrl == other.BaseUrl &&
+		c.AuthUsername == other.AuthUsername &&
+		c.PayloadType == other.PayloadType &&
+		payloadEquals(c.Payload, other.Payload) &&
+		headersEqual(c.Headers, other.Headers)
+}
+
+// payloadEquals compares the JSON content of two payloads, since New Relic may format them differently
+func payloadEquals(payload json.RawMessage, other json.RawMessage) bool {
+	if len(payload) == 0 || len(other) == 0 {
+		return len(payload) == len(other)
+	}
+
+	var content, otherContent interface{}
+	if json.Unmarshal(payload, &content) != nil || json.Unmarshal(other, &otherContent) != nil {
+		return bytes.Equal(payload, other)
+	}
+
+	return reflect.DeepEqual(content, otherContent)
+}
+
+func headersEqual(headers map[string]string, other map[string]string) bool {
+	if len(headers) != len(other) {
+		return false
+	}
+
+	for name, value := range headers {
+		if otherValue, ok := other[name]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	return true
 }
 
 type Links struct {
@@ -29,6 +29,61 @@ func TestConfiguration_Version_ShouldBeEqualForEqualStructs(t *testing.T) {
 	}
 }
 
+func TestConfiguration_Version_ShouldNotChangeForExistingChannelTypes(t *testing.T) {
+	slack := domain.Configuration{Url: "https://hooks.slack.com/services/T0/B0/x", Channel: "alerts"}
+	if slack.Version() != "v1_afe861b2befb5c8908928db2aef1e092" {
+		t.Errorf("Version of Slack channels should not change, got %s", slack.Version())
+	}
+
+	opsgenie := domain.Configuration{ApiKey: "key", Teams: "team", Tags: "tag"}
+	if opsgenie.Version() != "v1_7343c382983701b5ecb829e36dcced5d" {
+		t.Errorf("Version of OpsGenie channels should not change, got %s", opsgenie.Version())
+	}
+}
+
+func TestConfiguration_Version_ShouldChangeForModifiedWebhookSecrets(t *testing.T) {
+	config1 := domain.Configuration{BaseUrl: "https://example.com", AuthPassword: "password1"}
+	config2 := domain.Configuration{BaseUrl: "https://example.com", AuthPassword: "password2"}
+
+	if config1.Version() == config2.Version() {
+		t.Error("Version should change with the password")
+	}
+}
+
+func TestConfiguration_Equals_ComparesWebhookFields(t *testing.T) {
+	config := domain.Configuration{
+		BaseUrl:      "https://example.com",
+		AuthUsername: "user",
+		PayloadType:  "application/json",
+		Payload:      []byte(`{"condition": "$CONDITION_NAME", "account": "$ACCOUNT_ID"}`),
+		Headers:      map[string]string{"X-Source": "newrelic"},
+	}
+
+	reformatted := config
+	reformatted.Payload = []byte(`{"account":"$ACCOUNT_ID","condition":"$CONDITION_NAME"}`)
+	if !config.Equals(reformatted) {
+		t.Error("Configurations with the same payload content should be equal")
+	}
+
+	otherUsername := config
+	otherUsername.AuthUsername = "other"
+	if config.Equals(otherUsername) {
+		t.Error("Configurations with different usernames should not be equal")
+	}
+
+	otherPayload := config
+	otherPayload.Payload = []byte(`{"condition": "$CONDITION_ID"}`)
+	if config.Equals(otherPayload) {
+		t.Error("Configurations with different payloads should not be equal")
+	}
+
+	otherHeaders := config
+	otherHeaders.Headers = map[string]string{"X-Source": "operator"}
+	if config.Equals(otherHeaders) {
+		t.Error("Configurations with different headers should not be equal")
+	}
+}
+
 func TestSlackNotificationChannel_Equals_WithoutPolicyIdsAttached(t *testing.T) {
 	first := domain.NotificationChannel{
 		Channel: domain.Channel{
@@ -2,24 +2,30 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	client_go "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Client struct {
-	logr    logr.Logger
-	client  client_go.Client
-	factory v1alpha1.ChannelFactory
+	logr         logr.Logger
+	client       client_go.Client
+	secretReader client_go.Reader
+	factory      v1alpha1.ChannelFactory
 }
 
-func NewClient(logr logr.Logger, client client_go.Client, factory v1alpha1.ChannelFactory) *Client {
+// NewClient creates a client for notification channels. Secrets are read with
+// secretReader, so that they don't have to be cached by the manager
+func NewClient(logr logr.Logger, client client_go.Client, secretReader client_go.Reader, factory v1alpha1.ChannelFactory) *Client {
 	return &Client{
-		logr:    logr,
-		client:  client,
-		factory: factory,
+		logr:         logr,
+		client:       client,
+		secretReader: secretReader,
+		factory:      factory,
 	}
 }
 
@@ -57,6 +63,42 @@ func (c *Client) GetPolicies(channel v1alpha1.NotificationChannel) (v1alpha1.Ale
 	return result, nil
 }
 
+// GetSecretValues reads the secret keys referenced by a channel.
+// Missing secrets and keys are an error, unless the reference is optional
+func (c *Client) GetSecretValues(channel v1alpha1.NotificationChannel) (v1alpha1.SecretValues, error) {
+	values := v1alpha1.SecretValues{}
+	referencing, ok := channel.(v1alpha1.SecretReferencingChannel)
+	if !ok {
+		return values, nil
+	}
+
+	for _, ref := range referencing.GetSecretKeyRefs() {
+		optional := ref.Optional != nil && *ref.Optional
+		key := types.NamespacedName{Namespace: channel.GetNamespace(), Name: ref.Name}
+
+		var secret corev1.Secret
+		err := c.secretReader.Get(context.TODO(), key, &secret)
+		if err != nil && errors.IsNotFound(err) && optional {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value, found := secret.Data[ref.Key]
+		if !found && optional {
+			continue
+		}
+		if !found {
+			return nil, fmt.Errorf("key %s not found in secret %s", ref.Key, key)
+		}
+
+		values.Set(ref, string(value))
+	}
+
+	return values, nil
+}
+
 func (c *Client) DeleteChannel(channel v1alpha1.NotificationChannel) error {
 	channel.SetFinalizers([]string{})
 	err := c.client.Update(context.TODO(), channel)
@@ -0,0 +1,113 @@
+package nrql
+
+import "strings"
+
+// AlertConditionRules returns the rules for the queries of NRQL alert conditions.
+// New Relic evaluates these queries once per minute and sets the time window itself,
+// so the query must aggregate the events into a single number
+func AlertConditionRules() []Rule {
+	return []Rule{
+		{
+			Name:     "alert-no-since",
+			Severity: SeverityError,
+			Check:    checkAlertTimeWindow,
+		},
+		{
+			Name:     "alert-no-timeseries",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if query.Timeseries == nil {
+					return nil
+				}
+				return []Finding{{Pos: query.Timeseries.Pos, Message: "TIMESERIES cannot be used in alert conditions"}}
+			},
+		},
+		{
+			Name:     "alert-no-compare-with",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if query.CompareWith == nil {
+					return nil
+				}
+				return []Finding{{Pos: query.CompareWith.Pos, Message: "COMPARE WITH cannot be used in alert conditions, use a baseline condition instead"}}
+			},
+		},
+		{
+			Name:     "alert-single-value",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if len(query.Select) <= 1 {
+					return nil
+				}
+				return []Finding{{Pos: query.Select[1].Expr.Position(), Message: "alert conditions must select a single value"}}
+			},
+		},
+		{
+			Name:     "alert-aggregate",
+			Severity: SeverityError,
+			Check:    checkAlertAggregate,
+		},
+		{
+			Name:     "alert-numeric-value",
+			Severity: SeverityError,
+			Check:    checkAlertNumericValue,
+		},
+	}
+}
+
+// OutlierConditionRules returns the rules for the queries of NRQL outlier conditions.
+// Outlier conditions compare the groups of a query with each other, so the query must be faceted
+func OutlierConditionRules() []Rule {
+	return append(AlertConditionRules(), Rule{
+		Name:     "outlier-facet",
+		Severity: SeverityError,
+		Check: func(query *Query) []Finding {
+			if query.Facet != nil {
+				return nil
+			}
+			return []Finding{{Pos: query.Pos, Message: "outlier conditions must group the results with FACET"}}
+		},
+	})
+}
+
+func checkAlertTimeWindow(query *Query) []Finding {
+	var findings []Finding
+	if query.Since != nil {
+		findings = append(findings, Finding{Pos: query.Since.Pos, Message: "SINCE cannot be used in alert conditions, set sinceMinutes instead"})
+	}
+	if query.Until != nil {
+		findings = append(findings, Finding{Pos: query.Until.Pos, Message: "UNTIL cannot be used in alert conditions"})
+	}
+
+	return findings
+}
+
+func checkAlertAggregate(query *Query) []Finding {
+	var findings []Finding
+	for _, item := range query.Select {
+		if !containsAggregate(item.Expr) {
+			findings = append(findings, Finding{
+				Pos:     item.Expr.Position(),
+				Message: "alert conditions must use an aggregate function such as count(*) or average(duration)",
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkAlertNumericValue(query *Query) []Finding {
+	var findings []Finding
+	for _, item := range query.Select {
+		for _, call := range functionCalls(item.Expr) {
+			if nonNumericFunctions[strings.ToLower(call.Name)] {
+				findings = append(findings, Finding{
+					Pos:     call.Pos,
+					Message: call.Name + "() does not return a number and cannot be compared against a threshold",
+				})
+			}
+		}
+	}
+
+	return findings
+}
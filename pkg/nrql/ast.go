@@ -0,0 +1,336 @@
+package nrql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pos is the position of a node in the query, starting at line 1, column 1
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Query is the root of the syntax tree of a NRQL query
+type Query struct {
+	Pos    Pos
+	Select []SelectItem
+	From   []EventType
+	Where  Expr
+	Facet  *FacetClause
+	// Since and Until define the time window of the query
+	Since *TimeClause
+	Until *TimeClause
+	// CompareWith defines the time window the query is compared against
+	CompareWith *TimeClause
+	Limit       *LimitClause
+	Timeseries  *TimeseriesClause
+	OrderBy     *OrderByClause
+	Timezone    *StringLiteral
+	Extrapolate *Keyword
+}
+
+// SelectItem is a single value in the SELECT clause
+type SelectItem struct {
+	Expr  Expr
+	Alias string
+}
+
+type EventType struct {
+	Pos  Pos
+	Name string
+}
+
+type FacetClause struct {
+	Pos   Pos
+	Items []SelectItem
+}
+
+// TimeClause holds the time of a SINCE, UNTIL or COMPARE WITH clause
+type TimeClause struct {
+	Pos Pos
+	// Value is set for relative times such as `30 minutes ago` and for epoch timestamps
+	Value float64
+	// Unit is set for relative times, normalised to its singular form, e.g. `minute`
+	Unit string
+	Ago  bool
+	// Literal is set for absolute times given as a string, e.g. `'2020-01-01 00:00:00'`
+	Literal string
+	// Keyword is set for named times such as `today`, `yesterday` or `now`,
+	// and for calendar times such as `last week`, together with the Unit
+	Keyword string
+}
+
+type LimitClause struct {
+	Pos   Pos
+	Value int
+	Max   bool
+}
+
+type TimeseriesClause struct {
+	Pos Pos
+	// Bucket is the size of each bucket, nil when it is chosen automatically
+	Bucket *TimeClause
+	Auto   bool
+	Max    bool
+	// Slide is set for sliding windows, and SlideBy holds their size unless it is chosen automatically
+	Slide   bool
+	SlideBy *TimeClause
+}
+
+type OrderByClause struct {
+	Pos        Pos
+	Expr       Expr
+	Descending bool
+}
+
+// Keyword marks a clause consisting of a single keyword
+type Keyword struct {
+	Pos Pos
+}
+
+// Expr is an expression in a NRQL query
+type Expr interface {
+	Position() Pos
+	String() string
+}
+
+// Identifier is an attribute name
+type Identifier struct {
+	Pos  Pos
+	Name string
+}
+
+type StringLiteral struct {
+	Pos   Pos
+	Value string
+}
+
+type NumberLiteral struct {
+	Pos   Pos
+	Value float64
+	Text  string
+}
+
+// DurationLiteral is a function argument such as `1 minute` in `rate(count(*), 1 minute)`
+type DurationLiteral struct {
+	Pos   Pos
+	Value float64
+	Text  string
+	// Unit is normalised to its singular form, e.g. `minute`
+	Unit string
+}
+
+// BooleanLiteral is `true` or `false`
+type BooleanLiteral struct {
+	Pos   Pos
+	Value bool
+}
+
+type NullLiteral struct {
+	Pos Pos
+}
+
+// Star selects all attributes, or all events in `count(*)`
+type Star struct {
+	Pos Pos
+}
+
+type FunctionCall struct {
+	Pos  Pos
+	Name string
+	Args []Expr
+}
+
+// NamedArgument is a function argument given by its name, e.g. `t: 0.5` in `apdex(duration, t: 0.5)`
+type NamedArgument struct {
+	Pos   Pos
+	Name  string
+	Value Expr
+}
+
+// Subquery is a nested query, e.g. in `WHERE host IN (SELECT uniques(host) FROM SystemSample)`.
+// The linter only checks the outer query
+type Subquery struct {
+	Pos   Pos
+	Query *Query
+}
+
+// Condition is a function argument filtering the events, e.g. in `filter(count(*), WHERE error IS true)`
+type Condition struct {
+	Pos   Pos
+	Where Expr
+	Alias string
+}
+
+// BinaryExpr covers arithmetic, comparisons, `LIKE` and the logical operators.
+// Operators are upper case, e.g. `AND`, `NOT LIKE` or `>=`
+type BinaryExpr struct {
+	Pos      Pos
+	Operator string
+	Left     Expr
+	Right    Expr
+}
+
+// UnaryExpr is either a negation with `-` or `NOT`
+type UnaryExpr struct {
+	Pos      Pos
+	Operator string
+	Operand  Expr
+}
+
+type InExpr struct {
+	Pos    Pos
+	Expr   Expr
+	Values []Expr
+	Not    bool
+}
+
+type IsNullExpr struct {
+	Pos  Pos
+	Expr Expr
+	Not  bool
+}
+
+func (e *Identifier) Position() Pos      { return e.Pos }
+func (e *StringLiteral) Position() Pos   { return e.Pos }
+func (e *NumberLiteral) Position() Pos   { return e.Pos }
+func (e *DurationLiteral) Position() Pos { return e.Pos }
+func (e *BooleanLiteral) Position() Pos  { return e.Pos }
+func (e *NullLiteral) Position() Pos     { return e.Pos }
+func (e *Star) Position() Pos            { return e.Pos }
+func (e *FunctionCall) Position() Pos    { return e.Pos }
+func (e *NamedArgument) Position() Pos   { return e.Pos }
+func (e *Subquery) Position() Pos        { return e.Pos }
+func (e *Condition) Position() Pos       { return e.Pos }
+func (e *BinaryExpr) Position() Pos      { return e.Pos }
+func (e *UnaryExpr) Position() Pos       { return e.Pos }
+func (e *InExpr) Position() Pos          { return e.Pos }
+func (e *IsNullExpr) Position() Pos      { return e.Pos }
+
+func (e *Identifier) String() string {
+	return e.Name
+}
+
+func (e *StringLiteral) String() string {
+	return "'" + strings.Replace(e.Value, "'", "\\'", -1) + "'"
+}
+
+func (e *NumberLiteral) String() string {
+	return e.Text
+}
+
+func (e *DurationLiteral) String() string {
+	return e.Text + " " + e.Unit
+}
+
+func (e *BooleanLiteral) String() string {
+	if e.Value {
+		return "true"
+	}
+
+	return "false"
+}
+
+func (e *NullLiteral) String() string {
+	return "NULL"
+}
+
+func (e *Star) String() string {
+	return "*"
+}
+
+func (e *FunctionCall) String() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = arg.String()
+	}
+
+	return e.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (e *NamedArgument) String() string {
+	return e.Name + ": " + e.Value.String()
+}
+
+func (e *Subquery) String() string {
+	return "(subquery)"
+}
+
+func (e *Condition) String() string {
+	result := "WHERE " + e.Where.String()
+	if e.Alias != "" {
+		result += " AS '" + e.Alias + "'"
+	}
+
+	return result
+}
+
+func (e *BinaryExpr) String() string {
+	return "(" + e.Left.String() + " " + e.Operator + " " + e.Right.String() + ")"
+}
+
+func (e *UnaryExpr) String() string {
+	if e.Operator == "-" {
+		return "-" + e.Operand.String()
+	}
+
+	return e.Operator + " " + e.Operand.String()
+}
+
+func (e *InExpr) String() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = value.String()
+	}
+
+	operator := " IN "
+	if e.Not {
+		operator = " NOT IN "
+	}
+
+	return e.Expr.String() + operator + "(" + strings.Join(values, ", ") + ")"
+}
+
+func (e *IsNullExpr) String() string {
+	if e.Not {
+		return e.Expr.String() + " IS NOT NULL"
+	}
+
+	return e.Expr.String() + " IS NULL"
+}
+
+// Walk calls visit for the expression and all of its children, depth first.
+// Children are skipped when visit returns false
+func Walk(expr Expr, visit func(Expr) bool) {
+	if expr == nil || !visit(expr) {
+		return
+	}
+
+	switch e := expr.(type) {
+	case *FunctionCall:
+		for _, arg := range e.Args {
+			Walk(arg, visit)
+		}
+	case *NamedArgument:
+		Walk(e.Value, visit)
+	case *Condition:
+		Walk(e.Where, visit)
+	case *BinaryExpr:
+		Walk(e.Left, visit)
+		Walk(e.Right, visit)
+	case *UnaryExpr:
+		Walk(e.Operand, visit)
+	case *InExpr:
+		Walk(e.Expr, visit)
+		for _, value := range e.Values {
+			Walk(value, visit)
+		}
+	case *IsNullExpr:
+		Walk(e.Expr, visit)
+	}
+}
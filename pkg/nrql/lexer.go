@@ -0,0 +1,293 @@
+package nrql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenColon
+	tokenStar
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of query"
+	case tokenIdent, tokenQuotedIdent:
+		return "identifier"
+	case tokenString:
+		return "string"
+	case tokenNumber:
+		return "number"
+	case tokenLeftParen:
+		return "'('"
+	case tokenRightParen:
+		return "')'"
+	case tokenComma:
+		return "','"
+	case tokenColon:
+		return "':'"
+	case tokenStar:
+		return "'*'"
+	default:
+		return "operator"
+	}
+}
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   Pos
+}
+
+// is reports whether the token is the given keyword. Keywords are case insensitive
+// and quoted identifiers are never keywords
+func (t token) is(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return t.kind.String()
+	}
+
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+type lexer struct {
+	input  []rune
+	offset int
+	line   int
+	column int
+}
+
+func tokenize(query string) ([]token, error) {
+	l := &lexer{
+		input:  []rune(query),
+		line:   1,
+		column: 1,
+	}
+
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipWhitespace()
+	pos := l.pos()
+	if l.offset >= len(l.input) {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+
+	start := l.offset
+	r := l.input[l.offset]
+	switch {
+	case (r == 'r' || r == 'R') && (l.peekIs(1, '\'') || l.peekIs(1, '"')):
+		l.advance()
+		value, err := l.raw(l.input[l.offset], pos)
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokenString, text: string(l.input[start:l.offset]), value: value, pos: pos}, nil
+	case isIdentStart(r):
+		for l.offset < len(l.input) && isIdentPart(l.input[l.offset]) {
+			// A colon only belongs to the identifier when the name continues after it,
+			// so that named arguments such as `t: 0.5` are split
+			if l.input[l.offset] == ':' && !l.peekIsIdentStart(1) {
+				break
+			}
+			l.advance()
+		}
+		text := string(l.input[start:l.offset])
+		return token{kind: tokenIdent, text: text, value: text, pos: pos}, nil
+	case unicode.IsDigit(r) || (r == '.' && l.peekIsDigit(1)):
+		return l.number(pos)
+	case r == '`':
+		value, err := l.quoted('`', pos)
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokenQuotedIdent, text: string(l.input[start:l.offset]), value: value, pos: pos}, nil
+	case r == '\'' || r == '"':
+		value, err := l.quoted(r, pos)
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokenString, text: string(l.input[start:l.offset]), value: value, pos: pos}, nil
+	case r == '(':
+		l.advance()
+		return token{kind: tokenLeftParen, text: "(", pos: pos}, nil
+	case r == ')':
+		l.advance()
+		return token{kind: tokenRightParen, text: ")", pos: pos}, nil
+	case r == ',':
+		l.advance()
+		return token{kind: tokenComma, text: ",", pos: pos}, nil
+	case r == ':':
+		l.advance()
+		return token{kind: tokenColon, text: ":", pos: pos}, nil
+	case r == '*':
+		l.advance()
+		return token{kind: tokenStar, text: "*", pos: pos}, nil
+	}
+
+	for _, operator := range []string{"!=", "<>", "<=", ">=", "=", "<", ">", "+", "-", "/"} {
+		if l.hasPrefix(operator) {
+			for range operator {
+				l.advance()
+			}
+			return token{kind: tokenOperator, text: operator, value: operator, pos: pos}, nil
+		}
+	}
+
+	return token{}, newSyntaxError(pos, "unexpected character '%c'", r)
+}
+
+func (l *lexer) number(pos Pos) (token, error) {
+	start := l.offset
+	seenDot := false
+	for l.offset < len(l.input) {
+		r := l.input[l.offset]
+		if r == '.' && !seenDot {
+			seenDot = true
+		} else if !unicode.IsDigit(r) {
+			break
+		}
+		l.advance()
+	}
+
+	if l.offset < len(l.input) && (l.input[l.offset] == 'e' || l.input[l.offset] == 'E') {
+		next := 1
+		if l.peekIs(1, '+') || l.peekIs(1, '-') {
+			next = 2
+		}
+		if l.peekIsDigit(next) {
+			for i := 0; i < next; i++ {
+				l.advance()
+			}
+			for l.offset < len(l.input) && unicode.IsDigit(l.input[l.offset]) {
+				l.advance()
+			}
+		}
+	}
+
+	if l.offset < len(l.input) && isIdentStart(l.input[l.offset]) {
+		return token{}, newSyntaxError(l.pos(), "unexpected character '%c' after number", l.input[l.offset])
+	}
+
+	text := string(l.input[start:l.offset])
+	return token{kind: tokenNumber, text: text, value: text, pos: pos}, nil
+}
+
+// quoted reads a quoted string or identifier. The quote character is escaped
+// either by doubling it or with a backslash
+func (l *lexer) quoted(quote rune, pos Pos) (string, error) {
+	l.advance()
+	var value strings.Builder
+	for l.offset < len(l.input) {
+		r := l.input[l.offset]
+		switch {
+		case r == '\\' && l.offset+1 < len(l.input):
+			l.advance()
+			value.WriteRune(l.input[l.offset])
+		case r == quote && l.peekIs(1, quote):
+			l.advance()
+			value.WriteRune(quote)
+		case r == quote:
+			l.advance()
+			return value.String(), nil
+		default:
+			value.WriteRune(r)
+		}
+		l.advance()
+	}
+
+	return "", newSyntaxError(pos, "unterminated %c quote", quote)
+}
+
+// raw reads a raw string such as `r'\d+'`, in which backslashes are not escape characters.
+// Raw strings are mostly used for regular expressions
+func (l *lexer) raw(quote rune, pos Pos) (string, error) {
+	l.advance()
+	start := l.offset
+	for l.offset < len(l.input) {
+		if l.input[l.offset] == quote {
+			value := string(l.input[start:l.offset])
+			l.advance()
+			return value, nil
+		}
+		l.advance()
+	}
+
+	return "", newSyntaxError(pos, "unterminated %c quote", quote)
+}
+
+func (l *lexer) skipWhitespace() {
+	for l.offset < len(l.input) && unicode.IsSpace(l.input[l.offset]) {
+		l.advance()
+	}
+}
+
+func (l *lexer) advance() {
+	if l.input[l.offset] == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	l.offset++
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Line: l.line, Column: l.column}
+}
+
+func (l *lexer) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(l.input[l.offset:]), prefix)
+}
+
+func (l *lexer) peekIs(distance int, r rune) bool {
+	return l.offset+distance < len(l.input) && l.input[l.offset+distance] == r
+}
+
+func (l *lexer) peekIsDigit(distance int) bool {
+	return l.offset+distance < len(l.input) && unicode.IsDigit(l.input[l.offset+distance])
+}
+
+func (l *lexer) peekIsIdentStart(distance int) bool {
+	return l.offset+distance < len(l.input) && isIdentStart(l.input[l.offset+distance])
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$'
+}
+
+// isIdentPart allows dots and colons, which are common in attribute names
+// such as `provider.cpuUtilization.Average` or `aws.ec2:instanceId`
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '.' || r == ':'
+}
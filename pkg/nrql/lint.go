@@ -0,0 +1,159 @@
+package nrql
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// SeverityError marks queries which New Relic rejects or evaluates differently than intended
+	SeverityError Severity = "error"
+	// SeverityWarning marks clauses which have no effect
+	SeverityWarning Severity = "warning"
+)
+
+// syntaxRule is the name reported for queries which cannot be parsed
+const syntaxRule = "syntax"
+
+// Problem is a single finding of the linter
+type Problem struct {
+	Pos      Pos
+	Severity Severity
+	Rule     string
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", p.Pos, p.Severity, p.Message, p.Rule)
+}
+
+// Finding is reported by a rule and turned into a Problem by the linter
+type Finding struct {
+	Pos     Pos
+	Message string
+}
+
+// Rule checks a parsed query
+type Rule struct {
+	Name     string
+	Severity Severity
+	Check    func(query *Query) []Finding
+}
+
+// Lint parses the query and checks it against the rules.
+// A query which cannot be parsed results in a single syntax error
+func Lint(query string, rules []Rule) []Problem {
+	parsed, err := Parse(query)
+	if err != nil {
+		pos := Pos{Line: 1, Column: 1}
+		message := err.Error()
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			pos = syntaxErr.Pos
+			message = syntaxErr.Message
+		}
+
+		return []Problem{{Pos: pos, Severity: SeverityError, Rule: syntaxRule, Message: message}}
+	}
+
+	var problems []Problem
+	for _, rule := range rules {
+		for _, finding := range rule.Check(parsed) {
+			problems = append(problems, Problem{
+				Pos:      finding.Pos,
+				Severity: rule.Severity,
+				Rule:     rule.Name,
+				Message:  finding.Message,
+			})
+		}
+	}
+
+	return problems
+}
+
+// Errors returns the problems with the error severity
+func Errors(problems []Problem) []Problem {
+	var errors []Problem
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			errors = append(errors, problem)
+		}
+	}
+
+	return errors
+}
+
+// aggregateFunctions lists the functions which aggregate events into a single value per facet or bucket
+var aggregateFunctions = map[string]bool{
+	"apdex":            true,
+	"average":          true,
+	"bucketpercentile": true,
+	"cdfpercentage":    true,
+	"count":            true,
+	"derivative":       true,
+	"earliest":         true,
+	"filter":           true,
+	"funnel":           true,
+	"histogram":        true,
+	"keyset":           true,
+	"latest":           true,
+	"latestrate":       true,
+	"max":              true,
+	"median":           true,
+	"min":              true,
+	"percentage":       true,
+	"percentile":       true,
+	"predictlinear":    true,
+	"rate":             true,
+	"stddev":           true,
+	"sum":              true,
+	"uniquecount":      true,
+	"uniques":          true,
+}
+
+// nonNumericFunctions lists the aggregate functions which return lists or buckets instead of a number
+var nonNumericFunctions = map[string]bool{
+	"histogram": true,
+	"keyset":    true,
+	"uniques":   true,
+}
+
+func isAggregate(call *FunctionCall) bool {
+	return aggregateFunctions[strings.ToLower(call.Name)]
+}
+
+// functionCalls returns the function calls in the expression, outermost first
+func functionCalls(expr Expr) []*FunctionCall {
+	var calls []*FunctionCall
+	Walk(expr, func(e Expr) bool {
+		if call, ok := e.(*FunctionCall); ok {
+			calls = append(calls, call)
+		}
+		return true
+	})
+
+	return calls
+}
+
+func containsAggregate(expr Expr) bool {
+	for _, call := range functionCalls(expr) {
+		if isAggregate(call) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFunction(query *Query, name string) bool {
+	for _, item := range query.Select {
+		for _, call := range functionCalls(item.Expr) {
+			if strings.EqualFold(call.Name, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
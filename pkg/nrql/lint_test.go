@@ -0,0 +1,100 @@
+package nrql_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/nrql"
+	"testing"
+)
+
+func TestLint_AlertCondition(t *testing.T) {
+	tests := []struct {
+		query string
+		rules []string
+	}{
+		{query: "SELECT average(cpuUsedCores/cpuLimitCores)*100 FROM K8sContainerSample FACET podName"},
+		{query: "SELECT rate(count(*), 1 minute) FROM Transaction"},
+		{query: "SELECT derivative(memoryUsedBytes, 1 minute) FROM K8sContainerSample FACET podName"},
+		{query: "SELECT apdex(duration, t: 0.5) FROM Transaction WHERE appName = 'api'"},
+		{query: "SELECT count(*) FROM Log WHERE message RLIKE r'.*timeout \\d+.*'"},
+		{query: "SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample WHERE cpuPercent > 90)"},
+		{query: "SELECT count(*) FROM Transaction SINCE 5 minutes ago", rules: []string{"alert-no-since"}},
+		{query: "SELECT count(*) FROM Transaction TIMESERIES", rules: []string{"alert-no-timeseries"}},
+		{query: "SELECT count(*) FROM Transaction COMPARE WITH 1 day ago", rules: []string{"alert-no-compare-with"}},
+		{query: "SELECT count(*), average(duration) FROM Transaction", rules: []string{"alert-single-value"}},
+		{query: "SELECT duration FROM Transaction", rules: []string{"alert-aggregate"}},
+		{query: "SELECT uniques(host) FROM Transaction", rules: []string{"alert-numeric-value"}},
+		{query: "SELECT count(* FROM Transaction", rules: []string{"syntax"}},
+	}
+
+	for _, test := range tests {
+		problems := nrql.Lint(test.query, nrql.AlertConditionRules())
+		assertRules(t, test.query, problems, test.rules)
+	}
+}
+
+func TestLint_OutlierCondition(t *testing.T) {
+	tests := []struct {
+		query string
+		rules []string
+	}{
+		{query: "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET podName"},
+		{query: "SELECT average(cpuUsedCores) FROM K8sContainerSample", rules: []string{"outlier-facet"}},
+		{query: "SELECT average(cpuUsedCores) FROM K8sContainerSample SINCE 5 minutes ago", rules: []string{"alert-no-since", "outlier-facet"}},
+	}
+
+	for _, test := range tests {
+		problems := nrql.Lint(test.query, nrql.OutlierConditionRules())
+		assertRules(t, test.query, problems, test.rules)
+	}
+}
+
+func TestLint_Widget(t *testing.T) {
+	tests := []struct {
+		visualization string
+		query         string
+		rules         []string
+	}{
+		{visualization: "faceted_line_chart", query: "SELECT average(duration) FROM Transaction FACET name TIMESERIES SINCE 1 hour ago"},
+		{visualization: "line_chart", query: "SELECT rate(count(*), 1 minute) FROM Transaction TIMESERIES"},
+		{visualization: "billboard", query: "SELECT derivative(memoryUsedBytes, 1 minute) FROM K8sContainerSample"},
+		{visualization: "faceted_line_chart", query: "SELECT average(duration) FROM Transaction TIMESERIES", rules: []string{"widget-facet"}},
+		{visualization: "line_chart", query: "SELECT average(duration) FROM Transaction", rules: []string{"widget-timeseries"}},
+		{visualization: "billboard", query: "SELECT count(*) FROM Transaction TIMESERIES", rules: []string{"widget-no-timeseries"}},
+		{visualization: "billboard_comparison", query: "SELECT count(*) FROM Transaction", rules: []string{"widget-compare-with"}},
+		{visualization: "facet_table", query: "SELECT name FROM Transaction FACET host", rules: []string{"widget-aggregate"}},
+		{visualization: "gauge", query: "SELECT count(*), max(duration) FROM Transaction", rules: []string{"widget-single-value"}},
+		{visualization: "histogram", query: "SELECT count(*) FROM Transaction", rules: []string{"widget-function"}},
+		{visualization: "event_table", query: "SELECT count(*) FROM Transaction", rules: []string{"widget-no-aggregate"}},
+		{visualization: "metric_line_chart", query: "SELECT count(*) FROM Transaction", rules: []string{"widget-apm-metric"}},
+		{visualization: "unknown", query: "SELECT count(*) FROM Transaction TIMESERIES"},
+	}
+
+	for _, test := range tests {
+		problems := nrql.Lint(test.query, nrql.WidgetRules(test.visualization))
+		assertRules(t, test.visualization+": "+test.query, problems, test.rules)
+	}
+}
+
+func TestErrors_SkipsWarnings(t *testing.T) {
+	problems := nrql.Lint("SELECT count(*) FROM Transaction TIMESERIES", nrql.WidgetRules("billboard"))
+
+	if len(problems) != 1 {
+		t.Fatalf("Expected a warning, got %v", problems)
+	}
+	if len(nrql.Errors(problems)) != 0 {
+		t.Errorf("Expected no errors, got %v", nrql.Errors(problems))
+	}
+}
+
+func assertRules(t *testing.T, name string, problems []nrql.Problem, rules []string) {
+	t.Helper()
+	if len(problems) != len(rules) {
+		t.Errorf("%s: expected problems %v, got %v", name, rules, problems)
+		return
+	}
+
+	for i, problem := range problems {
+		if problem.Rule != rules[i] {
+			t.Errorf("%s: expected problems %v, got %v", name, rules, problems)
+		}
+	}
+}
@@ -0,0 +1,791 @@
+package nrql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError is returned when a query cannot be parsed
+type SyntaxError struct {
+	Pos     Pos
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Pos.String() + ": " + e.Message
+}
+
+func newSyntaxError(pos Pos, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// reservedWords cannot be used as attribute names unless they are quoted with backticks
+var reservedWords = map[string]bool{
+	"select":      true,
+	"from":        true,
+	"where":       true,
+	"facet":       true,
+	"since":       true,
+	"until":       true,
+	"limit":       true,
+	"timeseries":  true,
+	"compare":     true,
+	"with":        true,
+	"order":       true,
+	"extrapolate": true,
+	"slide":       true,
+	"as":          true,
+	"and":         true,
+	"or":          true,
+	"not":         true,
+	"like":        true,
+	"rlike":       true,
+	"in":          true,
+	"is":          true,
+}
+
+var timeUnits = map[string]string{
+	"second":  "second",
+	"seconds": "second",
+	"minute":  "minute",
+	"minutes": "minute",
+	"hour":    "hour",
+	"hours":   "hour",
+	"day":     "day",
+	"days":    "day",
+	"week":    "week",
+	"weeks":   "week",
+	"month":   "month",
+	"months":  "month",
+	"quarter": "quarter",
+	"year":    "year",
+	"years":   "year",
+}
+
+var comparisonOperators = map[string]bool{
+	"=":  true,
+	"!=": true,
+	"<>": true,
+	"<":  true,
+	"<=": true,
+	">":  true,
+	">=": true,
+}
+
+type parser struct {
+	tokens  []token
+	current int
+	// depth is the number of subqueries the parser is in
+	depth int
+}
+
+// Parse builds the syntax tree of a NRQL query.
+// The clauses following SELECT and FROM may appear in any order, but each of them only once
+func Parse(query string) (*Query, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	return p.parseQuery()
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	var err error
+	query := &Query{Pos: p.peek().pos}
+	switch {
+	case p.accept("select"):
+		if query.Select, err = p.parseSelectItems(); err != nil {
+			return nil, err
+		}
+		if _, err = p.expectKeyword("from"); err != nil {
+			return nil, err
+		}
+		if query.From, err = p.parseEventTypes(); err != nil {
+			return nil, err
+		}
+	case p.accept("from"):
+		if query.From, err = p.parseEventTypes(); err != nil {
+			return nil, err
+		}
+		if _, err = p.expectKeyword("select"); err != nil {
+			return nil, err
+		}
+		if query.Select, err = p.parseSelectItems(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.unexpected("SELECT or FROM")
+	}
+
+	for p.peek().kind != tokenEOF && !(p.depth > 0 && p.peek().kind == tokenRightParen) {
+		if err := p.parseClause(query); err != nil {
+			return nil, err
+		}
+	}
+
+	return query, nil
+}
+
+func (p *parser) parseClause(query *Query) error {
+	clause := p.next()
+	var err error
+	switch {
+	case clause.is("where"):
+		if query.Where != nil {
+			return duplicateClause(clause, "WHERE")
+		}
+		query.Where, err = p.parseExpr()
+	case clause.is("facet"):
+		if query.Facet != nil {
+			return duplicateClause(clause, "FACET")
+		}
+		query.Facet = &FacetClause{Pos: clause.pos}
+		query.Facet.Items, err = p.parseSelectItems()
+	case clause.is("since"):
+		if query.Since != nil {
+			return duplicateClause(clause, "SINCE")
+		}
+		query.Since, err = p.parseTime()
+	case clause.is("until"):
+		if query.Until != nil {
+			return duplicateClause(clause, "UNTIL")
+		}
+		query.Until, err = p.parseTime()
+	case clause.is("compare"):
+		if query.CompareWith != nil {
+			return duplicateClause(clause, "COMPARE WITH")
+		}
+		if _, err = p.expectKeyword("with"); err != nil {
+			return err
+		}
+		query.CompareWith, err = p.parseTime()
+	case clause.is("with"):
+		if query.Timezone != nil {
+			return duplicateClause(clause, "WITH TIMEZONE")
+		}
+		if _, err = p.expectKeyword("timezone"); err != nil {
+			return err
+		}
+		var timezone token
+		if timezone, err = p.expect(tokenString); err != nil {
+			return err
+		}
+		query.Timezone = &StringLiteral{Pos: timezone.pos, Value: timezone.value}
+	case clause.is("limit"):
+		if query.Limit != nil {
+			return duplicateClause(clause, "LIMIT")
+		}
+		query.Limit, err = p.parseLimit(clause)
+	case clause.is("timeseries"):
+		if query.Timeseries != nil {
+			return duplicateClause(clause, "TIMESERIES")
+		}
+		query.Timeseries, err = p.parseTimeseries(clause)
+	case clause.is("slide"):
+		// SLIDE BY may follow other clauses after TIMESERIES
+		if query.Timeseries == nil {
+			return newSyntaxError(clause.pos, "SLIDE BY can only be used together with TIMESERIES")
+		}
+		if query.Timeseries.Slide {
+			return duplicateClause(clause, "SLIDE BY")
+		}
+		err = p.parseSlideBy(query.Timeseries)
+	case clause.is("order"):
+		if query.OrderBy != nil {
+			return duplicateClause(clause, "ORDER BY")
+		}
+		if _, err = p.expectKeyword("by"); err != nil {
+			return err
+		}
+		query.OrderBy = &OrderByClause{Pos: clause.pos}
+		if query.OrderBy.Expr, err = p.parseExpr(); err != nil {
+			return err
+		}
+		if p.accept("desc") {
+			query.OrderBy.Descending = true
+		} else {
+			p.accept("asc")
+		}
+	case clause.is("extrapolate"):
+		if query.Extrapolate != nil {
+			return duplicateClause(clause, "EXTRAPOLATE")
+		}
+		query.Extrapolate = &Keyword{Pos: clause.pos}
+	default:
+		p.current--
+		return p.unexpected("a clause such as WHERE, FACET or SINCE")
+	}
+
+	return err
+}
+
+func (p *parser) parseSelectItems() ([]SelectItem, error) {
+	var items []SelectItem
+	for {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		alias, err := p.parseAlias()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, SelectItem{Expr: expr, Alias: alias})
+		if !p.acceptKind(tokenComma) {
+			return items, nil
+		}
+	}
+}
+
+func (p *parser) parseAlias() (string, error) {
+	if !p.accept("as") {
+		return "", nil
+	}
+
+	alias := p.next()
+	switch alias.kind {
+	case tokenString, tokenQuotedIdent, tokenIdent:
+		return alias.value, nil
+	default:
+		p.current--
+		return "", p.unexpected("an alias")
+	}
+}
+
+func (p *parser) parseEventTypes() ([]EventType, error) {
+	var eventTypes []EventType
+	for {
+		eventType := p.next()
+		if eventType.kind == tokenLeftParen {
+			return nil, newSyntaxError(eventType.pos, "subqueries are not supported")
+		}
+		if !isIdentifier(eventType) {
+			p.current--
+			return nil, p.unexpected("an event type")
+		}
+
+		eventTypes = append(eventTypes, EventType{Pos: eventType.pos, Name: eventType.value})
+		if !p.acceptKind(tokenComma) {
+			return eventTypes, nil
+		}
+	}
+}
+
+func (p *parser) parseTime() (*TimeClause, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, newSyntaxError(t.pos, "invalid number %s", t)
+		}
+
+		clause := &TimeClause{Pos: t.pos, Value: value}
+		if unit, ok := timeUnits[strings.ToLower(p.peek().text)]; ok && p.peek().kind == tokenIdent {
+			p.next()
+			clause.Unit = unit
+			clause.Ago = p.accept("ago")
+		}
+		return clause, nil
+	case t.kind == tokenString:
+		return &TimeClause{Pos: t.pos, Literal: t.value}, nil
+	case t.is("today"), t.is("yesterday"), t.is("now"):
+		return &TimeClause{Pos: t.pos, Keyword: strings.ToLower(t.text)}, nil
+	case t.is("last"), t.is("this"):
+		unit, ok := timeUnits[strings.ToLower(p.peek().text)]
+		if !ok || p.peek().kind != tokenIdent {
+			return nil, p.unexpected("a time unit such as week")
+		}
+		p.next()
+		return &TimeClause{Pos: t.pos, Keyword: strings.ToLower(t.text), Unit: unit}, nil
+	default:
+		p.current--
+		return nil, p.unexpected("a time such as 30 minutes ago")
+	}
+}
+
+func (p *parser) parseDuration() (*TimeClause, error) {
+	pos := p.peek().pos
+	clause, err := p.parseTime()
+	if err != nil {
+		return nil, err
+	}
+	if clause.Unit == "" || clause.Keyword != "" || clause.Ago {
+		return nil, newSyntaxError(pos, "expected a duration such as 5 minutes")
+	}
+
+	return clause, nil
+}
+
+func (p *parser) parseLimit(clause token) (*LimitClause, error) {
+	if p.accept("max") {
+		return &LimitClause{Pos: clause.pos, Max: true}, nil
+	}
+
+	value, err := p.expect(tokenNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := strconv.Atoi(value.value)
+	if err != nil {
+		return nil, newSyntaxError(value.pos, "the limit must be a whole number")
+	}
+
+	return &LimitClause{Pos: clause.pos, Value: limit}, nil
+}
+
+func (p *parser) parseTimeseries(clause token) (*TimeseriesClause, error) {
+	var err error
+	timeseries := &TimeseriesClause{Pos: clause.pos}
+	switch {
+	case p.accept("auto"):
+		timeseries.Auto = true
+	case p.accept("max"):
+		timeseries.Max = true
+	case p.peek().kind == tokenNumber:
+		if timeseries.Bucket, err = p.parseDuration(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept("slide") {
+		if err = p.parseSlideBy(timeseries); err != nil {
+			return nil, err
+		}
+	}
+
+	return timeseries, nil
+}
+
+// parseSlideBy parses the window of a SLIDE BY clause, following the SLIDE keyword
+func (p *parser) parseSlideBy(timeseries *TimeseriesClause) error {
+	var err error
+	if _, err = p.expectKeyword("by"); err != nil {
+		return err
+	}
+
+	timeseries.Slide = true
+	if p.accept("auto") || p.accept("max") {
+		return nil
+	}
+
+	timeseries.SlideBy, err = p.parseDuration()
+	return err
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		operator := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Pos: operator.pos, Operator: "OR", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		operator := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Pos: operator.pos, Operator: "AND", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.peek().is("not") {
+		operator := p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Pos: operator.pos, Operator: "NOT", Operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	operator := p.peek()
+	switch {
+	case operator.kind == tokenOperator && comparisonOperators[operator.text]:
+		p.next()
+		return p.parseBinaryRight(operator.pos, operator.text, left)
+	case operator.is("like"), operator.is("rlike"):
+		p.next()
+		return p.parseBinaryRight(operator.pos, strings.ToUpper(operator.text), left)
+	case operator.is("in"):
+		p.next()
+		return p.parseIn(operator.pos, left, false)
+	case operator.is("is"):
+		p.next()
+		return p.parseIs(operator.pos, left)
+	case operator.is("not"):
+		p.next()
+		next := p.next()
+		switch {
+		case next.is("like"), next.is("rlike"):
+			return p.parseBinaryRight(operator.pos, "NOT "+strings.ToUpper(next.text), left)
+		case next.is("in"):
+			return p.parseIn(operator.pos, left, true)
+		default:
+			p.current--
+			return nil, p.unexpected("LIKE or IN")
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseBinaryRight(pos Pos, operator string, left Expr) (Expr, error) {
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BinaryExpr{Pos: pos, Operator: operator, Left: left, Right: right}, nil
+}
+
+func (p *parser) parseIn(pos Pos, left Expr, not bool) (Expr, error) {
+	if _, err := p.expect(tokenLeftParen); err != nil {
+		return nil, err
+	}
+
+	in := &InExpr{Pos: pos, Expr: left, Not: not}
+	if p.peek().is("select") || p.peek().is("from") {
+		subquery, err := p.parseSubquery()
+		if err != nil {
+			return nil, err
+		}
+		in.Values = append(in.Values, subquery)
+		if _, err := p.expect(tokenRightParen); err != nil {
+			return nil, err
+		}
+		return in, nil
+	}
+
+	for {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		in.Values = append(in.Values, value)
+
+		if !p.acceptKind(tokenComma) {
+			break
+		}
+	}
+
+	if _, err := p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+func (p *parser) parseSubquery() (Expr, error) {
+	pos := p.peek().pos
+	p.depth++
+	query, err := p.parseQuery()
+	p.depth--
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subquery{Pos: pos, Query: query}, nil
+}
+
+func (p *parser) parseIs(pos Pos, left Expr) (Expr, error) {
+	not := p.accept("not")
+	value := p.next()
+	switch {
+	case value.is("null"):
+		return &IsNullExpr{Pos: pos, Expr: left, Not: not}, nil
+	case value.is("true"), value.is("false"):
+		operator := "IS"
+		if not {
+			operator = "IS NOT"
+		}
+		right := &BooleanLiteral{Pos: value.pos, Value: value.is("true")}
+		return &BinaryExpr{Pos: pos, Operator: operator, Left: left, Right: right}, nil
+	default:
+		p.current--
+		return nil, p.unexpected("NULL, TRUE or FALSE")
+	}
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && (p.peek().text == "+" || p.peek().text == "-") {
+		operator := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Pos: operator.pos, Operator: operator.text, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenStar || (p.peek().kind == tokenOperator && p.peek().text == "/") {
+		operator := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Pos: operator.pos, Operator: operator.text, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.peek().kind == tokenOperator && p.peek().text == "-" {
+		operator := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Pos: operator.pos, Operator: "-", Operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, newSyntaxError(t.pos, "invalid number %s", t)
+		}
+		return &NumberLiteral{Pos: t.pos, Value: value, Text: t.text}, nil
+	case tokenString:
+		return &StringLiteral{Pos: t.pos, Value: t.value}, nil
+	case tokenStar:
+		return &Star{Pos: t.pos}, nil
+	case tokenQuotedIdent:
+		return &Identifier{Pos: t.pos, Name: t.value}, nil
+	case tokenLeftParen:
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightParen); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLeftParen {
+			return p.parseFunctionCall(t)
+		}
+
+		switch {
+		case t.is("true"), t.is("false"):
+			return &BooleanLiteral{Pos: t.pos, Value: t.is("true")}, nil
+		case t.is("null"):
+			return &NullLiteral{Pos: t.pos}, nil
+		case reservedWords[strings.ToLower(t.text)]:
+			return nil, newSyntaxError(t.pos, "expected an expression, found keyword %s", strings.ToUpper(t.text))
+		}
+		return &Identifier{Pos: t.pos, Name: t.value}, nil
+	}
+
+	p.current--
+	return nil, p.unexpected("an expression")
+}
+
+func (p *parser) parseFunctionCall(name token) (Expr, error) {
+	p.next()
+	call := &FunctionCall{Pos: name.pos, Name: name.text}
+	if p.acceptKind(tokenRightParen) {
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+
+		if !p.acceptKind(tokenComma) {
+			break
+		}
+	}
+
+	if _, err := p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+
+	return call, nil
+}
+
+func (p *parser) parseArgument() (Expr, error) {
+	if p.isDurationArgument() {
+		return p.parseDurationArgument()
+	}
+	if p.isNamedArgument() {
+		name := p.next()
+		p.next()
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &NamedArgument{Pos: name.pos, Name: name.value, Value: value}, nil
+	}
+	if !p.peek().is("where") {
+		return p.parseExpr()
+	}
+
+	where := p.next()
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := p.parseAlias()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Condition{Pos: where.pos, Where: expr, Alias: alias}, nil
+}
+
+// isDurationArgument returns true when the current argument is a number followed by a time unit, e.g. `1 minute`
+func (p *parser) isDurationArgument() bool {
+	if p.peek().kind != tokenNumber || p.current+1 >= len(p.tokens) {
+		return false
+	}
+
+	unit := p.tokens[p.current+1]
+	_, ok := timeUnits[strings.ToLower(unit.text)]
+	return ok && unit.kind == tokenIdent
+}
+
+// isNamedArgument returns true when the current argument is given by its name, e.g. `t: 0.5`
+func (p *parser) isNamedArgument() bool {
+	return p.peek().kind == tokenIdent && p.current+1 < len(p.tokens) && p.tokens[p.current+1].kind == tokenColon
+}
+
+func (p *parser) parseDurationArgument() (Expr, error) {
+	t := p.next()
+	value, err := strconv.ParseFloat(t.value, 64)
+	if err != nil {
+		return nil, newSyntaxError(t.pos, "invalid number %s", t)
+	}
+
+	unit := p.next()
+	return &DurationLiteral{Pos: t.pos, Value: value, Text: t.value, Unit: timeUnits[strings.ToLower(unit.text)]}, nil
+}
+
+// peek returns the current token. Reading past the end of the query keeps returning the EOF token
+func (p *parser) peek() token {
+	if p.current >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+
+	return p.tokens[p.current]
+}
+
+// next returns the current token and moves to the following one
+func (p *parser) next() token {
+	t := p.peek()
+	p.current++
+
+	return t
+}
+
+func (p *parser) accept(keyword string) bool {
+	if p.peek().is(keyword) {
+		p.next()
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) acceptKind(kind tokenKind) bool {
+	if p.peek().kind == kind {
+		p.next()
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	if p.peek().kind != kind {
+		return token{}, p.unexpected(kind.String())
+	}
+
+	return p.next(), nil
+}
+
+func (p *parser) expectKeyword(keyword string) (token, error) {
+	if !p.peek().is(keyword) {
+		return token{}, p.unexpected(strings.ToUpper(keyword))
+	}
+
+	return p.next(), nil
+}
+
+func (p *parser) unexpected(expected string) *SyntaxError {
+	found := p.peek()
+	return newSyntaxError(found.pos, "expected %s, found %s", expected, found)
+}
+
+func duplicateClause(clause token, name string) *SyntaxError {
+	return newSyntaxError(clause.pos, "the %s clause can only be used once", name)
+}
+
+func isIdentifier(t token) bool {
+	return t.kind == tokenQuotedIdent || (t.kind == tokenIdent && !reservedWords[strings.ToLower(t.text)])
+}
@@ -0,0 +1,242 @@
+package nrql_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/nrql"
+	"testing"
+)
+
+func TestParse_ValidQueries(t *testing.T) {
+	queries := []string{
+		"SELECT count(*) FROM Transaction",
+		"select count(*) from Transaction since 1 hour ago",
+		"FROM Transaction SELECT average(duration) WHERE appName = 'api' FACET name",
+		"SELECT average(cpuUsedCores/cpuLimitCores) * 100 FROM K8sContainerSample WHERE deploymentName = 'api' FACET podName TIMESERIES",
+		"SELECT average(`provider.databaseConnections.Average`) as 'connections in use' From DatastoreSample WHERE provider = 'RdsDbInstance' and displayName IN ('microservices-dev', 'dev') FACET displayName TIMESERIES Since 1 hour ago Until 10 minutes ago",
+		"SELECT percentile(duration, 95, 99) FROM Transaction FACET name WHERE appName = 'api' LIMIT 20",
+		"SELECT max(restartCount) - min(restartCount) FROM K8sContainerSample FACET podName, containerName",
+		"SELECT filter(count(*), WHERE error IS true) / count(*) FROM Transaction COMPARE WITH 1 week ago",
+		"SELECT funnel(session, WHERE pageUrl LIKE '%/cart' AS 'cart', WHERE pageUrl NOT LIKE '%/checkout') FROM PageView SINCE yesterday",
+		"SELECT count(*) FROM Transaction FACET CASES(WHERE duration < 1 AS 'fast', WHERE duration >= 1 AS 'slow')",
+		"SELECT count(*) FROM Transaction WHERE host IS NOT NULL AND NOT (name = 'health' OR name = 'ready') TIMESERIES 5 minutes SLIDE BY 1 minute",
+		"SELECT uniques(host) FROM SystemSample SINCE '2020-01-01 00:00:00' UNTIL now WITH TIMEZONE 'Europe/Berlin' LIMIT MAX",
+		"SELECT * FROM Transaction ORDER BY duration DESC LIMIT 10",
+		"SELECT latest(isReady) + 1 FROM K8sPodSample WHERE status = 'Running' and isReady = 0",
+		"SELECT count(*) FROM Transaction SINCE last week EXTRAPOLATE",
+		"SELECT count(*) FROM Transaction WHERE name != 'It''s' AND duration > -1.5",
+		"SELECT rate(count(*), 1 minute) FROM Transaction",
+		"SELECT derivative(memoryUsedBytes, 30 seconds) FROM K8sContainerSample FACET podName",
+		"SELECT latestRate(requests, 1 MINUTE) FROM Metric TIMESERIES 5 minutes",
+		"SELECT apdex(duration, t: 0.5) FROM Transaction",
+		"SELECT apdex(duration, t:0.5) FROM Transaction WHERE aws.ec2:instanceId IS NOT NULL",
+		"SELECT count(*) FROM Log WHERE message RLIKE r'.*error\\d+.*'",
+		"SELECT capture(message, r'user=(?P<user>\\w+)') FROM Log FACET capture(message, R\"id=(\\d+)\")",
+		"SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample WHERE cpuPercent > 90)",
+		"SELECT count(*) FROM Transaction WHERE host NOT IN (FROM SystemSample SELECT uniques(host) LIMIT MAX) FACET host",
+		"SELECT count(*) FROM Transaction TIMESERIES 5 minutes SINCE 1 day ago SLIDE BY 1 minute",
+		"SELECT count(*) FROM Transaction TIMESERIES 1 hour SLIDE BY AUTO",
+	}
+
+	for _, query := range queries {
+		if _, err := nrql.Parse(query); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", query, err)
+		}
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+	}{
+		{query: "count(*) FROM Transaction", column: 1},
+		{query: "SELECT count(*) Transaction", column: 17},
+		{query: "SELECT count(* FROM Transaction", column: 16},
+		{query: "SELECT count(*) FROM Transaction WHERE", column: 39},
+		{query: "SELECT count(*) FROM Transaction SINCE 1 hour ago SINCE 2 hours ago", column: 51},
+		{query: "SELECT count(*) FROM Transaction WHERE name = 'api", column: 47},
+		{query: "SELECT count(*) FROM Transaction TIMESERIES 5", column: 45},
+		{query: "SELECT count(*) FROM Transaction WHERE from = 1", column: 40},
+		{query: "SELECT count(*) FROM Transaction GROUP BY name", column: 34},
+		{query: "SELECT rate(count(*), 1 fortnight) FROM Transaction", column: 25},
+		{query: "SELECT count(*) FROM Log WHERE message RLIKE r'.*", column: 46},
+		{query: "SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample", column: 87},
+		{query: "SELECT count(*) FROM Transaction SLIDE BY 1 minute", column: 34},
+		{query: "SELECT count(*) FROM Transaction TIMESERIES SLIDE BY 1 minute SLIDE BY 2 minutes", column: 63},
+	}
+
+	for _, test := range tests {
+		_, err := nrql.Parse(test.query)
+		syntaxErr, ok := err.(*nrql.SyntaxError)
+		if !ok {
+			t.Errorf("Expected a syntax error for %q, got %v", test.query, err)
+			continue
+		}
+		if syntaxErr.Pos.Column != test.column {
+			t.Errorf("Expected the error for %q at column %d, got %v", test.query, test.column, syntaxErr)
+		}
+	}
+}
+
+func TestParse_Ast(t *testing.T) {
+	query, err := nrql.Parse("FROM Transaction SELECT average(duration) AS 'avg' WHERE appName = 'api' AND duration > 1 FACET name SINCE 30 minutes ago TIMESERIES AUTO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(query.From) != 1 || query.From[0].Name != "Transaction" {
+		t.Errorf("Expected FROM Transaction, got %v", query.From)
+	}
+	if len(query.Select) != 1 || query.Select[0].Alias != "avg" || query.Select[0].Expr.String() != "average(duration)" {
+		t.Errorf("Unexpected SELECT %v", query.Select)
+	}
+	if query.Where.String() != "((appName = 'api') AND (duration > 1))" {
+		t.Errorf("Unexpected WHERE %s", query.Where)
+	}
+	if query.Facet == nil || query.Facet.Items[0].Expr.String() != "name" {
+		t.Errorf("Unexpected FACET %v", query.Facet)
+	}
+	if query.Since == nil || query.Since.Value != 30 || query.Since.Unit != "minute" || !query.Since.Ago {
+		t.Errorf("Unexpected SINCE %v", query.Since)
+	}
+	if query.Timeseries == nil || !query.Timeseries.Auto {
+		t.Errorf("Unexpected TIMESERIES %v", query.Timeseries)
+	}
+}
+
+func TestParse_DurationArgument(t *testing.T) {
+	query, err := nrql.Parse("SELECT rate(count(*), 5 minutes) FROM Transaction")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call, ok := query.Select[0].Expr.(*nrql.FunctionCall)
+	if !ok || len(call.Args) != 2 {
+		t.Fatalf("Expected a function call with two arguments, got %v", query.Select[0].Expr)
+	}
+	duration, ok := call.Args[1].(*nrql.DurationLiteral)
+	if !ok || duration.Value != 5 || duration.Unit != "minute" {
+		t.Errorf("Expected a duration of 5 minutes, got %v", call.Args[1])
+	}
+	if call.String() != "rate(count(*), 5 minute)" {
+		t.Errorf("Unexpected function call %s", call)
+	}
+}
+
+func TestParse_OperatorPrecedence(t *testing.T) {
+	query, err := nrql.Parse("SELECT sum(a) - sum(b) * 2 / count(*) FROM Transaction WHERE a = 1 OR b = 2 AND NOT c IN (1, 2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Select[0].Expr.String() != "(sum(a) - ((sum(b) * 2) / count(*)))" {
+		t.Errorf("Unexpected SELECT %s", query.Select[0].Expr)
+	}
+	if query.Where.String() != "((a = 1) OR ((b = 2) AND NOT c IN (1, 2)))" {
+		t.Errorf("Unexpected WHERE %s", query.Where)
+	}
+}
+
+func TestParse_Positions(t *testing.T) {
+	_, err := nrql.Parse("SELECT count(*)\nFROM Transaction\nWHERE name = ")
+	syntaxErr, ok := err.(*nrql.SyntaxError)
+	if !ok {
+		t.Fatalf("Expected a syntax error, got %v", err)
+	}
+
+	if syntaxErr.Pos.Line != 3 || syntaxErr.Pos.Column != 14 {
+		t.Errorf("Expected the error at 3:14, got %s", syntaxErr.Pos)
+	}
+}
+
+func TestParse_NamedArgument(t *testing.T) {
+	query, err := nrql.Parse("SELECT apdex(duration, t: 0.5) FROM Transaction")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call, ok := query.Select[0].Expr.(*nrql.FunctionCall)
+	if !ok || len(call.Args) != 2 {
+		t.Fatalf("Expected a function call with two arguments, got %v", query.Select[0].Expr)
+	}
+	named, ok := call.Args[1].(*nrql.NamedArgument)
+	if !ok || named.Name != "t" || named.Value.String() != "0.5" {
+		t.Errorf("Expected the named argument t: 0.5, got %v", call.Args[1])
+	}
+}
+
+func TestParse_RawString(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+	}{
+		{query: `SELECT count(*) FROM Log WHERE message RLIKE r'\d+ errors'`, value: `\d+ errors`},
+		{query: `SELECT count(*) FROM Log WHERE message RLIKE R"\w+"`, value: `\w+`},
+		{query: `SELECT count(*) FROM Log WHERE message RLIKE r''`, value: ``},
+	}
+
+	for _, test := range tests {
+		query, err := nrql.Parse(test.query)
+		if err != nil {
+			t.Errorf("Expected %q to be valid, got %v", test.query, err)
+			continue
+		}
+
+		where, ok := query.Where.(*nrql.BinaryExpr)
+		if !ok || where.Operator != "RLIKE" {
+			t.Errorf("Expected RLIKE for %q, got %v", test.query, query.Where)
+			continue
+		}
+		if literal, ok := where.Right.(*nrql.StringLiteral); !ok || literal.Value != test.value {
+			t.Errorf("Expected the raw string %q for %q, got %v", test.value, test.query, where.Right)
+		}
+	}
+}
+
+func TestParse_Subquery(t *testing.T) {
+	query, err := nrql.Parse("SELECT count(*) FROM Transaction WHERE host IN (SELECT uniques(host) FROM SystemSample WHERE cpuPercent > 90) SINCE 1 hour ago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in, ok := query.Where.(*nrql.InExpr)
+	if !ok || len(in.Values) != 1 {
+		t.Fatalf("Expected an IN expression with a single value, got %v", query.Where)
+	}
+	subquery, ok := in.Values[0].(*nrql.Subquery)
+	if !ok || subquery.Query.From[0].Name != "SystemSample" || subquery.Query.Where.String() != "(cpuPercent > 90)" {
+		t.Errorf("Expected a subquery on SystemSample, got %v", in.Values[0])
+	}
+	if query.Since == nil || query.Since.Unit != "hour" {
+		t.Errorf("Expected the SINCE clause of the outer query, got %v", query.Since)
+	}
+}
+
+func TestParse_SlideBy(t *testing.T) {
+	tests := []struct {
+		query string
+		slide string
+	}{
+		{query: "SELECT count(*) FROM Transaction TIMESERIES 5 minutes SLIDE BY 1 minute", slide: "minute"},
+		{query: "SELECT count(*) FROM Transaction TIMESERIES 5 minutes SINCE 1 day ago SLIDE BY 2 minutes", slide: "minute"},
+		{query: "SELECT count(*) FROM Transaction TIMESERIES 1 hour SLIDE BY AUTO"},
+	}
+
+	for _, test := range tests {
+		query, err := nrql.Parse(test.query)
+		if err != nil {
+			t.Errorf("Expected %q to be valid, got %v", test.query, err)
+			continue
+		}
+
+		timeseries := query.Timeseries
+		if !timeseries.Slide {
+			t.Errorf("Expected a sliding window for %q", test.query)
+		}
+		if test.slide == "" && timeseries.SlideBy != nil {
+			t.Errorf("Expected an automatic sliding window for %q, got %v", test.query, timeseries.SlideBy)
+		}
+		if test.slide != "" && (timeseries.SlideBy == nil || timeseries.SlideBy.Unit != test.slide) {
+			t.Errorf("Expected a sliding window in %ss for %q, got %v", test.slide, test.query, timeseries.SlideBy)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package nrql
+
+// widgetRequirements defines what a query must, or must not, contain to be plotted by a visualization
+type widgetRequirements struct {
+	timeseries   bool
+	noTimeseries bool
+	facet        bool
+	compareWith  bool
+	aggregate    bool
+	noAggregate  bool
+	singleValue  bool
+	function     string
+	// apm marks visualizations which plot APM metrics instead of a query
+	apm bool
+}
+
+var visualizations = map[string]widgetRequirements{
+	"application_breakdown": {apm: true},
+	"attribute_sheet":       {},
+	"background_breakdown":  {apm: true},
+	"billboard":             {aggregate: true, noTimeseries: true},
+	"billboard_comparison":  {aggregate: true, noTimeseries: true, compareWith: true},
+	"comparison_line_chart": {aggregate: true, timeseries: true, compareWith: true},
+	"event_table":           {noAggregate: true, noTimeseries: true},
+	"facet_bar_chart":       {aggregate: true, facet: true, noTimeseries: true},
+	"facet_pie_chart":       {aggregate: true, facet: true, noTimeseries: true},
+	"facet_table":           {aggregate: true, facet: true, noTimeseries: true},
+	"faceted_area_chart":    {aggregate: true, facet: true, timeseries: true},
+	"faceted_line_chart":    {aggregate: true, facet: true, timeseries: true},
+	"funnel":                {function: "funnel", noTimeseries: true},
+	"gauge":                 {aggregate: true, singleValue: true, noTimeseries: true},
+	"heatmap":               {function: "histogram", facet: true, noTimeseries: true},
+	"histogram":             {function: "histogram", noTimeseries: true},
+	"json":                  {},
+	"line_chart":            {aggregate: true, timeseries: true},
+	"list":                  {noTimeseries: true},
+	"metric_line_chart":     {apm: true},
+}
+
+// WidgetRules returns the rules for the query of a dashboard widget with the given visualization.
+// Unknown visualizations are only checked for syntax errors
+func WidgetRules(visualization string) []Rule {
+	requirements, ok := visualizations[visualization]
+	if !ok {
+		return nil
+	}
+
+	var rules []Rule
+	if requirements.apm {
+		rules = append(rules, Rule{
+			Name:     "widget-apm-metric",
+			Severity: SeverityWarning,
+			Check: func(query *Query) []Finding {
+				return []Finding{{Pos: query.Pos, Message: visualization + " widgets plot APM metrics and ignore the query"}}
+			},
+		})
+	}
+	if requirements.timeseries {
+		rules = append(rules, Rule{
+			Name:     "widget-timeseries",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if query.Timeseries != nil {
+					return nil
+				}
+				return []Finding{{Pos: query.Pos, Message: visualization + " widgets require a TIMESERIES clause"}}
+			},
+		})
+	}
+	if requirements.noTimeseries {
+		rules = append(rules, Rule{
+			Name:     "widget-no-timeseries",
+			Severity: SeverityWarning,
+			Check: func(query *Query) []Finding {
+				if query.Timeseries == nil {
+					return nil
+				}
+				return []Finding{{Pos: query.Timeseries.Pos, Message: "TIMESERIES has no effect in " + visualization + " widgets"}}
+			},
+		})
+	}
+	if requirements.facet {
+		rules = append(rules, Rule{
+			Name:     "widget-facet",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if query.Facet != nil {
+					return nil
+				}
+				return []Finding{{Pos: query.Pos, Message: visualization + " widgets require a FACET clause"}}
+			},
+		})
+	}
+	if requirements.compareWith {
+		rules = append(rules, Rule{
+			Name:     "widget-compare-with",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if query.CompareWith != nil {
+					return nil
+				}
+				return []Finding{{Pos: query.Pos, Message: visualization + " widgets require a COMPARE WITH clause"}}
+			},
+		})
+	}
+	if requirements.aggregate {
+		rules = append(rules, Rule{
+			Name:     "widget-aggregate",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				var findings []Finding
+				for _, item := range query.Select {
+					if !containsAggregate(item.Expr) {
+						findings = append(findings, Finding{Pos: item.Expr.Position(), Message: visualization + " widgets require an aggregate function"})
+					}
+				}
+				return findings
+			},
+		})
+	}
+	if requirements.noAggregate {
+		rules = append(rules, Rule{
+			Name:     "widget-no-aggregate",
+			Severity: SeverityWarning,
+			Check: func(query *Query) []Finding {
+				var findings []Finding
+				for _, item := range query.Select {
+					if containsAggregate(item.Expr) {
+						findings = append(findings, Finding{Pos: item.Expr.Position(), Message: visualization + " widgets show single events, use a table visualization for aggregated values"})
+					}
+				}
+				return findings
+			},
+		})
+	}
+	if requirements.singleValue {
+		rules = append(rules, Rule{
+			Name:     "widget-single-value",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if len(query.Select) <= 1 {
+					return nil
+				}
+				return []Finding{{Pos: query.Select[1].Expr.Position(), Message: visualization + " widgets show a single value"}}
+			},
+		})
+	}
+	if requirements.function != "" {
+		rules = append(rules, Rule{
+			Name:     "widget-function",
+			Severity: SeverityError,
+			Check: func(query *Query) []Finding {
+				if containsFunction(query, requirements.function) {
+					return nil
+				}
+				return []Finding{{Pos: query.Pos, Message: visualization + " widgets require the " + requirements.function + "() function"}}
+			},
+		})
+	}
+
+	return rules
+}
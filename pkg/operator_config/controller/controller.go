@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/config/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/infrastructure/file"
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/infrastructure/k8s"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+var log = logf.Log.WithName("controller_operator_config")
+
+// secretRefreshInterval defines how often the configuration is reloaded
+// so that rotated secrets are picked up without changing the configuration itself
+const secretRefreshInterval = time.Minute
+
+// Source defines where the operator configuration is read from.
+// When neither field is set, the default configuration is used.
+type Source struct {
+	// Path to a file containing an OperatorConfig manifest
+	File string
+	// Name of the cluster-scoped OperatorConfig resource
+	Name string
+}
+
+// LoadConfig reads the configuration once when the operator starts
+func LoadConfig(reader client.Reader, configSource Source) (*domain.Config, error) {
+	loader := k8s.NewLoader(reader)
+	if configSource.File != "" {
+		instance, err := file.ReadConfig(configSource.File)
+		if err != nil {
+			return nil, err
+		}
+
+		return loader.Load(instance.Spec)
+	}
+
+	if configSource.Name != "" {
+		var instance v1alpha1.OperatorConfig
+		err := reader.Get(context.TODO(), types.NamespacedName{Name: configSource.Name}, &instance)
+		if errors.IsNotFound(err) {
+			log.Info("OperatorConfig not found, using the default configuration", "Name", configSource.Name)
+			config := domain.NewDefaultConfig()
+			return &config, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return loader.Load(instance.Spec)
+	}
+
+	config := domain.NewDefaultConfig()
+	return &config, nil
+}
+
+// Add reloads the configuration in the store whenever its source changes
+func Add(mgr manager.Manager, store *domain.Store, configSource Source) error {
+	loader := k8s.NewLoader(mgr.GetAPIReader())
+	if configSource.File != "" {
+		log.Info("Watching operator configuration file", "Path", configSource.File)
+		watcher := file.NewWatcher(log, configSource.File, secretRefreshInterval, func() {
+			reloadFile(loader, store, configSource.File)
+		})
+		return mgr.Add(watcher)
+	}
+
+	if configSource.Name == "" {
+		return nil
+	}
+
+	log.Info("Registering operator config controller")
+	reconciler := &ReconcileOperatorConfig{
+		k8s:    k8s.NewClient(log, mgr.GetClient()),
+		loader: loader,
+		store:  store,
+		log:    log,
+	}
+
+	c, err := controller.New("operator-config-controller", mgr, controller.Options{Reconciler: reconciler})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &v1alpha1.OperatorConfig{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return e.Meta.GetName() == configSource.Name
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaNew.GetName() == configSource.Name && e.MetaNew.GetGeneration() != e.MetaOld.GetGeneration()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	})
+}
+
+// ReconcileOperatorConfig validates the OperatorConfig and applies it to the store
+type ReconcileOperatorConfig struct {
+	k8s    *k8s.Client
+	loader *k8s.Loader
+	store  *domain.Store
+	log    logr.Logger
+}
+
+// blank assignment to verify that ReconcileOperatorConfig implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileOperatorConfig{}
+
+func (r *ReconcileOperatorConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.log.WithValues("Request.Name", request.Name)
+	reqLogger.Info("Reconciling OperatorConfig")
+
+	instance, err := r.k8s.GetConfig(request.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("OperatorConfig was deleted, keeping the last valid configuration")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	var status v1alpha1.OperatorConfigStatus
+	config, err := r.loader.Load(instance.Spec)
+	if err != nil {
+		reqLogger.Error(err, "Invalid operator configuration, keeping the last valid configuration")
+		status = v1alpha1.NewConfigInvalid(instance.Generation, err)
+	} else {
+		apply(r.store, *config)
+		status = v1alpha1.NewConfigApplied(instance.Generation)
+	}
+
+	// The configuration is reloaded periodically to pick up rotated secrets,
+	// so the status is only written when the outcome changed
+	if instance.Status != status {
+		instance.Status = status
+		err = r.k8s.UpdateConfigStatus(instance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: secretRefreshInterval}, nil
+}
+
+func reloadFile(loader *k8s.Loader, store *domain.Store, path string) {
+	instance, err := file.ReadConfig(path)
+	if err != nil {
+		log.Error(err, "Error reading configuration file, keeping the last valid configuration")
+		return
+	}
+
+	config, err := loader.Load(instance.Spec)
+	if err != nil {
+		log.Error(err, "Invalid operator configuration, keeping the last valid configuration")
+		return
+	}
+
+	apply(store, *config)
+}
+
+func apply(store *domain.Store, config domain.Config) {
+	current := store.Get()
+	if current == config {
+		return
+	}
+
+	if current.MetricsPort != config.MetricsPort || current.OperatorMetricsPort != config.OperatorMetricsPort {
+		log.Info("Metrics ports changed, the new ports will be used after the operator is restarted")
+	}
+
+	store.Set(config)
+	log.Info("Applied new operator configuration")
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config is the validated operator configuration, with all secret references resolved
+type Config struct {
+	AdminKey         string
+	RestApiUrl       string
+	InfraApiUrl      string
+	SyntheticsApiUrl string
+	NerdGraphUrl     string
+	AccountId        int64
+
+	RequestTimeout        time.Duration
+	ErrorRequeueInterval  time.Duration
+	ResyncInterval        time.Duration
+	EntityResolveInterval time.Duration
+	SaveFailureMode       string
+
+	DefaultSlackWebhookUrl string
+	DefaultOpsgenieApiKey  string
+
+	MetricsPort         int32
+	OperatorMetricsPort int32
+}
+
+// NewDefaultConfig returns the configuration used when no configuration file or OperatorConfig is given.
+// Credentials are read from the environment variables set in the operator deployment.
+func NewDefaultConfig() Config {
+	return Config{
+		AdminKey:               os.Getenv("NEWRELIC_ADMIN_KEY"),
+		RestApiUrl:             "https://api.newrelic.com/v2",
+		InfraApiUrl:            "https://infra-api.newrelic.com/v2",
+		SyntheticsApiUrl:       "https://synthetics.newrelic.com/synthetics/api/v3",
+		NerdGraphUrl:           "https://api.newrelic.com",
+		AccountId:              accountIdFromEnv(),
+		RequestTimeout:         3 * time.Second,
+		ErrorRequeueInterval:   5 * time.Second,
+		ResyncInterval:         0,
+		EntityResolveInterval:  5 * time.Minute,
+		SaveFailureMode:        internal.SaveFailureModeRollback,
+		DefaultSlackWebhookUrl: os.Getenv("DEFAULT_SLACK_WEBHOOK_URL"),
+		DefaultOpsgenieApiKey:  os.Getenv("DEFAULT_OPS_GENIE_API_KEY"),
+		MetricsPort:            8383,
+		OperatorMetricsPort:    8686,
+	}
+}
+
+// accountIdFromEnv reads the account id from the environment, and returns zero when it is not set or invalid
+func accountIdFromEnv() int64 {
+	accountId, err := strconv.ParseInt(os.Getenv("NEWRELIC_ACCOUNT_ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return accountId
+}
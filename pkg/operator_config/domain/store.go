@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"github.com/personio/newrelic-alert-manager/internal"
+	"sync"
+	"time"
+)
+
+// Store holds the current operator configuration and serves it to the controllers.
+// It is safe for concurrent use, so the configuration can be replaced while the controllers are running.
+type Store struct {
+	mutex  sync.RWMutex
+	config Config
+}
+
+func NewStore(config Config) *Store {
+	return &Store{
+		config: config,
+	}
+}
+
+func (store *Store) Get() Config {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.config
+}
+
+func (store *Store) Set(config Config) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.config = config
+}
+
+func (store *Store) RestApi() internal.ClientSettings {
+	config := store.Get()
+	return internal.ClientSettings{
+		Url:      config.RestApiUrl,
+		AdminKey: config.AdminKey,
+		Timeout:  config.RequestTimeout,
+	}
+}
+
+func (store *Store) InfraApi() internal.ClientSettings {
+	config := store.Get()
+	return internal.ClientSettings{
+		Url:      config.InfraApiUrl,
+		AdminKey: config.AdminKey,
+		Timeout:  config.RequestTimeout,
+	}
+}
+
+func (store *Store) SyntheticsApi() internal.ClientSettings {
+	config := store.Get()
+	return internal.ClientSettings{
+		Url:      config.SyntheticsApiUrl,
+		AdminKey: config.AdminKey,
+		Timeout:  config.RequestTimeout,
+	}
+}
+
+// NerdGraphApi sends the admin key in the API-Key header, which NerdGraph expects instead of X-Api-Key
+func (store *Store) NerdGraphApi() internal.ClientSettings {
+	config := store.Get()
+	return internal.ClientSettings{
+		Url:          config.NerdGraphUrl,
+		AdminKey:     config.AdminKey,
+		Timeout:      config.RequestTimeout,
+		ApiKeyHeader: "API-Key",
+	}
+}
+
+func (store *Store) AccountId() int64 {
+	return store.Get().AccountId
+}
+
+func (store *Store) ErrorRequeueInterval() time.Duration {
+	return store.Get().ErrorRequeueInterval
+}
+
+func (store *Store) ResyncInterval() time.Duration {
+	return store.Get().ResyncInterval
+}
+
+func (store *Store) EntityResolveInterval() time.Duration {
+	return store.Get().EntityResolveInterval
+}
+
+func (store *Store) SaveFailureMode() string {
+	return store.Get().SaveFailureMode
+}
+
+func (store *Store) DefaultSlackWebhookUrl() string {
+	return store.Get().DefaultSlackWebhookUrl
+}
+
+func (store *Store) DefaultOpsgenieApiKey() string {
+	return store.Get().DefaultOpsgenieApiKey
+}
+
+// blank assignment to verify that Store implements internal.Settings
+var _ internal.Settings = &Store{}
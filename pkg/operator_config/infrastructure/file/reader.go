@@ -0,0 +1,30 @@
+package file
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/config/v1alpha1"
+	"io/ioutil"
+	"sigs.k8s.io/yaml"
+)
+
+// ReadConfig reads an OperatorConfig manifest from a YAML file.
+// Unknown fields are rejected so that typos do not silently fall back to default values.
+func ReadConfig(path string) (*v1alpha1.OperatorConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config v1alpha1.OperatorConfig
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse configuration file %s: %s", path, err.Error())
+	}
+
+	expectedApiVersion := v1alpha1.SchemeGroupVersion.String()
+	if config.APIVersion != expectedApiVersion || config.Kind != "OperatorConfig" {
+		return nil, fmt.Errorf("configuration file %s must contain an OperatorConfig of version %s", path, expectedApiVersion)
+	}
+
+	return &config, nil
+}
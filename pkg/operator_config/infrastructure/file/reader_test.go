@@ -0,0 +1,78 @@
+package file_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/infrastructure/file"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "operator-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	err = ioutil.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadConfig_ValidFile(t *testing.T) {
+	path := writeConfig(t, `
+apiVersion: config.newrelic.io/v1alpha1
+kind: OperatorConfig
+metadata:
+  name: newrelic-alert-manager
+spec:
+  requestTimeout: 10s
+  endpoints:
+    restApiUrl: https://api.eu.newrelic.com/v2
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	config, err := file.ReadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Spec.RequestTimeout.Duration.String() != "10s" {
+		t.Error("RequestTimeout should be equal to 10s")
+	}
+	if config.Spec.Endpoints.RestApiUrl != "https://api.eu.newrelic.com/v2" {
+		t.Error("RestApiUrl should be equal to https://api.eu.newrelic.com/v2")
+	}
+}
+
+func TestReadConfig_UnknownField(t *testing.T) {
+	path := writeConfig(t, `
+apiVersion: config.newrelic.io/v1alpha1
+kind: OperatorConfig
+spec:
+  requestTimout: 10s
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	_, err := file.ReadConfig(path)
+	if err == nil {
+		t.Error("Unknown fields should be rejected")
+	}
+}
+
+func TestReadConfig_WrongKind(t *testing.T) {
+	path := writeConfig(t, `
+apiVersion: alerts.newrelic.io/v1alpha1
+kind: AlertPolicy
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	_, err := file.ReadConfig(path)
+	if err == nil {
+		t.Error("Manifests of other kinds should be rejected")
+	}
+}
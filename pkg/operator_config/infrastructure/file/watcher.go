@@ -0,0 +1,59 @@
+package file
+
+import (
+	"github.com/go-logr/logr"
+	"gopkg.in/fsnotify.v1"
+	"path/filepath"
+	"time"
+)
+
+// Watcher calls onChange whenever the watched file may have changed, and once per resync period.
+// The parent directory is watched instead of the file itself, since files mounted
+// from a ConfigMap are replaced by swapping a symlink rather than being written to.
+type Watcher struct {
+	log      logr.Logger
+	path     string
+	resync   time.Duration
+	onChange func()
+}
+
+func NewWatcher(log logr.Logger, path string, resync time.Duration, onChange func()) *Watcher {
+	return &Watcher{
+		log:      log,
+		path:     path,
+		resync:   resync,
+		onChange: onChange,
+	}
+}
+
+// Start watches the file until the stop channel is closed
+func (w *Watcher) Start(stop <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	err = watcher.Add(filepath.Dir(w.path))
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.resync)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			w.onChange()
+		case event := <-watcher.Events:
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				w.onChange()
+			}
+		case err := <-watcher.Errors:
+			w.log.Error(err, "Error watching configuration file", "Path", w.path)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/config/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Client struct {
+	logr   logr.Logger
+	client client_go.Client
+}
+
+func NewClient(logr logr.Logger, client client_go.Client) *Client {
+	return &Client{
+		logr:   logr,
+		client: client,
+	}
+}
+
+func (c *Client) GetConfig(name string) (*v1alpha1.OperatorConfig, error) {
+	var instance v1alpha1.OperatorConfig
+	err := c.client.Get(context.TODO(), types.NamespacedName{Name: name}, &instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (c *Client) UpdateConfigStatus(config *v1alpha1.OperatorConfig) error {
+	return c.client.Status().Update(context.TODO(), config)
+}
@@ -0,0 +1,160 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/config/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net/url"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+// Loader turns an OperatorConfigSpec into a Config.
+// Empty fields keep their default values and secret references are resolved through the API server.
+type Loader struct {
+	reader client_go.Reader
+}
+
+func NewLoader(reader client_go.Reader) *Loader {
+	return &Loader{
+		reader: reader,
+	}
+}
+
+// Load validates the spec and returns the resulting configuration.
+// All validation errors are reported together, and no configuration is returned when any of them fails.
+func (loader Loader) Load(spec v1alpha1.OperatorConfigSpec) (*domain.Config, error) {
+	config := domain.NewDefaultConfig()
+	specPath := field.NewPath("spec")
+
+	var errs field.ErrorList
+	errs = append(errs, loader.loadSecret(&config.AdminKey, spec.AdminKeySecretRef, specPath.Child("adminKeySecretRef"))...)
+	if config.AdminKey == "" {
+		errs = append(errs, field.Required(specPath.Child("adminKeySecretRef"), "an admin key is required"))
+	}
+
+	if spec.AccountId < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("accountId"), spec.AccountId, "the account id must be positive"))
+	} else if spec.AccountId > 0 {
+		config.AccountId = spec.AccountId
+	}
+
+	endpointsPath := specPath.Child("endpoints")
+	errs = append(errs, loadUrl(&config.RestApiUrl, spec.Endpoints.RestApiUrl, endpointsPath.Child("restApiUrl"))...)
+	errs = append(errs, loadUrl(&config.InfraApiUrl, spec.Endpoints.InfraApiUrl, endpointsPath.Child("infraApiUrl"))...)
+	errs = append(errs, loadUrl(&config.SyntheticsApiUrl, spec.Endpoints.SyntheticsApiUrl, endpointsPath.Child("syntheticsApiUrl"))...)
+	errs = append(errs, loadUrl(&config.NerdGraphUrl, spec.Endpoints.NerdGraphUrl, endpointsPath.Child("nerdGraphUrl"))...)
+
+	errs = append(errs, loadDuration(&config.RequestTimeout, spec.RequestTimeout, false, specPath.Child("requestTimeout"))...)
+	errs = append(errs, loadDuration(&config.ErrorRequeueInterval, spec.ErrorRequeueInterval, false, specPath.Child("errorRequeueInterval"))...)
+	errs = append(errs, loadDuration(&config.ResyncInterval, spec.ResyncInterval, true, specPath.Child("resyncInterval"))...)
+	errs = append(errs, loadDuration(&config.EntityResolveInterval, spec.EntityResolveInterval, false, specPath.Child("entityResolveInterval"))...)
+
+	switch spec.SaveFailureMode {
+	case "":
+	case internal.SaveFailureModeRollback, internal.SaveFailureModeCheckpoint:
+		config.SaveFailureMode = spec.SaveFailureMode
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("saveFailureMode"), spec.SaveFailureMode, []string{internal.SaveFailureModeRollback, internal.SaveFailureModeCheckpoint}))
+	}
+
+	defaultsPath := specPath.Child("defaults")
+	errs = append(errs, loader.loadSecret(&config.DefaultSlackWebhookUrl, spec.Defaults.SlackWebhookUrlSecretRef, defaultsPath.Child("slackWebhookUrlSecretRef"))...)
+	errs = append(errs, loader.loadSecret(&config.DefaultOpsgenieApiKey, spec.Defaults.OpsgenieApiKeySecretRef, defaultsPath.Child("opsgenieApiKeySecretRef"))...)
+
+	metricsPath := specPath.Child("metrics")
+	errs = append(errs, loadPort(&config.MetricsPort, spec.Metrics.Port, metricsPath.Child("port"))...)
+	errs = append(errs, loadPort(&config.OperatorMetricsPort, spec.Metrics.OperatorPort, metricsPath.Child("operatorPort"))...)
+	if config.MetricsPort == config.OperatorMetricsPort {
+		errs = append(errs, field.Duplicate(metricsPath.Child("operatorPort"), config.OperatorMetricsPort))
+	}
+
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	return &config, nil
+}
+
+func (loader Loader) loadSecret(target *string, ref *v1alpha1.SecretKeyReference, path *field.Path) field.ErrorList {
+	if ref == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	if ref.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), ""))
+	}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+	if ref.Key == "" {
+		errs = append(errs, field.Required(path.Child("key"), ""))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	var secret corev1.Secret
+	err := loader.reader.Get(context.TODO(), types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &secret)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, fmt.Sprintf("%s/%s", ref.Namespace, ref.Name), err.Error())}
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return field.ErrorList{field.NotFound(path.Child("key"), ref.Key)}
+	}
+
+	*target = string(value)
+	return nil
+}
+
+func loadUrl(target *string, value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return field.ErrorList{field.Invalid(path, value, "the URL scheme must be http or https")}
+	}
+
+	*target = value
+	return nil
+}
+
+func loadDuration(target *time.Duration, value *metav1.Duration, allowZero bool, path *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+
+	if value.Duration < 0 || (value.Duration == 0 && !allowZero) {
+		return field.ErrorList{field.Invalid(path, value.Duration.String(), "the duration must be positive")}
+	}
+
+	*target = value.Duration
+	return nil
+}
+
+func loadPort(target *int32, value int32, path *field.Path) field.ErrorList {
+	if value == 0 {
+		return nil
+	}
+
+	if value < 1 || value > 65535 {
+		return field.ErrorList{field.Invalid(path, value, "the port must be between 1 and 65535")}
+	}
+
+	*target = value
+	return nil
+}
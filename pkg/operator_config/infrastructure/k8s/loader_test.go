@@ -0,0 +1,156 @@
+package k8s_test
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/config/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/operator_config/infrastructure/k8s"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"os"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"testing"
+	"time"
+)
+
+type secretReader struct {
+	secrets map[client_go.ObjectKey]map[string][]byte
+}
+
+func (reader secretReader) Get(ctx context.Context, key client_go.ObjectKey, obj runtime.Object) error {
+	data, ok := reader.secrets[key]
+	if !ok {
+		return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+
+	obj.(*corev1.Secret).Data = data
+	return nil
+}
+
+func (reader secretReader) List(ctx context.Context, list runtime.Object, opts ...client_go.ListOption) error {
+	return nil
+}
+
+func newSecretReader() secretReader {
+	return secretReader{
+		secrets: map[client_go.ObjectKey]map[string][]byte{
+			{Namespace: "newrelic-alert-manager", Name: "newrelic-alert-manager"}: {
+				"adminKey":               []byte("secret-admin-key"),
+				"defaultSlackWebhookUrl": []byte("https://hooks.slack.com/secret"),
+			},
+		},
+	}
+}
+
+func TestLoader_Load_EmptySpecUsesDefaults(t *testing.T) {
+	os.Setenv("NEWRELIC_ADMIN_KEY", "env-admin-key")
+	defer os.Unsetenv("NEWRELIC_ADMIN_KEY")
+
+	config, err := k8s.NewLoader(newSecretReader()).Load(v1alpha1.OperatorConfigSpec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.AdminKey != "env-admin-key" {
+		t.Error("AdminKey should be read from the environment")
+	}
+	if config.RestApiUrl != "https://api.newrelic.com/v2" {
+		t.Error("RestApiUrl should use the default value")
+	}
+	if config.RequestTimeout != 3*time.Second {
+		t.Error("RequestTimeout should be equal to 3s")
+	}
+	if config.ErrorRequeueInterval != 5*time.Second {
+		t.Error("ErrorRequeueInterval should be equal to 5s")
+	}
+	if config.NerdGraphUrl != "https://api.newrelic.com" {
+		t.Error("NerdGraphUrl should use the default value")
+	}
+	if config.SaveFailureMode != "Rollback" {
+		t.Error("SaveFailureMode should be equal to Rollback")
+	}
+}
+
+func TestLoader_Load_ResolvesSecretsAndOverrides(t *testing.T) {
+	spec := v1alpha1.OperatorConfigSpec{
+		AdminKeySecretRef: &v1alpha1.SecretKeyReference{
+			Namespace: "newrelic-alert-manager",
+			Name:      "newrelic-alert-manager",
+			Key:       "adminKey",
+		},
+		AccountId: 1234567,
+		Endpoints: v1alpha1.Endpoints{
+			RestApiUrl:   "https://api.eu.newrelic.com/v2",
+			NerdGraphUrl: "https://api.eu.newrelic.com",
+		},
+		ResyncInterval:  &metav1.Duration{Duration: 10 * time.Minute},
+		SaveFailureMode: "Checkpoint",
+		Defaults: v1alpha1.Defaults{
+			SlackWebhookUrlSecretRef: &v1alpha1.SecretKeyReference{
+				Namespace: "newrelic-alert-manager",
+				Name:      "newrelic-alert-manager",
+				Key:       "defaultSlackWebhookUrl",
+			},
+		},
+	}
+
+	config, err := k8s.NewLoader(newSecretReader()).Load(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.AdminKey != "secret-admin-key" {
+		t.Error("AdminKey should be read from the secret")
+	}
+	if config.DefaultSlackWebhookUrl != "https://hooks.slack.com/secret" {
+		t.Error("DefaultSlackWebhookUrl should be read from the secret")
+	}
+	if config.RestApiUrl != "https://api.eu.newrelic.com/v2" {
+		t.Error("RestApiUrl should be overridden")
+	}
+	if config.ResyncInterval != 10*time.Minute {
+		t.Error("ResyncInterval should be equal to 10m")
+	}
+	if config.AccountId != 1234567 {
+		t.Error("AccountId should be overridden")
+	}
+	if config.NerdGraphUrl != "https://api.eu.newrelic.com" {
+		t.Error("NerdGraphUrl should be overridden")
+	}
+	if config.SaveFailureMode != "Checkpoint" {
+		t.Error("SaveFailureMode should be overridden")
+	}
+}
+
+func TestLoader_Load_ReportsAllValidationErrors(t *testing.T) {
+	spec := v1alpha1.OperatorConfigSpec{
+		AdminKeySecretRef: &v1alpha1.SecretKeyReference{
+			Namespace: "newrelic-alert-manager",
+			Name:      "missing-secret",
+			Key:       "adminKey",
+		},
+		Endpoints: v1alpha1.Endpoints{
+			InfraApiUrl: "infra-api.newrelic.com",
+		},
+		RequestTimeout:  &metav1.Duration{Duration: -time.Second},
+		SaveFailureMode: "Stop",
+		Metrics: v1alpha1.Metrics{
+			Port:         8686,
+			OperatorPort: 8686,
+		},
+	}
+
+	_, err := k8s.NewLoader(newSecretReader()).Load(spec)
+	if err == nil {
+		t.Fatal("Load should return an error")
+	}
+
+	for _, field := range []string{"spec.adminKeySecretRef", "spec.endpoints.infraApiUrl", "spec.requestTimeout", "spec.saveFailureMode", "spec.metrics.operatorPort"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Error should mention %s: %s", field, err.Error())
+		}
+	}
+}
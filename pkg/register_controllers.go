@@ -1,55 +1,80 @@
 package pkg
 
 import (
+	"github.com/personio/newrelic-alert-manager/internal"
 	alertpolicycontroller "github.com/personio/newrelic-alert-manager/pkg/alert_policies/controller"
 	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
 	dashboardcontroller "github.com/personio/newrelic-alert-manager/pkg/dashboards/controller"
+	mutingrulecontroller "github.com/personio/newrelic-alert-manager/pkg/muting_rules/controller"
+	rolloutcontroller "github.com/personio/newrelic-alert-manager/pkg/rollout_muting/controller"
 	channelcontroller "github.com/personio/newrelic-alert-manager/pkg/notification_channels/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-type RegisterControllerFunc func(manager manager.Manager) error
+type RegisterControllerFunc func(manager manager.Manager, options internal.ControllerOptions) error
 
 // RegisterControllers adds all Controllers to the Manager
-func RegisterControllers(m manager.Manager) error {
+func RegisterControllers(m manager.Manager, options internal.ControllerOptions) error {
 	registerControllerFuncs := []RegisterControllerFunc{
 		registerEmailController(),
 		registerSlackController(),
 		registerOpsgenieController(),
+		registerWebhookController(),
+		registerPagerDutyController(),
 		alertpolicycontroller.Add,
 		dashboardcontroller.Add,
+		mutingrulecontroller.Add,
+		rolloutcontroller.Add,
 	}
 
 	for _, f := range registerControllerFuncs {
-		if err := f(m); err != nil {
+		if err := f(m, options); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 func registerOpsgenieController() RegisterControllerFunc {
-	add := func(mgr manager.Manager) error {
+	add := func(mgr manager.Manager, options internal.ControllerOptions) error {
 		channelType := &v1alpha1.OpsgenieNotificationChannel{}
 		factory := v1alpha1.NewOpsgenieNotificationChannelFactory()
-		return channelcontroller.Add(mgr, "ops-genie-notification-channel-controller", channelType, factory)
+		return channelcontroller.Add(mgr, options, "ops-genie-notification-channel-controller", channelType, factory)
+	}
+	return add
+}
+
+func registerWebhookController() RegisterControllerFunc {
+	add := func(mgr manager.Manager, options internal.ControllerOptions) error {
+		channelType := &v1alpha1.WebhookNotificationChannel{}
+		factory := v1alpha1.NewWebhookNotificationChannelFactory()
+		return channelcontroller.Add(mgr, options, "webhook-notification-channel-controller", channelType, factory)
+	}
+	return add
+}
+
+func registerPagerDutyController() RegisterControllerFunc {
+	add := func(mgr manager.Manager, options internal.ControllerOptions) error {
+		channelType := &v1alpha1.PagerDutyNotificationChannel{}
+		factory := v1alpha1.NewPagerDutyNotificationChannelFactory()
+		return channelcontroller.Add(mgr, options, "pager-duty-notification-channel-controller", channelType, factory)
 	}
 	return add
 }
 
 func registerSlackController() RegisterControllerFunc {
-	add := func(mgr manager.Manager) error {
+	add := func(mgr manager.Manager, options internal.ControllerOptions) error {
 		channelType := &v1alpha1.SlackNotificationChannel{}
 		factory := v1alpha1.NewSlackNotificationChannelFactory()
-		return channelcontroller.Add(mgr, "slack-notification-channel-controller", channelType, factory)
+		return channelcontroller.Add(mgr, options, "slack-notification-channel-controller", channelType, factory)
 	}
 	return add
 }
 
 func registerEmailController() RegisterControllerFunc {
-	add := func(mgr manager.Manager) error {
+	add := func(mgr manager.Manager, options internal.ControllerOptions) error {
 		channelType := &v1alpha1.EmailNotificationChannel{}
 		factory := v1alpha1.NewEmailNotificationChannelFactory()
-		return channelcontroller.Add(mgr, "user-notification-channel-controller", channelType, factory)
+		return channelcontroller.Add(mgr, options, "user-notification-channel-controller", channelType, factory)
 	}
 	return add
 }
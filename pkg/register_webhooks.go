@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	alerts "github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	dashboards "github.com/personio/newrelic-alert-manager/pkg/apis/dashboards/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/defaults"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// RegisterWebhooks adds all admission webhooks and the conversion webhook to the Manager's webhook server.
+// The paths must match the webhook configurations in deploy/webhooks and the CRDs in deploy/crds
+func RegisterWebhooks(m manager.Manager) {
+	server := m.GetWebhookServer()
+	namespaceDefaults := defaults.NewNamespaceDefaults(m.GetAPIReader())
+	server.Register("/mutate-alerts-newrelic-io-v1alpha1-alertpolicy", &admission.Webhook{Handler: defaults.NewPolicyDefaulter(namespaceDefaults)})
+	server.Register("/mutate-dashboards-newrelic-io-v1alpha1-dashboard", &admission.Webhook{Handler: defaults.NewDashboardDefaulter(namespaceDefaults)})
+	server.Register("/validate-alerts-newrelic-io-v1alpha1-alertpolicy", admission.ValidatingWebhookFor(&alerts.AlertPolicy{}))
+	server.Register("/validate-alerts-newrelic-io-v1alpha1-nrqlalertcondition", admission.ValidatingWebhookFor(&alerts.NrqlAlertCondition{}))
+	server.Register("/validate-alerts-newrelic-io-v1alpha1-apmalertcondition", admission.ValidatingWebhookFor(&alerts.ApmAlertCondition{}))
+	server.Register("/validate-alerts-newrelic-io-v1alpha1-mutingrule", admission.ValidatingWebhookFor(&alerts.MutingRule{}))
+	server.Register("/validate-dashboards-newrelic-io-v1alpha1-dashboard", admission.ValidatingWebhookFor(&dashboards.Dashboard{}))
+	server.Register("/convert", &conversion.Webhook{})
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/internal"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/infrastructure/k8s"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+var log = logf.Log.WithName("controller_rollout_muting")
+
+// workloadKind holds what the reconciler needs to know about one kind of workload
+type workloadKind struct {
+	name           string
+	controllerName string
+	newObject      func() runtime.Object
+	isProgressing  func(workload runtime.Object) bool
+}
+
+var workloadKinds = []workloadKind{
+	{
+		name:           "Deployment",
+		controllerName: "newrelic-deployment-rollout-controller",
+		newObject:      func() runtime.Object { return &appsv1.Deployment{} },
+		isProgressing: func(workload runtime.Object) bool {
+			return domain.IsDeploymentProgressing(workload.(*appsv1.Deployment))
+		},
+	},
+	{
+		name:           "StatefulSet",
+		controllerName: "newrelic-statefulset-rollout-controller",
+		newObject:      func() runtime.Object { return &appsv1.StatefulSet{} },
+		isProgressing: func(workload runtime.Object) bool {
+			return domain.IsStatefulSetProgressing(workload.(*appsv1.StatefulSet))
+		},
+	},
+}
+
+// ReconcileRollout mutes the alerts of annotated workloads of one kind while they are rolled out
+type ReconcileRollout struct {
+	k8s     *k8s.Client
+	scheme  *runtime.Scheme
+	kind    workloadKind
+	now     func() time.Time
+	log     logr.Logger
+	options internal.ControllerOptions
+}
+
+// Add registers a controller for Deployments and one for StatefulSets when rollout muting is enabled
+func Add(mgr manager.Manager, options internal.ControllerOptions) error {
+	if !options.RolloutMuting {
+		return nil
+	}
+
+	log.Info("Registering newrelic rollout muting controllers")
+	k8sClient := k8s.NewClient(log, mgr.GetClient(), mgr.GetEventRecorderFor("newrelic-rollout-muting"))
+	for _, kind := range workloadKinds {
+		reconciler := &ReconcileRollout{
+			k8s:     k8sClient,
+			scheme:  mgr.GetScheme(),
+			kind:    kind,
+			now:     time.Now,
+			log:     log.WithValues("Kind", kind.name),
+			options: options,
+		}
+
+		c, err := controller.New(kind.controllerName, mgr, options.ForController(kind.controllerName, reconciler))
+		if err != nil {
+			return err
+		}
+
+		// Watch for changes to the workloads, including their status, while they opt in to rollout muting
+		err = c.Watch(&source.Kind{Type: kind.newObject()}, &handler.EnqueueRequestForObject{}, newAnnotatedPredicate())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newAnnotatedPredicate skips the events of workloads which do not opt in to rollout muting.
+// Updates are passed on when either version has the annotations, so that removing them unmutes the workload
+func newAnnotatedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isAnnotated(e.Meta)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isAnnotated(e.MetaOld) || isAnnotated(e.MetaNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isAnnotated(e.Meta)
+		},
+	}
+}
+
+func isAnnotated(workload metav1.Object) bool {
+	annotations := workload.GetAnnotations()
+	_, hasPolicies := annotations[domain.PoliciesAnnotation]
+	_, hasApplication := annotations[domain.ApplicationAnnotation]
+	return hasPolicies || hasApplication
+}
+
+// blank assignment to verify that ReconcileRollout implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileRollout{}
+
+// Reconcile creates a muting rule when a rollout starts, disables it when the rollout takes longer than the maximum window,
+// and deletes it once the rollout completed. Muting rules are owned by their workload, which removes them with the workload
+func (r *ReconcileRollout) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	workload := r.kind.newObject()
+	err := r.k8s.GetWorkload(request.NamespacedName, workload)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return internal.NewReconcileResult(nil)
+		}
+
+		reqLogger.Error(err, "Error talking to API server. Re-queueing request")
+		return r.options.NewReconcileResult(err)
+	}
+
+	workloadMeta, err := meta.Accessor(workload)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ruleName := types.NamespacedName{Namespace: request.Namespace, Name: RuleName(r.kind.name, request.Name)}
+	rule, err := r.k8s.GetMutingRule(ruleName)
+	if err != nil {
+		reqLogger.Error(err, "Error talking to API server. Re-queueing request")
+		return r.options.NewReconcileResult(err)
+	}
+
+	settings, err := domain.NewMuteSettings(workloadMeta.GetAnnotations())
+	if err != nil {
+		r.k8s.RecordEvent(workload, corev1.EventTypeWarning, "RolloutMuteFailed", err.Error())
+		return r.options.NewReconcileResult(internal.NewClientError(err.Error()))
+	}
+
+	isManaged := rule != nil && rule.Labels[rolloutLabel] == "true"
+	if settings == nil {
+		if !isManaged {
+			return internal.NewReconcileResult(nil)
+		}
+		return r.unmute(workload, rule, "Alerts are no longer muted since the rollout muting annotations were removed")
+	}
+
+	if rule != nil && !isManaged {
+		err = internal.NewClientError(fmt.Sprintf("MutingRule %s already exists and is not managed by the rollout muting controller", ruleName))
+		r.k8s.RecordEvent(workload, corev1.EventTypeWarning, "RolloutMuteFailed", err.Error())
+		return r.options.NewReconcileResult(err)
+	}
+
+	now := r.now()
+	window := NewMuteWindow(rule)
+	progressing := r.kind.isProgressing(workload)
+	switch domain.NextTransition(progressing, workloadMeta.GetGeneration(), window, now) {
+	case domain.TransitionMute:
+		return r.mute(workload, workloadMeta, rule, *settings, now)
+	case domain.TransitionExpire:
+		return r.expire(workload, rule, *settings)
+	case domain.TransitionUnmute:
+		return r.unmute(workload, rule, "Alerts are no longer muted since the rollout completed")
+	}
+
+	if window == nil || window.Expired {
+		return internal.NewReconcileResult(nil)
+	}
+
+	// Keep the conditions of an active rule in line with the annotations, and check again when its window ends
+	condition := NewRuleCondition(*settings)
+	if !reflect.DeepEqual(rule.Spec.Condition, condition) {
+		rule.Spec.Condition = condition
+		if err := r.k8s.UpdateMutingRule(rule); err != nil {
+			return r.options.NewReconcileResult(err)
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: window.End.Sub(now)}, nil
+}
+
+func (r *ReconcileRollout) mute(workload runtime.Object, workloadMeta metav1.Object, rule *v1alpha1.MutingRule, settings domain.MuteSettings, now time.Time) (reconcile.Result, error) {
+	desired := NewMutingRule(r.kind.name, workloadMeta, settings, now)
+	err := controllerutil.SetControllerReference(workloadMeta, desired, r.scheme)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if rule == nil {
+		err = r.k8s.CreateMutingRule(desired)
+	} else {
+		rule.Labels = desired.Labels
+		rule.Annotations = desired.Annotations
+		rule.OwnerReferences = desired.OwnerReferences
+		rule.Spec = desired.Spec
+		err = r.k8s.UpdateMutingRule(rule)
+	}
+	if err != nil {
+		return r.options.NewReconcileResult(err)
+	}
+
+	r.k8s.RecordEvent(workload, corev1.EventTypeNormal, string(domain.TransitionMute),
+		fmt.Sprintf("Muting alerts during the rollout for at most %s with MutingRule %s", settings.MaxWindow, desired.Name))
+	return reconcile.Result{RequeueAfter: settings.MaxWindow}, nil
+}
+
+func (r *ReconcileRollout) expire(workload runtime.Object, rule *v1alpha1.MutingRule, settings domain.MuteSettings) (reconcile.Result, error) {
+	enabled := false
+	rule.Spec.Enabled = &enabled
+	err := r.k8s.UpdateMutingRule(rule)
+	if err != nil {
+		return r.options.NewReconcileResult(err)
+	}
+
+	r.k8s.RecordEvent(workload, corev1.EventTypeWarning, string(domain.TransitionExpire),
+		fmt.Sprintf("Alerts are no longer muted since the rollout did not complete within %s", settings.MaxWindow))
+	return internal.NewReconcileResult(nil)
+}
+
+func (r *ReconcileRollout) unmute(workload runtime.Object, rule *v1alpha1.MutingRule, message string) (reconcile.Result, error) {
+	err := r.k8s.DeleteMutingRule(rule)
+	if err != nil {
+		return r.options.NewReconcileResult(err)
+	}
+
+	r.k8s.RecordEvent(workload, corev1.EventTypeNormal, string(domain.TransitionUnmute), message)
+	return internal.NewReconcileResult(nil)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// generationAnnotation records on a muting rule the generation of the workload whose rollout it mutes
+	generationAnnotation = "alerts.newrelic.io/rollout-generation"
+	// rolloutLabel marks the muting rules which are managed by the rollout muting controller
+	rolloutLabel = "alerts.newrelic.io/rollout"
+	// targetNameAttribute is the attribute of violations which holds the name of the New Relic application
+	targetNameAttribute = "targetName"
+	// scheduleTimeZone is the time zone of the schedules of rollout muting rules
+	scheduleTimeZone = "UTC"
+)
+
+// RuleName returns the name of the muting rule of a workload, which is created in the namespace of the workload
+func RuleName(kind string, workloadName string) string {
+	return fmt.Sprintf("%s-%s-rollout", strings.ToLower(kind), workloadName)
+}
+
+// NewMutingRule returns a rule which mutes the violations of the workload from now until the maximum window ends.
+// New Relic stops muting the violations at the end of the window, even when the operator is not running
+func NewMutingRule(kind string, workload metav1.Object, settings domain.MuteSettings, now time.Time) *v1alpha1.MutingRule {
+	start := now.UTC()
+	enabled := true
+	return &v1alpha1.MutingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: workload.GetNamespace(),
+			Name:      RuleName(kind, workload.GetName()),
+			Labels: map[string]string{
+				rolloutLabel: "true",
+			},
+			Annotations: map[string]string{
+				generationAnnotation: strconv.FormatInt(workload.GetGeneration(), 10),
+			},
+		},
+		Spec: v1alpha1.MutingRuleSpec{
+			Name:        fmt.Sprintf("[NewRelic Operator] Rollout of %s %s/%s", kind, workload.GetNamespace(), workload.GetName()),
+			Description: fmt.Sprintf("Mutes alerts while %s %s/%s is rolled out", kind, workload.GetNamespace(), workload.GetName()),
+			Enabled:     &enabled,
+			Condition:   NewRuleCondition(settings),
+			Schedule: &v1alpha1.MutingRuleSchedule{
+				StartTime: start.Format(v1alpha1.MutingRuleTimeLayout),
+				EndTime:   start.Add(settings.MaxWindow).Format(v1alpha1.MutingRuleTimeLayout),
+				TimeZone:  scheduleTimeZone,
+			},
+		},
+	}
+}
+
+// NewRuleCondition matches the violations of the policies of the workload, restricted to its application when both are set
+func NewRuleCondition(settings domain.MuteSettings) v1alpha1.MutingRuleConditionGroup {
+	operator := v1alpha1.MutingRuleOperatorAnd
+	group := v1alpha1.MutingRuleConditionGroup{Operator: &operator}
+	if len(settings.Policies) > 0 {
+		condition := v1alpha1.MutingRuleCondition{
+			Attribute: v1alpha1.MutingRuleAttributePolicyId,
+			Operator:  "in",
+		}
+		for _, policy := range settings.Policies {
+			condition.PolicyRefs = append(condition.PolicyRefs, v1alpha1.PolicyReference{Name: policy})
+		}
+		group.Conditions = append(group.Conditions, condition)
+	}
+	if settings.Application != "" {
+		group.Conditions = append(group.Conditions, v1alpha1.MutingRuleCondition{
+			Attribute: targetNameAttribute,
+			Operator:  "equals",
+			Values:    []string{settings.Application},
+		})
+	}
+
+	return group
+}
+
+// NewMuteWindow reads the window of an existing rollout muting rule. Rules created by other means have no window
+func NewMuteWindow(rule *v1alpha1.MutingRule) *domain.MuteWindow {
+	if rule == nil || rule.Spec.Schedule == nil {
+		return nil
+	}
+
+	generation, err := strconv.ParseInt(rule.Annotations[generationAnnotation], 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	end, err := time.Parse(v1alpha1.MutingRuleTimeLayout, rule.Spec.Schedule.EndTime)
+	if err != nil {
+		return nil
+	}
+
+	return &domain.MuteWindow{
+		Generation: generation,
+		End:        end,
+		Expired:    rule.Spec.Enabled != nil && !*rule.Spec.Enabled,
+	}
+}
@@ -0,0 +1,63 @@
+package controller_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/controller"
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"testing"
+	"time"
+)
+
+func TestNewMutingRule(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Namespace = "shop"
+	deployment.Name = "checkout"
+	deployment.Generation = 4
+	settings := domain.MuteSettings{
+		Policies:    []string{"checkout"},
+		Application: "checkout-api",
+		MaxWindow:   10 * time.Minute,
+	}
+	now := time.Date(2020, 6, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	rule := controller.NewMutingRule("Deployment", deployment, settings, now)
+
+	if rule.Namespace != "shop" || rule.Name != "deployment-checkout-rollout" {
+		t.Errorf("Expected rule shop/deployment-checkout-rollout, got %s/%s", rule.Namespace, rule.Name)
+	}
+	if rule.Spec.Schedule.StartTime != "2020-06-01T12:00:00" || rule.Spec.Schedule.EndTime != "2020-06-01T12:10:00" || rule.Spec.Schedule.TimeZone != "UTC" {
+		t.Errorf("Expected a ten minute window in UTC, got %+v", rule.Spec.Schedule)
+	}
+	conditions := rule.Spec.Condition.Conditions
+	if len(conditions) != 2 || conditions[0].PolicyRefs[0].Name != "checkout" || conditions[1].Values[0] != "checkout-api" {
+		t.Errorf("Expected the policy and application conditions, got %+v", conditions)
+	}
+	if errs := rule.Spec.Validate(field.NewPath("spec")); len(errs) > 0 {
+		t.Errorf("Expected a valid rule, got %v", errs)
+	}
+
+	window := controller.NewMuteWindow(rule)
+	expected := domain.MuteWindow{Generation: 4, End: now.Add(10 * time.Minute)}
+	if window == nil || window.Generation != expected.Generation || !window.End.Equal(expected.End) || window.Expired {
+		t.Errorf("Expected window %+v, got %+v", expected, window)
+	}
+
+	disabled := false
+	rule.Spec.Enabled = &disabled
+	if !controller.NewMuteWindow(rule).Expired {
+		t.Error("Expected the window of a disabled rule to be expired")
+	}
+}
+
+func TestNewMuteWindow_UnmanagedRule(t *testing.T) {
+	rule := controller.NewMutingRule("StatefulSet", &appsv1.StatefulSet{}, domain.MuteSettings{Application: "web"}, time.Now())
+	rule.Annotations = nil
+
+	if window := controller.NewMuteWindow(rule); window != nil {
+		t.Errorf("Expected no window, got %+v", window)
+	}
+	if window := controller.NewMuteWindow(nil); window != nil {
+		t.Errorf("Expected no window, got %+v", window)
+	}
+}
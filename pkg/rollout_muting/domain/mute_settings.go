@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The annotations of Deployments and StatefulSets which opt in to muting alerts during rollouts
+const (
+	// PoliciesAnnotation lists the comma separated names of the AlertPolicy resources in the namespace of the workload to mute
+	PoliciesAnnotation = "alerts.newrelic.io/rollout-mute-policies"
+	// ApplicationAnnotation is the name of the New Relic application whose violations are muted
+	ApplicationAnnotation = "alerts.newrelic.io/rollout-mute-application"
+	// MaxMinutesAnnotation limits how long alerts stay muted when a rollout does not complete
+	MaxMinutesAnnotation = "alerts.newrelic.io/rollout-mute-max-minutes"
+)
+
+// DefaultMaxWindow is used when a workload does not set the MaxMinutesAnnotation
+const DefaultMaxWindow = 15 * time.Minute
+
+// MuteSettings describe which violations are muted while a workload is rolled out
+type MuteSettings struct {
+	// Policies are the names of the AlertPolicy resources whose violations are muted
+	Policies []string
+	// Application restricts the muted violations to the ones of the New Relic application
+	Application string
+	// MaxWindow is the longest time violations are muted for a single rollout
+	MaxWindow time.Duration
+}
+
+// NewMuteSettings reads the settings from the annotations of a workload.
+// Workloads without policies or an application do not opt in, and nil is returned
+func NewMuteSettings(annotations map[string]string) (*MuteSettings, error) {
+	settings := &MuteSettings{
+		Application: strings.TrimSpace(annotations[ApplicationAnnotation]),
+		MaxWindow:   DefaultMaxWindow,
+	}
+	for _, policy := range strings.Split(annotations[PoliciesAnnotation], ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			settings.Policies = append(settings.Policies, policy)
+		}
+	}
+
+	if len(settings.Policies) == 0 && settings.Application == "" {
+		return nil, nil
+	}
+
+	if value, ok := annotations[MaxMinutesAnnotation]; ok {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes < 1 {
+			return nil, fmt.Errorf("annotation %s must be a positive number of minutes, got %q", MaxMinutesAnnotation, value)
+		}
+		settings.MaxWindow = time.Duration(minutes) * time.Minute
+	}
+
+	return settings, nil
+}
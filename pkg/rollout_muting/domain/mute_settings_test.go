@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMuteSettings(t *testing.T) {
+	settings, err := domain.NewMuteSettings(map[string]string{
+		domain.PoliciesAnnotation:    "checkout, payments,",
+		domain.ApplicationAnnotation: "checkout-api",
+		domain.MaxMinutesAnnotation:  "5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &domain.MuteSettings{
+		Policies:    []string{"checkout", "payments"},
+		Application: "checkout-api",
+		MaxWindow:   5 * time.Minute,
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, settings)
+	}
+}
+
+func TestNewMuteSettings_DefaultMaxWindow(t *testing.T) {
+	settings, err := domain.NewMuteSettings(map[string]string{domain.ApplicationAnnotation: "checkout-api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if settings.MaxWindow != domain.DefaultMaxWindow {
+		t.Errorf("Expected the default window, got %s", settings.MaxWindow)
+	}
+}
+
+func TestNewMuteSettings_NotAnnotated(t *testing.T) {
+	settings, err := domain.NewMuteSettings(map[string]string{
+		domain.PoliciesAnnotation:   " ",
+		domain.MaxMinutesAnnotation: "5",
+	})
+	if err != nil || settings != nil {
+		t.Errorf("Expected no settings, got %+v and %v", settings, err)
+	}
+}
+
+func TestNewMuteSettings_InvalidMaxMinutes(t *testing.T) {
+	for _, value := range []string{"0", "ten", "-1"} {
+		_, err := domain.NewMuteSettings(map[string]string{
+			domain.PoliciesAnnotation:   "checkout",
+			domain.MaxMinutesAnnotation: value,
+		})
+		if err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+}
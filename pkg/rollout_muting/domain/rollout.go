@@ -0,0 +1,57 @@
+package domain
+
+import appsv1 "k8s.io/api/apps/v1"
+
+// progressDeadlineExceeded is the reason of the Progressing condition of a Deployment whose rollout failed
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// IsDeploymentProgressing reports whether a rollout of the Deployment is in progress,
+// following the checks of `kubectl rollout status`. Rollouts which exceeded their progress deadline are no longer in progress
+func IsDeploymentProgressing(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return true
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == progressDeadlineExceeded {
+			return false
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.UpdatedReplicas < replicas ||
+		deployment.Status.Replicas > deployment.Status.UpdatedReplicas ||
+		deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas
+}
+
+// IsStatefulSetProgressing reports whether a rollout of the StatefulSet is in progress,
+// following the checks of `kubectl rollout status`. StatefulSets with the OnDelete strategy are never rolled out by Kubernetes
+func IsStatefulSetProgressing(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return true
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return true
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		return statefulSet.Status.UpdatedReplicas < replicas-*rollingUpdate.Partition
+	}
+
+	return statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	"testing"
+)
+
+func TestIsDeploymentProgressing(t *testing.T) {
+	tests := map[string]struct {
+		mutate   func(deployment *appsv1.Deployment)
+		expected bool
+	}{
+		"complete":            {mutate: func(d *appsv1.Deployment) {}, expected: false},
+		"new generation":      {mutate: func(d *appsv1.Deployment) { d.Generation = 3 }, expected: true},
+		"updating replicas":   {mutate: func(d *appsv1.Deployment) { d.Status.UpdatedReplicas = 1 }, expected: true},
+		"old replicas remain": {mutate: func(d *appsv1.Deployment) { d.Status.Replicas = 3 }, expected: true},
+		"unavailable":         {mutate: func(d *appsv1.Deployment) { d.Status.AvailableReplicas = 1 }, expected: true},
+		"deadline exceeded": {
+			mutate: func(d *appsv1.Deployment) {
+				d.Status.UpdatedReplicas = 1
+				d.Status.Conditions = []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+				}
+			},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		deployment := newDeployment()
+		test.mutate(deployment)
+		if actual := domain.IsDeploymentProgressing(deployment); actual != test.expected {
+			t.Errorf("%s: expected progressing to be %t", name, test.expected)
+		}
+	}
+}
+
+func TestIsStatefulSetProgressing(t *testing.T) {
+	tests := map[string]struct {
+		mutate   func(statefulSet *appsv1.StatefulSet)
+		expected bool
+	}{
+		"complete":         {mutate: func(s *appsv1.StatefulSet) {}, expected: false},
+		"new generation":   {mutate: func(s *appsv1.StatefulSet) { s.Generation = 3 }, expected: true},
+		"not ready":        {mutate: func(s *appsv1.StatefulSet) { s.Status.ReadyReplicas = 1 }, expected: true},
+		"updating":         {mutate: func(s *appsv1.StatefulSet) { s.Status.UpdateRevision = "web-2" }, expected: true},
+		"on delete update": {mutate: func(s *appsv1.StatefulSet) { s.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType; s.Generation = 3 }, expected: false},
+		"partition updated": {
+			mutate: func(s *appsv1.StatefulSet) {
+				partition := int32(1)
+				s.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+				s.Status.UpdateRevision = "web-2"
+				s.Status.UpdatedReplicas = 1
+			},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		statefulSet := newStatefulSet()
+		test.mutate(statefulSet)
+		if actual := domain.IsStatefulSetProgressing(statefulSet); actual != test.expected {
+			t.Errorf("%s: expected progressing to be %t", name, test.expected)
+		}
+	}
+}
+
+func newDeployment() *appsv1.Deployment {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{}
+	deployment.Generation = 2
+	deployment.Spec.Replicas = &replicas
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: 2,
+		Replicas:           2,
+		UpdatedReplicas:    2,
+		AvailableReplicas:  2,
+	}
+
+	return deployment
+}
+
+func newStatefulSet() *appsv1.StatefulSet {
+	replicas := int32(2)
+	statefulSet := &appsv1.StatefulSet{}
+	statefulSet.Generation = 2
+	statefulSet.Spec.Replicas = &replicas
+	statefulSet.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	statefulSet.Status = appsv1.StatefulSetStatus{
+		ObservedGeneration: 2,
+		Replicas:           2,
+		ReadyReplicas:      2,
+		UpdatedReplicas:    2,
+		CurrentRevision:    "web-1",
+		UpdateRevision:     "web-1",
+	}
+
+	return statefulSet
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Transition is a change to the muting of a workload. The values are used as the reasons of the recorded events
+type Transition string
+
+const (
+	TransitionNone Transition = ""
+	// TransitionMute starts muting violations when a rollout starts, or restarts the window when the workload changes during a rollout
+	TransitionMute Transition = "RolloutMuted"
+	// TransitionExpire stops muting violations of a rollout which did not complete within the maximum window
+	TransitionExpire Transition = "RolloutMuteExpired"
+	// TransitionUnmute removes the muting once the rollout completed
+	TransitionUnmute Transition = "RolloutUnmuted"
+)
+
+// MuteWindow is the time during which the violations of a workload are muted for one of its rollouts
+type MuteWindow struct {
+	// Generation is the generation of the workload whose rollout started the window
+	Generation int64
+	// End is the time after which violations are no longer muted
+	End time.Time
+	// Expired is set once the window ended before the rollout completed
+	Expired bool
+}
+
+// NextTransition returns the change to the current window of a workload. The window is nil when no violations are muted
+func NextTransition(progressing bool, generation int64, window *MuteWindow, now time.Time) Transition {
+	if !progressing {
+		if window != nil {
+			return TransitionUnmute
+		}
+		return TransitionNone
+	}
+
+	if window == nil || window.Generation != generation {
+		return TransitionMute
+	}
+
+	if !window.Expired && !now.Before(window.End) {
+		return TransitionExpire
+	}
+
+	return TransitionNone
+}
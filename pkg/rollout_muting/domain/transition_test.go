@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"github.com/personio/newrelic-alert-manager/pkg/rollout_muting/domain"
+	"testing"
+	"time"
+)
+
+func TestNextTransition(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	active := &domain.MuteWindow{Generation: 2, End: now.Add(time.Minute)}
+	ended := &domain.MuteWindow{Generation: 2, End: now}
+	expired := &domain.MuteWindow{Generation: 2, End: now, Expired: true}
+
+	tests := map[string]struct {
+		progressing bool
+		generation  int64
+		window      *domain.MuteWindow
+		expected    domain.Transition
+	}{
+		"rollout starts":                 {progressing: true, generation: 2, window: nil, expected: domain.TransitionMute},
+		"rollout continues":              {progressing: true, generation: 2, window: active, expected: domain.TransitionNone},
+		"workload changes during window": {progressing: true, generation: 3, window: active, expected: domain.TransitionMute},
+		"window ends":                    {progressing: true, generation: 2, window: ended, expected: domain.TransitionExpire},
+		"expired rollout continues":      {progressing: true, generation: 2, window: expired, expected: domain.TransitionNone},
+		"new rollout after expiry":       {progressing: true, generation: 3, window: expired, expected: domain.TransitionMute},
+		"rollout completes":              {progressing: false, generation: 2, window: active, expected: domain.TransitionUnmute},
+		"expired rollout completes":      {progressing: false, generation: 2, window: expired, expected: domain.TransitionUnmute},
+		"no rollout":                     {progressing: false, generation: 2, window: nil, expected: domain.TransitionNone},
+	}
+
+	for name, test := range tests {
+		actual := domain.NextTransition(test.progressing, test.generation, test.window, now)
+		if actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", name, test.expected, actual)
+		}
+	}
+}
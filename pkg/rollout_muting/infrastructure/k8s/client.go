@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+	"github.com/personio/newrelic-alert-manager/pkg/apis/alerts/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	client_go "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Client struct {
+	logr     logr.Logger
+	client   client_go.Client
+	recorder record.EventRecorder
+}
+
+func NewClient(logr logr.Logger, client client_go.Client, recorder record.EventRecorder) *Client {
+	return &Client{
+		logr:     logr,
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+// GetWorkload reads the Deployment or StatefulSet into the given object
+func (c *Client) GetWorkload(name types.NamespacedName, workload runtime.Object) error {
+	return c.client.Get(context.TODO(), name, workload)
+}
+
+// GetMutingRule returns nil when the rule does not exist
+func (c *Client) GetMutingRule(name types.NamespacedName) (*v1alpha1.MutingRule, error) {
+	var instance v1alpha1.MutingRule
+	err := c.client.Get(context.TODO(), name, &instance)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (c *Client) CreateMutingRule(rule *v1alpha1.MutingRule) error {
+	err := c.client.Create(context.TODO(), rule)
+	if err != nil {
+		c.logr.Error(err, "Error creating muting rule")
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) UpdateMutingRule(rule *v1alpha1.MutingRule) error {
+	err := c.client.Update(context.TODO(), rule)
+	if err != nil {
+		if errors.IsConflict(err) {
+			c.logr.Info("Conflict updating muting rule, retrying")
+		} else {
+			c.logr.Error(err, "Error updating muting rule")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteMutingRule deletes the rule, whose finalizer removes it from New Relic
+func (c *Client) DeleteMutingRule(rule *v1alpha1.MutingRule) error {
+	err := c.client.Delete(context.TODO(), rule)
+	if err != nil && !errors.IsNotFound(err) {
+		c.logr.Error(err, "Error deleting muting rule")
+		return err
+	}
+
+	return nil
+}
+
+// RecordEvent records an event on the workload
+func (c *Client) RecordEvent(workload runtime.Object, eventType string, reason string, message string) {
+	c.recorder.Event(workload, eventType, reason, message)
+}